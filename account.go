@@ -0,0 +1,84 @@
+package textrazor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithAccountCache memoizes GetAccount results for ttl, so the features needing the account
+// (limiters, validators...) share a single request. Concurrent calls on a stale cache wait for the same request.
+//
+// ForceRefresh bypasses the cache for a single call, and updates it.
+func WithAccountCache(ttl time.Duration) Option {
+	return func(c *Client) { c.accountCache = &accountCache{ttl: ttl} }
+}
+
+// ForceRefresh makes GetAccountContext query the API even if a cached account is still valid,
+// and analyses skip the cache of WithCache, the new analysis is stored
+func ForceRefresh() CallOption {
+	return func(o *callOptions) { o.forceRefresh = true }
+}
+
+type accountCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	account  *Account
+	expires  time.Time
+	inflight *accountCall
+}
+
+// accountCall is a GetAccount request shared by concurrent callers
+type accountCall struct {
+	done    chan struct{}
+	account *Account
+	err     error
+}
+
+// get returns a copy of the cached account, calling hit, or of the account returned by fetch.
+// The first caller on a stale cache runs fetch, the other callers wait for its result.
+func (ac *accountCache) get(ctx context.Context, force bool, fetch func() (*Account, error), hit func()) (*Account, error) {
+	ac.mu.Lock()
+	if !force && ac.account != nil && time.Now().Before(ac.expires) {
+		a := *ac.account
+		ac.mu.Unlock()
+		hit()
+		return &a, nil
+	}
+	if call := ac.inflight; call != nil && !force {
+		ac.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if call.err != nil {
+			return nil, call.err
+		}
+		a := *call.account
+		return &a, nil
+	}
+	call := &accountCall{done: make(chan struct{})}
+	ac.inflight = call
+	ac.mu.Unlock()
+
+	call.account, call.err = fetch()
+
+	ac.mu.Lock()
+	if call.err == nil {
+		ac.account = call.account
+		ac.expires = time.Now().Add(ac.ttl)
+	}
+	if ac.inflight == call {
+		ac.inflight = nil
+	}
+	ac.mu.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	a := *call.account
+	return &a, nil
+}
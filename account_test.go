@@ -0,0 +1,68 @@
+package textrazor
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Account cache tests
+
+func TestAccountCache(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithAccountCache(time.Hour))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			account, err := client.GetAccount()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			// callers get their own copy
+			account.Plan = "modified"
+		}()
+	}
+	wg.Wait()
+	if n := len(transport.Requests()); n != 1 {
+		t.Error("expect a single request, got", n)
+	}
+
+	account, err := client.GetAccount()
+	if err != nil || account.Plan == "modified" {
+		t.Error("expect the cached account to be unmodified, got", account, err)
+	}
+	if _, err := client.GetAccountContext(context.Background(), ForceRefresh()); err != nil {
+		t.Error(err)
+	}
+	if n := len(transport.Requests()); n != 2 {
+		t.Error("expect ForceRefresh to send a request, got", n)
+	}
+}
+
+func TestAccountCacheExpiration(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(
+		textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account},
+		textrazortest.Reply{Status: http.StatusInternalServerError, Body: textrazortest.Error},
+		textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account},
+	)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithAccountCache(time.Nanosecond))
+
+	for i, expectErr := range []bool{false, true, false} {
+		time.Sleep(time.Millisecond)
+		if _, err := client.GetAccount(); (err != nil) != expectErr {
+			t.Error("call", i, "expect error", expectErr, "got", err)
+		}
+	}
+	if n := len(transport.Requests()); n != 3 {
+		t.Error("expect a request per expired call, got", n)
+	}
+}
@@ -0,0 +1,137 @@
+package textrazor
+
+import "context"
+
+// TextAnalyzer is a minimal text analysis interface, implemented by Client and StaticAnalyzer,
+// so applications can swap or mix providers.
+//
+// The features specific to TextRazor remain reachable with a type assertion to *Client.
+type TextAnalyzer interface {
+	// Entities returns the entities found in text
+	Entities(ctx context.Context, text string) ([]Entity, error)
+	// Topics returns the topics of text
+	Topics(ctx context.Context, text string) ([]Topic, error)
+	// Categories returns the categories of text, for the classifiers configured by the implementation
+	Categories(ctx context.Context, text string) ([]ScoredCategory, error)
+	// Language returns the ISO-639-2 code of the language of text
+	Language(ctx context.Context, text string) (string, error)
+}
+
+// DefaultClassifier is the classifier used by Client.Categories when none is set with WithDefaultParams
+const DefaultClassifier = "textrazor_newscodes"
+
+// WithDefaultParams adds params to every analysis, unless they are set by the call, e.g. the classifiers used by Categories
+func WithDefaultParams(params Params) Option {
+	return func(c *Client) { c.defaultParams = params }
+}
+
+// withDefaultParams returns a copy of params completed with the default params of the client
+func (c *Client) withDefaultParams(params Params) Params {
+	if len(c.defaultParams) == 0 {
+		return params
+	}
+	p := copyParams(params)
+	for k, v := range c.defaultParams {
+		if _, ok := p[k]; !ok {
+			p[k] = append([]string(nil), v...)
+		}
+	}
+	return p
+}
+
+// Entities implements TextAnalyzer
+func (c *Client) Entities(ctx context.Context, text string) ([]Entity, error) {
+	a, err := c.AnalyzeTextContext(ctx, text, Params{"extractors": {"entities"}})
+	if err != nil {
+		return nil, err
+	}
+	return a.Entities, nil
+}
+
+// Topics implements TextAnalyzer
+func (c *Client) Topics(ctx context.Context, text string) ([]Topic, error) {
+	a, err := c.AnalyzeTextContext(ctx, text, Params{"extractors": {"topics"}})
+	if err != nil {
+		return nil, err
+	}
+	return a.Topics, nil
+}
+
+// Categories implements TextAnalyzer, with the classifiers set by WithDefaultParams or DefaultClassifier
+func (c *Client) Categories(ctx context.Context, text string) ([]ScoredCategory, error) {
+	params := Params{"extractors": {"entities"}}
+	if _, ok := c.defaultParams["classifiers"]; !ok {
+		params.Set("classifiers", DefaultClassifier)
+	}
+	a, err := c.AnalyzeTextContext(ctx, text, params)
+	if err != nil {
+		return nil, err
+	}
+	return a.Categories, nil
+}
+
+// Language implements TextAnalyzer
+func (c *Client) Language(ctx context.Context, text string) (string, error) {
+	a, err := c.AnalyzeTextContext(ctx, text, Params{"extractors": {"entities"}})
+	if err != nil {
+		return "", err
+	}
+	return a.Language, nil
+}
+
+// StaticAnalyzer is an offline TextAnalyzer returning the same analysis for every text,
+// for tests and development without an API key
+type StaticAnalyzer struct {
+	Analysis *Analysis
+	// Err is returned by every call when set
+	Err error
+}
+
+func (s *StaticAnalyzer) analysis(ctx context.Context) (*Analysis, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if s.Analysis == nil {
+		return &Analysis{}, nil
+	}
+	return s.Analysis.Clone(), nil
+}
+
+// Entities implements TextAnalyzer
+func (s *StaticAnalyzer) Entities(ctx context.Context, text string) ([]Entity, error) {
+	a, err := s.analysis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.Entities, nil
+}
+
+// Topics implements TextAnalyzer
+func (s *StaticAnalyzer) Topics(ctx context.Context, text string) ([]Topic, error) {
+	a, err := s.analysis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.Topics, nil
+}
+
+// Categories implements TextAnalyzer
+func (s *StaticAnalyzer) Categories(ctx context.Context, text string) ([]ScoredCategory, error) {
+	a, err := s.analysis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.Categories, nil
+}
+
+// Language implements TextAnalyzer
+func (s *StaticAnalyzer) Language(ctx context.Context, text string) (string, error) {
+	a, err := s.analysis(ctx)
+	if err != nil {
+		return "", err
+	}
+	return a.Language, nil
+}
@@ -0,0 +1,87 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			TextAnalyzer tests
+
+var (
+	_ TextAnalyzer = (*Client)(nil)
+	_ TextAnalyzer = (*StaticAnalyzer)(nil)
+)
+
+// sentForm returns the form sent by the i-th request of transport
+func sentForm(t *testing.T, transport *textrazortest.SequenceTransport, i int) Params {
+	req := transport.Requests()[i]
+	req.Body, _ = req.GetBody()
+	if err := req.ParseForm(); err != nil {
+		t.Fatal(err)
+	}
+	return Params(req.PostForm)
+}
+
+func TestClientTextAnalyzer(t *testing.T) {
+	var tests = []struct {
+		name              string
+		opts              []Option
+		expectClassifiers string
+		expectOverride    string
+	}{
+		{"default classifier", nil, DefaultClassifier, ""},
+		{"default params", []Option{WithDefaultParams(Params{"classifiers": {"custom"}, "languageOverride": {"fre"}})}, "custom", "fre"},
+	}
+
+	for _, tt := range tests {
+		transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisCategories})
+		var analyzer TextAnalyzer = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, tt.opts...)
+
+		categories, err := analyzer.Categories(context.Background(), testText)
+		if err != nil || len(categories) != 3 {
+			t.Error(tt.name, "expect 3 categories, got", categories, err)
+		}
+		language, err := analyzer.Language(context.Background(), testText)
+		if err != nil || language != "eng" {
+			t.Error(tt.name, "expect eng, got", language, err)
+		}
+
+		form := sentForm(t, transport, 0)
+		if form.Get("classifiers") != tt.expectClassifiers || form.Get("text") != testText {
+			t.Error(tt.name, "expect classifiers", tt.expectClassifiers, "got", form)
+		}
+		if form := sentForm(t, transport, 1); form.Get("languageOverride") != tt.expectOverride {
+			t.Error(tt.name, "expect the default params to be sent, got", form)
+		}
+	}
+}
+
+func TestStaticAnalyzer(t *testing.T) {
+	analysis := &Analysis{Language: "eng", Entities: []Entity{{EntityID: "BBC", Types: []string{"Organisation"}}}, Topics: []Topic{{Label: "Media"}}}
+	var analyzer TextAnalyzer = &StaticAnalyzer{Analysis: analysis}
+
+	entities, err := analyzer.Entities(context.Background(), "anything")
+	if err != nil || len(entities) != 1 || entities[0].EntityID != "BBC" {
+		t.Error("expect BBC, got", entities, err)
+	}
+	entities[0].Types[0] = "modified"
+	if analysis.Entities[0].Types[0] != "Organisation" {
+		t.Error("expect the static analysis not to be modified")
+	}
+	if topics, _ := analyzer.Topics(context.Background(), ""); len(topics) != 1 {
+		t.Error("expect 1 topic, got", topics)
+	}
+	if language, _ := analyzer.Language(context.Background(), ""); language != "eng" {
+		t.Error("expect eng, got", language)
+	}
+
+	failing := &StaticAnalyzer{Err: errors.New("offline")}
+	if _, err := failing.Categories(context.Background(), ""); err != failing.Err {
+		t.Error("expect the configured error, got", err)
+	}
+}
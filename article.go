@@ -0,0 +1,104 @@
+package textrazor
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// ArticleSeparator is the marker between the title and the body of an article analyzed in a single request
+const ArticleSeparator = "\n\n"
+
+// DefaultTitleBoost is the factor applied to the relevance score of entities found in the title of an article
+const DefaultTitleBoost = 1.5
+
+// ArticleOptions defines how AnalyzeArticle analyzes a title and a body
+type ArticleOptions struct {
+	// Separate analyzes the title and the body in 2 requests merged with MergeAnalyses,
+	// instead of a single request for the title and the body joined with ArticleSeparator
+	Separate bool
+	// TitleBoost multiplies the relevance score of the entities found in the title,
+	// including their mentions in the body, scores are capped to 1. 0 means DefaultTitleBoost
+	TitleBoost float64
+}
+
+// AnalyzeArticle returns a single analysis of the title and the body of an article,
+// where the entities of the title are more relevant
+func (c *Client) AnalyzeArticle(title, body string, params Params, o ArticleOptions) (*Analysis, error) {
+	return c.AnalyzeArticleContext(context.Background(), title, body, params, o)
+}
+
+// AnalyzeArticleContext is like AnalyzeArticle with a context
+func (c *Client) AnalyzeArticleContext(ctx context.Context, title, body string, params Params, o ArticleOptions, opts ...CallOption) (*Analysis, error) {
+	var analysis *Analysis
+	if o.Separate {
+		parts := make([]*Analysis, 2)
+		for i, text := range []string{title, body} {
+			p := copyParams(params)
+			part, err := c.AnalyzeTextContext(ctx, text, p, opts...)
+			if err != nil {
+				return nil, err
+			}
+			// the merged offsets need the length of each part
+			if part.CleanedText == "" && part.RawText == "" {
+				part.RawText = text
+			}
+			parts[i] = part
+		}
+		analysis = MergeAnalyses(parts...)
+	} else {
+		var err error
+		analysis, err = c.AnalyzeTextContext(ctx, title+ArticleSeparator+body, copyParams(params), opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	boost := o.TitleBoost
+	if boost == 0 {
+		boost = DefaultTitleBoost
+	}
+	boostTitleEntities(analysis, utf8.RuneCountInString(title), boost)
+	return analysis, nil
+}
+
+// boostTitleEntities multiplies the relevance score of the entities ending before titleEnd,
+// and of the other mentions of the same entities
+func boostTitleEntities(a *Analysis, titleEnd int, boost float64) {
+	inTitle := map[string]bool{}
+	for _, e := range a.Entities {
+		if e.EndingPos <= titleEnd {
+			inTitle[entityKey(e)] = true
+		}
+	}
+	for i := range a.Entities {
+		e := &a.Entities[i]
+		if !inTitle[entityKey(*e)] {
+			continue
+		}
+		e.RelevanceScore *= boost
+		if e.RelevanceScore > 1 {
+			e.RelevanceScore = 1
+		}
+	}
+}
+
+// entityKey identifies the mentions of the same entity
+func entityKey(e Entity) string {
+	switch {
+	case e.EntityID != "":
+		return e.EntityID
+	case e.CustomEntityID != "":
+		return "custom:" + e.CustomEntityID
+	}
+	return "text:" + strings.ToLower(e.MatchedText)
+}
+
+// copyParams returns a copy of params, as Analyze* methods set the text in the given Params
+func copyParams(params Params) Params {
+	p := make(Params, len(params))
+	for k, v := range params {
+		p[k] = append([]string(nil), v...)
+	}
+	return p
+}
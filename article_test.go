@@ -0,0 +1,92 @@
+package textrazor
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			AnalyzeArticle tests
+
+const testTitle = "BBC investigates Barclays"
+
+var titleAnalysis = `{"ok":true,"response":{"entities":[
+	{"id":0,"entityId":"BBC","matchedText":"BBC","matchingTokens":[0],"startingPos":0,"endingPos":3,"relevanceScore":0.4},
+	{"id":1,"entityId":"Barclays","matchedText":"Barclays","matchingTokens":[2],"startingPos":17,"endingPos":25,"relevanceScore":0.8}]}}`
+
+func TestAnalyzeArticle(t *testing.T) {
+	// the same entities, at the offsets they have in the text sent in a single request
+	shift := len(testTitle + ArticleSeparator)
+	joinedAnalysis := fmt.Sprintf(`{"ok":true,"response":{"entities":[
+	{"id":0,"entityId":"BBC","matchedText":"BBC","startingPos":0,"endingPos":3,"relevanceScore":0.4},
+	{"id":1,"entityId":"Barclays","matchedText":"Barclays","startingPos":%d,"endingPos":%d,"relevanceScore":0.5},
+	{"id":2,"entityId":"Panorama","matchedText":"Panorama","startingPos":%d,"endingPos":%d,"relevanceScore":0.3}]}}`, shift, shift+8, shift+110, shift+118)
+
+	var tests = []struct {
+		name     string
+		options  ArticleOptions
+		replies  []textrazortest.Reply
+		requests int
+		expect   []float64
+	}{
+		{"joined", ArticleOptions{}, []textrazortest.Reply{{Status: http.StatusOK, Body: joinedAnalysis}}, 1,
+			[]float64{0.6, 0.5, 0.3}},
+		{"separate", ArticleOptions{Separate: true, TitleBoost: 2}, []textrazortest.Reply{{Status: http.StatusOK, Body: titleAnalysis}, {Status: http.StatusOK, Body: textrazortest.AnalysisEntities}}, 2,
+			[]float64{0.8, 1, 1, 0.8902, 0.3877, 0.5127}},
+	}
+
+	for _, tt := range tests {
+		transport := textrazortest.NewSequenceTransport(tt.replies...)
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+		params := Params{"extractors": {"entities"}}
+
+		analysis, err := client.AnalyzeArticle(testTitle, textrazortest.Text, params, tt.options)
+		if err != nil {
+			t.Fatal(tt.name, err)
+		}
+		if params.Get("text") != "" {
+			t.Error(tt.name, "expect params not to be modified")
+		}
+		if len(transport.Requests()) != tt.requests {
+			t.Error(tt.name, "expect", tt.requests, "requests, got", len(transport.Requests()))
+		}
+		if len(analysis.Entities) != len(tt.expect) {
+			t.Fatal(tt.name, "expect", len(tt.expect), "entities, got", analysis.Entities)
+		}
+		for i, e := range analysis.Entities {
+			if fmt.Sprintf("%.4f", e.RelevanceScore) != fmt.Sprintf("%.4f", tt.expect[i]) {
+				t.Error(tt.name, "expect", e.EntityID, "relevance", tt.expect[i], "got", e.RelevanceScore)
+			}
+		}
+	}
+}
+
+func TestAnalyzeArticleSeparateOffsets(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: titleAnalysis}, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	analysis, err := client.AnalyzeArticle(testTitle, textrazortest.Text, Params{"extractors": {"entities"}}, ArticleOptions{Separate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.RawText != testTitle+MergeSeparator+textrazortest.Text {
+		t.Error("expect the raw text of the article, got", analysis.RawText)
+	}
+	for _, e := range analysis.Entities {
+		if analysis.RawText[e.StartingPos:e.EndingPos] != e.MatchedText {
+			t.Error("expect", e.MatchedText, "at", e.StartingPos, "got", analysis.RawText[e.StartingPos:e.EndingPos])
+		}
+	}
+
+	for i, text := range []string{testTitle, textrazortest.Text} {
+		req := transport.Requests()[i]
+		req.Body, _ = req.GetBody()
+		req.ParseForm()
+		if req.PostForm.Get("text") != text {
+			t.Error("expect request", i, "to send", text, "got", req.PostForm.Get("text"))
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package textrazor
+
+import (
+	"sort"
+	"strings"
+)
+
+// SentenceEvidence is the support of a sentence for a topic or a category
+type SentenceEvidence struct {
+	// Sentence is the index of the sentence in Analysis.Sentences
+	Sentence int
+	// StartingPos and EndingPos are the character offsets of the sentence
+	StartingPos int
+	EndingPos   int
+	// Score is the sum of the relevance scores of the supporting entities
+	Score float64
+	// Entities are the indexes of the supporting entities in Analysis.Entities
+	Entities []int
+}
+
+// TopicAttribution lists the sentences supporting a topic, the best supporting sentence first
+type TopicAttribution struct {
+	Topic    Topic
+	Evidence []SentenceEvidence
+}
+
+// CategoryAttribution lists the sentences supporting a category, the best supporting sentence first
+type CategoryAttribution struct {
+	Category ScoredCategory
+	Evidence []SentenceEvidence
+}
+
+// AttributeTopics attributes the topics of the analysis to the sentences whose entities support them,
+// for explainability. An entity supports a topic with the same Wikidata id, or named like the topic.
+//
+// It needs the entities, the topics and the sentences (returned by the 'words' extractor) of the analysis.
+func (a *Analysis) AttributeTopics() []TopicAttribution {
+	sentences := a.sentenceIndex()
+	attributions := make([]TopicAttribution, len(a.Topics))
+	for i, t := range a.Topics {
+		attributions[i] = TopicAttribution{Topic: t, Evidence: a.evidence(sentences, func(e Entity) bool { return supportsTopic(e, t) })}
+	}
+	return attributions
+}
+
+// AttributeCategories attributes the categories of the analysis to sentences, through the topics:
+// an entity supports a category if it supports a topic whose label, or one of its words, is part of the category label.
+//
+// e.g. the topic "Mass media" links the entities about media to the category "science and technology>media"
+func (a *Analysis) AttributeCategories() []CategoryAttribution {
+	sentences := a.sentenceIndex()
+	attributions := make([]CategoryAttribution, len(a.Categories))
+	for i, c := range a.Categories {
+		var topics []Topic
+		for _, t := range a.Topics {
+			if labelMatches(c.Label, t.Label) {
+				topics = append(topics, t)
+			}
+		}
+		attributions[i] = CategoryAttribution{Category: c, Evidence: a.evidence(sentences, func(e Entity) bool {
+			for _, t := range topics {
+				if supportsTopic(e, t) {
+					return true
+				}
+			}
+			return false
+		})}
+	}
+	return attributions
+}
+
+// sentenceIndex maps word positions to sentence indexes
+func (a *Analysis) sentenceIndex() map[int]int {
+	index := map[int]int{}
+	for i, s := range a.Sentences {
+		for _, w := range s.Words {
+			index[w.Position] = i
+		}
+	}
+	return index
+}
+
+// evidence returns the sentences holding the entities matched by supports, the best supporting sentence first
+func (a *Analysis) evidence(sentences map[int]int, supports func(Entity) bool) []SentenceEvidence {
+	bySentence := map[int]*SentenceEvidence{}
+	for i, e := range a.Entities {
+		if !supports(e) {
+			continue
+		}
+		counted := map[int]bool{}
+		for _, p := range e.MatchingTokens {
+			s, ok := sentences[p]
+			if !ok || counted[s] {
+				continue
+			}
+			counted[s] = true
+			ev, ok := bySentence[s]
+			if !ok {
+				words := a.Sentences[s].Words
+				ev = &SentenceEvidence{Sentence: s, StartingPos: words[0].StartingPos, EndingPos: words[len(words)-1].EndingPos}
+				bySentence[s] = ev
+			}
+			ev.Score += e.RelevanceScore
+			ev.Entities = append(ev.Entities, i)
+		}
+	}
+
+	evidence := make([]SentenceEvidence, 0, len(bySentence))
+	for _, ev := range bySentence {
+		evidence = append(evidence, *ev)
+	}
+	sort.Slice(evidence, func(i, j int) bool {
+		if evidence[i].Score != evidence[j].Score {
+			return evidence[i].Score > evidence[j].Score
+		}
+		return evidence[i].Sentence < evidence[j].Sentence
+	})
+	return evidence
+}
+
+// supportsTopic reports whether an entity supports a topic
+func supportsTopic(e Entity, t Topic) bool {
+	if e.WikidataID != "" && e.WikidataID == t.WikidataID {
+		return true
+	}
+	for _, name := range []string{e.EntityEnglishID, e.EntityID} {
+		if name != "" && strings.EqualFold(name, t.Label) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelMatches reports whether a topic label, or one of its words, is part of a category label
+func labelMatches(category, topic string) bool {
+	category = strings.ToLower(category)
+	for _, w := range append([]string{topic}, strings.Fields(topic)...) {
+		w = strings.ToLower(w)
+		// short words like "of" or "and" would match most categories
+		if len(w) >= 4 && containsWord(category, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether s contains w, not as a part of a longer word
+func containsWord(s, w string) bool {
+	isLetter := func(b byte) bool { return b >= 'a' && b <= 'z' }
+	for i := strings.Index(s, w); i >= 0; {
+		end := i + len(w)
+		if (i == 0 || !isLetter(s[i-1])) && (end == len(s) || !isLetter(s[end])) {
+			return true
+		}
+		next := strings.Index(s[i+1:], w)
+		if next < 0 {
+			return false
+		}
+		i += 1 + next
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package textrazor
+
+import (
+	"testing"
+)
+
+//***************************************************************
+// 			Sentence attribution tests
+
+func attributionAnalysis() *Analysis {
+	return &Analysis{
+		Sentences: []Sentence{
+			{Words: []Word{{Position: 0, StartingPos: 0, EndingPos: 8, Token: "Barclays"}, {Position: 1, StartingPos: 9, EndingPos: 15, Token: "misled"}, {Position: 2, StartingPos: 16, EndingPos: 28, Token: "shareholders"}}},
+			{Words: []Word{{Position: 3, StartingPos: 30, EndingPos: 33, Token: "BBC"}, {Position: 4, StartingPos: 34, EndingPos: 42, Token: "Panorama"}, {Position: 5, StartingPos: 43, EndingPos: 48, Token: "found"}}},
+			{Words: []Word{{Position: 6, StartingPos: 50, EndingPos: 53, Token: "The"}, {Position: 7, StartingPos: 54, EndingPos: 57, Token: "BBC"}}},
+		},
+		Entities: []Entity{
+			{EntityID: "Barclays", WikidataID: "Q245343", MatchingTokens: []int{0}, RelevanceScore: 0.7},
+			{EntityID: "BBC", WikidataID: "Q9531", MatchingTokens: []int{3}, RelevanceScore: 0.4},
+			{EntityID: "Panorama (TV programme)", MatchingTokens: []int{4}, RelevanceScore: 0.3},
+			{EntityID: "BBC", WikidataID: "Q9531", MatchingTokens: []int{7}, RelevanceScore: 0.5},
+			{EntityID: "Mass media", MatchingTokens: []int{4, 5}, RelevanceScore: 0.2},
+		},
+		Topics: []Topic{
+			{Label: "Barclays", WikidataID: "Q245343"},
+			{Label: "BBC", WikidataID: "Q9531"},
+			{Label: "Mass media", WikidataID: "Q11033"},
+			{Label: "Banking", WikidataID: "Q22687"},
+		},
+		Categories: []ScoredCategory{
+			{Label: "science and technology>media"},
+			{Label: "economy, business and finance>financial and business service>banking"},
+		},
+	}
+}
+
+func TestAttributeTopics(t *testing.T) {
+	attributions := attributionAnalysis().AttributeTopics()
+	var tests = []struct {
+		topic          string
+		expectSentence []int
+	}{
+		{"Barclays", []int{0}},
+		{"BBC", []int{2, 1}},
+		{"Mass media", []int{1}},
+		{"Banking", nil},
+	}
+
+	if len(attributions) != len(tests) {
+		t.Fatal("expect an attribution per topic, got", attributions)
+	}
+	for i, tt := range tests {
+		a := attributions[i]
+		if a.Topic.Label != tt.topic || len(a.Evidence) != len(tt.expectSentence) {
+			t.Error("expect", tt.topic, "in sentences", tt.expectSentence, "got", a)
+			continue
+		}
+		for j, s := range tt.expectSentence {
+			if a.Evidence[j].Sentence != s {
+				t.Error("expect", tt.topic, "in sentences", tt.expectSentence, "got", a.Evidence)
+			}
+		}
+	}
+
+	bbc := attributions[1].Evidence[1]
+	if bbc.StartingPos != 30 || bbc.EndingPos != 48 || len(bbc.Entities) != 1 || bbc.Entities[0] != 1 || bbc.Score != 0.4 {
+		t.Errorf("expect BBC evidence in sentence 1, got %+v", bbc)
+	}
+}
+
+func TestAttributeCategories(t *testing.T) {
+	attributions := attributionAnalysis().AttributeCategories()
+	if len(attributions) != 2 {
+		t.Fatal("expect an attribution per category, got", attributions)
+	}
+	if ev := attributions[0].Evidence; len(ev) != 1 || ev[0].Sentence != 1 || len(ev[0].Entities) != 1 || ev[0].Entities[0] != 4 {
+		t.Error("expect media to be supported by sentence 1, got", ev)
+	}
+	if ev := attributions[1].Evidence; len(ev) != 0 {
+		t.Error("expect banking to be unsupported, got", ev)
+	}
+}
+
+func TestLabelMatches(t *testing.T) {
+	var tests = []struct {
+		category, topic string
+		expect          bool
+	}{
+		{"science and technology>media", "Mass media", true},
+		{"science and technology>media", "Social media", true},
+		{"science and technology>media", "Media", true},
+		{"science and technology>multimedia", "Media", false},
+		{"economy, business and finance", "Business", true},
+		{"economy, business and finance", "Art and entertainment", false},
+	}
+	for _, tt := range tests {
+		if got := labelMatches(tt.category, tt.topic); got != tt.expect {
+			t.Error(tt.category, tt.topic, "expect", tt.expect, "got", got)
+		}
+	}
+}
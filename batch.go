@@ -0,0 +1,64 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// BatchResult is the analysis of a document of AnalyzeBatch
+type BatchResult struct {
+	Document Document
+	// Analysis is nil when the analysis failed
+	Analysis *Analysis
+	Err      error
+}
+
+// AnalyzeBatchError is returned by AnalyzeBatch when the analysis of some documents failed
+type AnalyzeBatchError struct {
+	// Failed are the results of the failed documents, in the order of the documents
+	Failed []BatchResult
+	// Total is the number of documents of the batch
+	Total int
+}
+
+func (e *AnalyzeBatchError) Error() string {
+	msgs := make([]string, len(e.Failed))
+	for i, r := range e.Failed {
+		msgs[i] = fmt.Sprintf("document '%s': %v", r.Document.ID, r.Err)
+	}
+	return fmt.Sprintf("%d of %d documents failed: %s", len(e.Failed), e.Total, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed documents, so errors.Is and errors.As match any of them
+func (e *AnalyzeBatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, r := range e.Failed {
+		errs[i] = r.Err
+	}
+	return errs
+}
+
+// AnalyzeBatch analyzes documents with the same params on a pool of workers, see CallConcurrency,
+// and returns a result per document, in the order of docs. The concurrency limit of the client, if any, still applies.
+//
+// A failed analysis doesn't stop the others: its result holds the error, and the error is an *AnalyzeBatchError.
+// The documents not analyzed yet when ctx is done fail with the error of ctx.
+func (c *Client) AnalyzeBatch(ctx context.Context, docs []Document, params Params, opts ...CallOption) ([]BatchResult, error) {
+	analyses, errs := c.analyzeAll(ctx, len(docs), func(i int) (*Analysis, error) {
+		return c.AnalyzeTextContext(ctx, docs[i].Text, copyParams(params), opts...)
+	}, opts...)
+
+	results := make([]BatchResult, len(docs))
+	batchErr := &AnalyzeBatchError{Total: len(docs)}
+	for i, d := range docs {
+		results[i] = BatchResult{Document: d, Analysis: analyses[i], Err: errs[i]}
+		if errs[i] != nil {
+			batchErr.Failed = append(batchErr.Failed, results[i])
+		}
+	}
+	if len(batchErr.Failed) > 0 {
+		return results, batchErr
+	}
+	return results, nil
+}
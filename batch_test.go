@@ -0,0 +1,79 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+//***************************************************************
+// 			AnalyzeBatch tests
+
+func TestAnalyzeBatch(t *testing.T) {
+	transport := &classifyingTransport{categories: map[string][]ScoredCategory{
+		"football": {{CategoryID: "1", Label: "football"}},
+		"rugby":    {{CategoryID: "2", Label: "rugby"}},
+	}}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &lockedTransport{rt: transport})
+
+	docs := []Document{{"d1", "rugby"}, {"d2", "cinema"}, {"d3", "football"}, {"d4", "opera"}}
+	results, err := client.AnalyzeBatch(context.Background(), docs, Params{"extractors": {"entities"}}, CallConcurrency(2))
+
+	var batchErr *AnalyzeBatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Failed) != 2 || batchErr.Total != 4 {
+		t.Fatal("expect the analyses of cinema and opera to fail, got", err)
+	}
+	if !strings.HasPrefix(err.Error(), "2 of 4 documents failed: document 'd2': ") || !strings.Contains(err.Error(), "; document 'd4': ") {
+		t.Error("unexpected error message", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Error("expect the API errors to be unwrapped, got", err)
+	}
+
+	if len(results) != len(docs) {
+		t.Fatalf("expect %d results, got %d", len(docs), len(results))
+	}
+	for i, r := range results {
+		if r.Document != docs[i] {
+			t.Errorf("expect the document %v at index %d, got %v", docs[i], i, r.Document)
+		}
+		failed := r.Document.Text == "cinema" || r.Document.Text == "opera"
+		switch {
+		case failed && (r.Err == nil || r.Analysis != nil):
+			t.Errorf("expect %s to fail, got %v %v", r.Document.ID, r.Analysis, r.Err)
+		case !failed && (r.Err != nil || len(r.Analysis.Categories) != 1 || r.Analysis.Categories[0].Label != r.Document.Text):
+			t.Errorf("expect the analysis of %s, got %v %v", r.Document.ID, r.Analysis, r.Err)
+		}
+	}
+}
+
+func TestAnalyzeBatchConcurrency(t *testing.T) {
+	transport := &concurrencyTransport{}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithConcurrencyLimit(2))
+
+	docs := make([]Document, 10)
+	for i := range docs {
+		docs[i] = Document{Text: testText}
+	}
+	results, err := client.AnalyzeBatch(context.Background(), docs, Params{"extractors": {"entities"}}, CallConcurrency(5))
+	if err != nil || len(results) != len(docs) {
+		t.Fatal(err)
+	}
+	if max := atomic.LoadInt32(&transport.max); max != 2 {
+		t.Error("expect the concurrency limit of the client to apply, got", max)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = client.AnalyzeBatch(ctx, docs[:2], Params{"extractors": {"entities"}})
+	if !errors.Is(err, context.Canceled) || len(results) != 2 || !errors.Is(results[1].Err, context.Canceled) {
+		t.Error("expect every analysis to fail with the context, got", err)
+	}
+	if results, err := client.AnalyzeBatch(context.Background(), nil, nil); err != nil || len(results) != 0 {
+		t.Error("expect no results, got", results, err)
+	}
+}
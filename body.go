@@ -0,0 +1,160 @@
+package textrazor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrResponseTooLarge is returned when a response body exceeds the size set by WithMaxResponseSize
+var ErrResponseTooLarge = errors.New("response too large")
+
+// WithMaxResponseSize fails the calls whose response body, once decompressed, exceeds n bytes with ErrResponseTooLarge,
+// e.g. to bound the memory used by the analyses of arbitrary URLs. Responses are unlimited by default.
+//
+// The size is checked as the response is read, so the call fails as soon as the limit is reached.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) { c.maxResponseSize = n }
+}
+
+// WithResponseBody sets whether HTTPResponse.Body keeps the raw body of the successful responses,
+// DefaultKeepResponseBody by default. Discarding it saves memory when many analyses are held,
+// Status, Headers, Time and Meta are kept. The body of failed responses is always kept.
+//
+// Discarding the body doesn't lower the peak memory of the decoding: json.Decoder still buffers the whole response
+// before decoding it, the saving is the body no longer held by the response afterwards. The body is read
+// and kept anyway while the client needs it, e.g. for its cache, its sampler or strict decoding.
+func WithResponseBody(keep bool) Option {
+	return func(c *Client) { c.keepBody = keep }
+}
+
+// bodyNeeded reports whether the body of a successful response is needed once decoded
+func (c *Client) bodyNeeded() bool {
+	return c.keepBody || c.strictDecoding || c.cache != nil || c.sampler != nil || len(c.responseHooks) > 0
+}
+
+// discardBody drops the body of a response when the client doesn't keep them
+func (c *Client) discardBody(r *HTTPResponse) {
+	if r != nil && !c.keepBody {
+		r.Body = nil
+	}
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// limitedBody fails the reads beyond its remaining size with ErrResponseTooLarge
+type limitedBody struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining == 0 {
+		// json.Decoder ignores the errors returned with data, the limit is reported once it's reached
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// bodyReadError marks the errors of the reads of a response body, to tell them from the decoding errors
+type bodyReadError struct{ err error }
+
+func (e *bodyReadError) Error() string { return e.err.Error() }
+
+// bodyReader wraps the read errors of a body in bodyReadError
+type bodyReader struct{ r io.Reader }
+
+func (b bodyReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err != nil && err != io.EOF {
+		err = &bodyReadError{err}
+	}
+	return n, err
+}
+
+// responseDecodeError is the decoding error of a response body read entirely
+type responseDecodeError struct{ err error }
+
+func (e *responseDecodeError) Error() string { return e.err.Error() }
+
+// decodeResponse decodes the body of a response into r, decompressing it when needed, and keeps the body in r.Body
+// when keep is set. A body which isn't kept is decoded by a json.Decoder, a kept body is read then decoded from its bytes
+// so it isn't buffered twice. The decoding errors are returned as *responseDecodeError, after the body is read.
+func (c *Client) decodeResponse(resp *http.Response, r *HTTPResponse, keep bool) error {
+	wire := &countingReader{r: resp.Body}
+	defer func() { r.Meta.WireBytes = wire.n }()
+
+	body, err := decompressBody(resp, bufio.NewReader(wire), &r.Meta)
+	if err != nil {
+		return err
+	}
+	if c.maxResponseSize > 0 {
+		body = &limitedBody{r: body, remaining: c.maxResponseSize}
+	}
+	body = bodyReader{body}
+
+	var decodeErr error
+	if keep {
+		var kept bytes.Buffer
+		if resp.ContentLength > 0 && !r.Meta.Decompressed && (c.maxResponseSize <= 0 || resp.ContentLength <= c.maxResponseSize) {
+			kept.Grow(int(resp.ContentLength) + bytes.MinRead)
+		}
+		_, err = kept.ReadFrom(body)
+		r.Body = kept.Bytes()
+		if err == nil {
+			decodeErr = json.Unmarshal(r.Body, r)
+		}
+	} else {
+		dec := json.NewDecoder(body)
+		decodeErr = dec.Decode(r)
+		if decodeErr == nil {
+			// the body holds a single value, like with json.Unmarshal
+			if _, err := dec.Token(); err != io.EOF {
+				decodeErr = errors.New("invalid data after top-level value")
+			}
+		}
+		// the rest of the body is read, e.g. the message of an error response which isn't JSON
+		_, err = io.Copy(io.Discard, body)
+	}
+
+	var readErr *bodyReadError
+	switch {
+	case errors.As(decodeErr, &readErr):
+	case !errors.As(err, &readErr):
+		if decodeErr != nil {
+			return &responseDecodeError{decodeErr}
+		}
+		return nil
+	}
+	switch err = readErr.err; {
+	case errors.Is(err, ErrResponseTooLarge):
+		return fmt.Errorf("http response body read failed: %w, limit of %d bytes", err, c.maxResponseSize)
+	case errors.Is(err, errDecompression):
+		return err
+	}
+	return fmt.Errorf("http response body read failed: %w", err)
+}
@@ -0,0 +1,165 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Response body tests
+
+// gzipTransport replies with a gzip encoded body
+type gzipTransport struct{ body []byte }
+
+func (t gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Encoding": {"gzip"}},
+		Body: io.NopCloser(strings.NewReader(string(t.body))), Request: req}, nil
+}
+
+// brokenTransport replies with a body failing after its first bytes
+type brokenTransport struct{}
+
+func (brokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := io.MultiReader(strings.NewReader(textrazortest.Account[:20]), &brokenReader{})
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(body), Request: req}, nil
+}
+
+type brokenReader struct{}
+
+func (*brokenReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestMaxResponseSize(t *testing.T) {
+	size := int64(len(textrazortest.Account))
+	var tests = []struct {
+		name      string
+		transport http.RoundTripper
+		limit     int64
+		tooLarge  bool
+	}{
+		{"unlimited", textrazortest.NewTransport(http.StatusOK, textrazortest.Account), 0, false},
+		{"at the limit", textrazortest.NewTransport(http.StatusOK, textrazortest.Account), size, false},
+		{"over the limit", textrazortest.NewTransport(http.StatusOK, textrazortest.Account), size - 1, true},
+		{"error over the limit", textrazortest.NewTransport(http.StatusInternalServerError, strings.Repeat("x", 100)), 10, true},
+		{"compressed at the limit", gzipTransport{gzipped(t, textrazortest.Account)}, size, false},
+		// the limit applies to the decompressed body
+		{"compressed over the limit", gzipTransport{gzipped(t, textrazortest.Account+strings.Repeat(" ", 1<<20))}, size + 1000, true},
+	}
+	for _, tt := range tests {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, tt.transport, WithMaxResponseSize(tt.limit))
+		account, err := client.GetAccount()
+		if tt.tooLarge {
+			if !errors.Is(err, ErrResponseTooLarge) {
+				t.Errorf("%s: expect ErrResponseTooLarge, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || account.Plan == "" || string(account.HTTPResponse.Body) != textrazortest.Account {
+			t.Errorf("%s: expect the decoded account and its body, got %+v %v", tt.name, account, err)
+		}
+	}
+}
+
+func TestResponseBodyErrors(t *testing.T) {
+	var tests = []struct {
+		name      string
+		transport http.RoundTripper
+		keep      bool
+		expect    string
+	}{
+		{"trailing data", textrazortest.NewTransport(http.StatusOK, textrazortest.Account+`{"ok": true}`), true, "http response body parsing failed: invalid character '{' after top-level value"},
+		{"trailing data streamed", textrazortest.NewTransport(http.StatusOK, textrazortest.Account+`{"ok": true}`), false, "http response body parsing failed: invalid data after top-level value"},
+		{"truncated", textrazortest.NewTransport(http.StatusOK, textrazortest.Account[:20]), true, "http response body parsing failed: unexpected end of JSON input"},
+		{"truncated streamed", textrazortest.NewTransport(http.StatusOK, textrazortest.Account[:20]), false, "http response body parsing failed: unexpected EOF"},
+		{"broken connection", brokenTransport{}, true, "http response body read failed: connection reset"},
+		{"broken connection streamed", brokenTransport{}, false, "http response body read failed: connection reset"},
+		{"corrupted gzip", gzipTransport{gzipped(t, textrazortest.Account)[:30]}, true, "gzip response decompression failed: unexpected EOF"},
+		{"corrupted gzip streamed", gzipTransport{gzipped(t, textrazortest.Account)[:30]}, false, "gzip response decompression failed: unexpected EOF"},
+	}
+	for _, tt := range tests {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, tt.transport, WithResponseBody(tt.keep))
+		if _, err := client.GetAccount(); err == nil || err.Error() != tt.expect {
+			t.Errorf("%s: expect the error %q, got %v", tt.name, tt.expect, err)
+		}
+	}
+
+	// the body of an error response which isn't JSON is kept whole
+	body := "<html>" + strings.Repeat("Bad Gateway ", 1000) + "</html>"
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusBadGateway, body))
+	_, err := client.GetAccount()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || string(apiErr.HTTPResponse.Body) != body {
+		t.Errorf("expect an API error with the whole body, got %v", err)
+	}
+}
+
+func TestResponseBody(t *testing.T) {
+	transport := textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisFull)
+	newClient := func(opts ...Option) *Client {
+		return NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, opts...)
+	}
+
+	a, err := newClient().AnalyzeText("text", Params{"extractors": {"entities"}})
+	if err != nil || string(a.HTTPResponse.Body) != textrazortest.AnalysisFull {
+		t.Errorf("expect the body to be kept by default, got %v", err)
+	}
+
+	a, err = newClient(WithResponseBody(false)).AnalyzeText("text", Params{"extractors": {"entities"}})
+	if err != nil || a.HTTPResponse.Body != nil || len(a.Entities) == 0 {
+		t.Fatalf("expect a decoded analysis without body, got %v", err)
+	}
+	if r := a.HTTPResponse; r.Status != http.StatusOK || r.Headers.Get("Content-Type") == "" || r.Time == 0 || !r.Ok {
+		t.Errorf("expect the status, headers and time to be kept, got %+v", r)
+	}
+
+	// the cache and the sampler still get the body
+	var archived []byte
+	sampler := NewSampler(1, SampleSinkFunc(func(ctx context.Context, s *ArchivedSample) error {
+		archived = s.Response
+		return nil
+	}))
+	client := newClient(WithResponseBody(false), WithCache(NewMemoryCache()), WithSampler(sampler))
+	for i := 0; i < 2; i++ {
+		a, err = client.AnalyzeText("text", Params{"extractors": {"entities"}})
+		if err != nil || a.HTTPResponse.Body != nil || len(a.Entities) == 0 {
+			t.Errorf("call %d: expect a decoded analysis without body, got %v", i, err)
+		}
+	}
+	if string(archived) != textrazortest.AnalysisFull {
+		t.Error("expect the sampler to archive the body")
+	}
+	if stats := client.Snapshot(); stats.Requests != 1 {
+		t.Errorf("expect the second analysis to be cached, got %+v", stats)
+	}
+
+	// failed responses keep their body
+	errorBody := `{"ok": false, "error": "Invalid extractor"}`
+	client = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint,
+		textrazortest.NewTransport(http.StatusBadRequest, errorBody), WithResponseBody(false))
+	var apiErr *APIError
+	if _, err := client.GetAccount(); !errors.As(err, &apiErr) || string(apiErr.HTTPResponse.Body) != errorBody {
+		t.Errorf("expect an API error with its body, got %v", err)
+	}
+}
+
+func BenchmarkDecodeResponse(b *testing.B) {
+	_, body := textrazortest.GenerateAnalysis(textrazortest.GenerateOptions{Seed: 1, Sentences: 4000, Entities: 10000, Topics: 100})
+	for name, keep := range map[string]bool{"kept": true, "discarded": false} {
+		b.Run(name, func(b *testing.B) {
+			client := NewCustomClient(testAPIKey, false, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint,
+				textrazortest.NewTransport(http.StatusOK, body), WithResponseBody(keep))
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
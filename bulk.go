@@ -0,0 +1,178 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Defaults of BulkOptions
+const (
+	DefaultBulkBatchSize   = 1000
+	DefaultBulkConcurrency = 2
+)
+
+// BulkOptions configures UploadDictionaryEntries, zero values use the defaults
+type BulkOptions struct {
+	// BatchSize is the number of entries sent per request
+	BatchSize int
+	// Concurrency is the maximum number of batches uploaded at the same time
+	Concurrency int
+	// Progress, if set, is called after each batch, from a single goroutine at a time
+	Progress func(BulkProgress)
+}
+
+// BulkProgress reports the progress of a bulk upload
+type BulkProgress struct {
+	Batches int
+	// Done counts the finished batches, including the Failed ones
+	Done   int
+	Failed int
+	// Entries is the total number of entries, Uploaded the number of entries of the succeeded batches
+	Entries  int
+	Uploaded int
+}
+
+// BatchError is the failure of a batch of a bulk upload
+type BatchError struct {
+	// Batch is the index of the batch, its entries start at Offset in the uploaded slice
+	Batch   int
+	Offset  int
+	Entries []DictionaryEntry
+	Err     error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch %d (entries %d to %d) failed: %v", e.Batch, e.Offset, e.Offset+len(e.Entries)-1, e.Err)
+}
+
+// Unwrap returns the error of the batch
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// BulkError is returned by UploadDictionaryEntries when some batches failed, the others are uploaded
+type BulkError struct {
+	// Errors are ordered by batch
+	Errors []*BatchError
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d batches failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed batches, so errors.Is and errors.As match any of them
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// UploadDictionaryEntries adds entries to a dictionary in batches, to stay below the request size limit of the API.
+// Batches are uploaded concurrently, a failed batch doesn't stop the others: the error is then a *BulkError
+// holding the entries to upload again.
+func (c *Client) UploadDictionaryEntries(ID string, entries []DictionaryEntry, o BulkOptions) (*BulkProgress, error) {
+	return c.UploadDictionaryEntriesContext(context.Background(), ID, entries, o)
+}
+
+// UploadDictionaryEntriesContext is like UploadDictionaryEntries with a context,
+// once the context is done the remaining batches fail with its error
+func (c *Client) UploadDictionaryEntriesContext(ctx context.Context, ID string, entries []DictionaryEntry, o BulkOptions, opts ...CallOption) (*BulkProgress, error) {
+	size := o.batchSize()
+	var batches []*BatchError
+	for offset := 0; offset < len(entries); offset += size {
+		end := offset + size
+		if end > len(entries) {
+			end = len(entries)
+		}
+		batches = append(batches, &BatchError{Batch: len(batches), Offset: offset, Entries: entries[offset:end]})
+	}
+
+	next := 0
+	return c.uploadBatches(ctx, ID, o, BulkProgress{Batches: len(batches), Entries: len(entries)}, func() (*BatchError, error) {
+		if next == len(batches) {
+			return nil, nil
+		}
+		next++
+		return batches[next-1], nil
+	}, opts...)
+}
+
+func (o *BulkOptions) batchSize() int {
+	if o.BatchSize <= 0 {
+		return DefaultBulkBatchSize
+	}
+	return o.BatchSize
+}
+
+// uploadBatches uploads the batches returned by next, until it returns nil or an error.
+// The totals of progress are counted as the batches come when they are unknown, i.e. 0.
+//
+// the error of next is returned once the started batches are done, with the errors of the failed batches
+func (c *Client) uploadBatches(ctx context.Context, ID string, o BulkOptions, progress BulkProgress, next func() (*BatchError, error), opts ...CallOption) (*BulkProgress, error) {
+	workers := o.Concurrency
+	if workers <= 0 {
+		workers = DefaultBulkConcurrency
+	}
+	counting := progress.Batches == 0
+
+	var (
+		mu      sync.Mutex
+		batches []*BatchError
+		err     error
+	)
+	// failed batches don't stop the others
+	g, _ := newGroup(ctx, workers, &c.stats.goroutines)
+	for {
+		var b *BatchError
+		if b, err = next(); b == nil || err != nil {
+			break
+		}
+		batches = append(batches, b)
+		if counting {
+			mu.Lock()
+			progress.Batches++
+			progress.Entries += len(b.Entries)
+			mu.Unlock()
+		}
+		g.Go(func() error {
+			if b.Err = ctx.Err(); b.Err == nil {
+				_, b.Err = c.AddDictionaryEntriesContext(ctx, ID, b.Entries, opts...)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			progress.Done++
+			if b.Err != nil {
+				progress.Failed++
+			} else {
+				progress.Uploaded += len(b.Entries)
+			}
+			if o.Progress != nil {
+				o.Progress(progress)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	var failed []*BatchError
+	for _, b := range batches {
+		if b.Err != nil {
+			failed = append(failed, b)
+		}
+	}
+	if len(failed) > 0 {
+		bulkErr := &BulkError{Errors: failed}
+		if err != nil {
+			return &progress, fmt.Errorf("%w, %w", err, bulkErr)
+		}
+		return &progress, bulkErr
+	}
+	return &progress, err
+}
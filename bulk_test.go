@@ -0,0 +1,99 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+//***************************************************************
+// 			Bulk upload tests
+
+func bulkEntries(n int) []DictionaryEntry {
+	entries := make([]DictionaryEntry, n)
+	for i := range entries {
+		entries[i] = DictionaryEntry{ID: fmt.Sprintf("E%03d", i), Text: fmt.Sprintf("entry %d", i)}
+	}
+	return entries
+}
+
+func TestUploadDictionaryEntries(t *testing.T) {
+	server := newDictionaryServer()
+	defer server.Close()
+
+	var calls []BulkProgress
+	progress, err := server.client().UploadDictionaryEntries(dictID, bulkEntries(25), BulkOptions{
+		BatchSize:   10,
+		Concurrency: 2,
+		Progress:    func(p BulkProgress) { calls = append(calls, p) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.writes != 3 || len(server.sorted()) != 25 {
+		t.Error("expect 25 entries uploaded in 3 batches, got", len(server.sorted()), "in", server.writes)
+	}
+	if *progress != (BulkProgress{Batches: 3, Done: 3, Entries: 25, Uploaded: 25}) {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+	if len(calls) != 3 || calls[2] != *progress {
+		t.Error("expect a progress report per batch, got", calls)
+	}
+}
+
+func TestUploadDictionaryEntriesErrors(t *testing.T) {
+	server := newDictionaryServer()
+	defer server.Close()
+
+	// fail the second request
+	var requests int32
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 2 {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			w.Write([]byte(`{"ok": false, "error": "Request too large"}`))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	entries := bulkEntries(6)
+	progress, err := server.client().UploadDictionaryEntries(dictID, entries, BulkOptions{BatchSize: 2, Concurrency: 1})
+
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 1 {
+		t.Fatal("expect a BulkError with a failed batch, got", err)
+	}
+	b := bulkErr.Errors[0]
+	if b.Batch != 1 || b.Offset != 2 || len(b.Entries) != 2 || b.Entries[0].ID != entries[2].ID {
+		t.Errorf("expect the second batch to fail, got %+v", b)
+	}
+	if !errors.Is(err, ErrRequestTooLarge) {
+		t.Error("expect the batch error to be matched, got", err)
+	}
+	if progress.Failed != 1 || progress.Uploaded != 4 || len(server.sorted()) != 4 {
+		t.Errorf("expect the other batches to be uploaded, got %+v", progress)
+	}
+}
+
+func TestUploadDictionaryEntriesCanceled(t *testing.T) {
+	server := newDictionaryServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	progress, err := server.client().UploadDictionaryEntriesContext(ctx, dictID, bulkEntries(4), BulkOptions{BatchSize: 1})
+	if !errors.Is(err, context.Canceled) || progress.Failed != 4 || server.writes != 0 {
+		t.Errorf("expect every batch to fail with the context, got %+v %v", progress, err)
+	}
+}
+
+func TestUploadDictionaryEntriesEmpty(t *testing.T) {
+	progress, err := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, 200, "", true)).UploadDictionaryEntries(dictID, nil, BulkOptions{})
+	if err != nil || progress.Batches != 0 {
+		t.Errorf("expect nothing to upload, got %+v %v", progress, err)
+	}
+}
@@ -0,0 +1,181 @@
+package textrazor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithCache stores the raw JSON responses of analyses in cache, an analysis with the same parameters
+// is then decoded from the cache without sending a request, nor using the daily quota.
+//
+// Cache failures are logged, see WithLogger, and the request is sent.
+// Cached analyses are kept forever unless a CachePolicy is set, see WithCachePolicy.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// cacheKey returns the key of an analysis in the cache, a hash of the endpoint and the parameters
+func (c *Client) cacheKey(params Params) string {
+	encoded, _ := params.Encode()
+	h := sha256.Sum256([]byte(c.endpointURL() + "\n" + encoded))
+	return hex.EncodeToString(h[:])
+}
+
+// CachePolicy decides when the analyses stored by WithCache are outdated and analyzed again
+type CachePolicy struct {
+	// TTL is how long an analysis stays valid, 0 means forever, it can be changed for a single call with CallCacheTTL
+	TTL time.Duration
+	// Version identifies the extraction settings, e.g. a custom classifier version,
+	// analyses stored with another version are analyzed again
+	Version string
+	// Reanalyze reports whether a valid stored analysis must be analyzed again, when set
+	Reanalyze func(e *CacheEntry) bool
+
+	now func() time.Time
+}
+
+// CacheEntry is an analysis stored in the cache with a CachePolicy
+type CacheEntry struct {
+	StoredAt time.Time `json:"storedAt"`
+	// ExpiresAt is zero when the analysis never expires
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+	Version   string    `json:"version,omitempty"`
+	// Body is the raw JSON response of the analysis
+	Body json.RawMessage `json:"body"`
+}
+
+// WithCachePolicy stores the analyses in the cache with their expiry and the version of the policy,
+// and analyzes them again when they are outdated, see CachePolicy.
+//
+// Responses stored without a policy are outdated when the policy has a TTL or a Version.
+func WithCachePolicy(p CachePolicy) Option {
+	return func(c *Client) {
+		if p.now == nil {
+			p.now = time.Now
+		}
+		c.cachePolicy = &p
+	}
+}
+
+// CallCacheTTL sets the TTL of the analysis of a single call in the cache, see WithCachePolicy
+func CallCacheTTL(d time.Duration) CallOption {
+	return func(o *callOptions) { o.cacheTTL = d }
+}
+
+// cachedAnalysis returns the analysis stored in the cache, or nil when it is missing or outdated
+func (c *Client) cachedAnalysis(ctx context.Context, key string, o *callOptions) *Analysis {
+	if o.forceRefresh {
+		return nil
+	}
+	value, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.logf(ctx, "cache read failed: %v", err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	body := value
+	if p := c.cachePolicy; p != nil {
+		entry := &CacheEntry{}
+		if !bytes.Contains(value, []byte(`"storedAt"`)) || json.Unmarshal(value, entry) != nil || len(entry.Body) == 0 {
+			// stored without a policy
+			entry = &CacheEntry{Body: value}
+		}
+		if p.outdated(entry) {
+			return nil
+		}
+		body = entry.Body
+	}
+
+	analysis := c.newAnalysis()
+	r := &HTTPResponse{Status: http.StatusOK, Headers: http.Header{}, Body: body, Response: analysis}
+	analysis.setHTTPResponse(r)
+	if err := r.ParseBody(); err != nil {
+		c.logf(ctx, "cached analysis decoding failed: %v", err)
+		return nil
+	}
+	return analysis
+}
+
+// outdated reports whether a stored analysis must be analyzed again
+func (p *CachePolicy) outdated(e *CacheEntry) bool {
+	if e.StoredAt.IsZero() && (p.TTL > 0 || p.Version != "") {
+		return true
+	}
+	if !e.ExpiresAt.IsZero() && !p.now().Before(e.ExpiresAt) {
+		return true
+	}
+	if e.Version != p.Version {
+		return true
+	}
+	return p.Reanalyze != nil && p.Reanalyze(e)
+}
+
+// cacheAnalysis stores the response of an analysis in the cache
+func (c *Client) cacheAnalysis(ctx context.Context, key string, a *Analysis, o *callOptions) {
+	if a.HTTPResponse == nil {
+		return
+	}
+	value := a.HTTPResponse.Body
+	if p := c.cachePolicy; p != nil {
+		entry := CacheEntry{StoredAt: p.now(), Version: p.Version, Body: value}
+		ttl := p.TTL
+		if o.cacheTTL > 0 {
+			ttl = o.cacheTTL
+		}
+		if ttl > 0 {
+			entry.ExpiresAt = entry.StoredAt.Add(ttl)
+		}
+		var err error
+		if value, err = json.Marshal(entry); err != nil {
+			c.logf(ctx, "cache entry encoding failed: %v", err)
+			return
+		}
+	}
+	if err := c.cache.Set(ctx, key, value); err != nil {
+		c.logf(ctx, "cache write failed: %v", err)
+	}
+}
+
+// MemoryCache is a Cache in memory, without eviction
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string][]byte
+}
+
+// NewMemoryCache returns an empty MemoryCache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string][]byte{}}
+}
+
+// Get implements Cache
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.entries[key]
+	return v, ok, nil
+}
+
+// Set implements Cache
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Len returns the number of cached responses
+func (m *MemoryCache) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.entries)
+}
@@ -0,0 +1,175 @@
+package textrazor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Cache tests
+
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache down")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte) error {
+	return errors.New("cache down")
+}
+
+func TestCache(t *testing.T) {
+	cache := NewMemoryCache()
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisWords})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithCache(cache), WithResolvedReferences())
+
+	for i := 0; i < 2; i++ {
+		a, err := client.AnalyzeText(testText, Params{"extractors": {"words"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(a.Sentences) != 1 || a.HTTPResponse == nil || a.HTTPResponse.Status != http.StatusOK || !a.resolveRefs {
+			t.Errorf("expect the analysis %d to be decoded, got %+v", i, a)
+		}
+	}
+	if n := len(transport.Requests()); n != 1 || cache.Len() != 1 {
+		t.Error("expect the second analysis to be cached, got", n, "requests")
+	}
+
+	if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(transport.Requests()); n != 2 || cache.Len() != 2 {
+		t.Error("expect other params to be another request, got", n, "requests")
+	}
+}
+
+func TestCacheFailure(t *testing.T) {
+	var logs bytes.Buffer
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithCache(failingCache{}), WithLogger(log.New(&logs, "", 0)))
+
+	if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal("expect the analysis to succeed without cache, got", err)
+	}
+	if !strings.Contains(logs.String(), "cache read failed: cache down") || !strings.Contains(logs.String(), "cache write failed: cache down") {
+		t.Error("expect the cache failures to be logged, got", logs.String())
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	client := NewClient(testAPIKey)
+	other := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, "http://localhost", "https://localhost", nil)
+	p := Params{"text": {testText}, "extractors": {"entities"}}
+	if client.cacheKey(p) != client.cacheKey(copyParams(p)) {
+		t.Error("expect the same key for the same params")
+	}
+	if client.cacheKey(p) == other.cacheKey(p) {
+		t.Error("expect another key for another endpoint")
+	}
+	if client.cacheKey(p) == client.cacheKey(Params{"text": {testText}, "extractors": {"topics"}}) {
+		t.Error("expect another key for other params")
+	}
+}
+
+func TestCachePolicy(t *testing.T) {
+	cache := NewMemoryCache()
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newClient := func(p CachePolicy) *Client {
+		p.now = func() time.Time { return now }
+		return NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithCache(cache), WithCachePolicy(p))
+	}
+	params := Params{"extractors": {"entities"}}
+	requests := 0
+	analyze := func(name string, client *Client, sent bool, opts ...CallOption) {
+		t.Helper()
+		a, err := client.AnalyzeTextContext(context.Background(), testText, copyParams(params), opts...)
+		if err != nil || len(a.Entities) == 0 {
+			t.Fatal(name, err)
+		}
+		if sent {
+			requests++
+		}
+		if n := len(transport.Requests()); n != requests {
+			t.Errorf("%s: expect %d requests, got %d", name, requests, n)
+			requests = n
+		}
+	}
+
+	v1 := newClient(CachePolicy{TTL: time.Hour, Version: "v1"})
+	analyze("first analysis", v1, true)
+	analyze("cached", v1, false)
+	now = now.Add(30 * time.Minute)
+	analyze("still valid", v1, false)
+	analyze("forced refresh", v1, true, ForceRefresh())
+	now = now.Add(59 * time.Minute)
+	analyze("refresh extended the ttl", v1, false)
+	now = now.Add(time.Minute)
+	analyze("expired", v1, true)
+
+	v2 := newClient(CachePolicy{Version: "v2"})
+	analyze("other version", v2, true)
+	now = now.Add(24 * time.Hour)
+	analyze("no ttl", v2, false)
+	analyze("other version again", v1, true, CallCacheTTL(time.Minute))
+	now = now.Add(time.Minute)
+	analyze("call ttl", v1, true)
+
+	reanalyzed := 0
+	hook := newClient(CachePolicy{Version: "v1", Reanalyze: func(e *CacheEntry) bool {
+		reanalyzed++
+		return e.StoredAt.Before(now)
+	}})
+	analyze("reanalyze hook", hook, false)
+	now = now.Add(time.Second)
+	analyze("reanalyze hook", hook, true)
+	if reanalyzed != 2 {
+		t.Error("expect the hook to be called twice, got", reanalyzed)
+	}
+}
+
+func TestCachePolicyLegacyEntries(t *testing.T) {
+	cache := NewMemoryCache()
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	legacy := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithCache(cache))
+	if _, err := legacy.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		policy CachePolicy
+		sent   bool
+	}{
+		{CachePolicy{Reanalyze: func(*CacheEntry) bool { return false }}, false},
+		{CachePolicy{TTL: time.Hour}, true},
+	}
+	for _, tt := range tests {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithCache(cache), WithCachePolicy(tt.policy))
+		before := len(transport.Requests())
+		a, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}})
+		if err != nil || len(a.Entities) == 0 {
+			t.Fatal(err)
+		}
+		if sent := len(transport.Requests()) > before; sent != tt.sent {
+			t.Errorf("%+v: expect a request %v, got %v", tt.policy, tt.sent, sent)
+		}
+	}
+
+	// the entries stored with a policy are decoded by the policy only
+	var entry CacheEntry
+	for _, v := range cache.entries {
+		if err := json.Unmarshal(v, &entry); err != nil || entry.StoredAt.IsZero() || !entry.ExpiresAt.Equal(entry.StoredAt.Add(time.Hour)) {
+			t.Errorf("expect an entry with an expiry, got %+v %v", entry, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package textrazor
+
+import (
+	"net/http"
+	"time"
+)
+
+// CallOption configures a single API call, it is accepted by every *Context method of Client
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout      time.Duration
+	forceRefresh bool
+	// fraction of the remaining time reserved to decode the response
+	decodeReserve float64
+	// concurrent analyses of AnalyzeMany
+	concurrency int
+	// headers added to the requests, see CallHeaders
+	headers http.Header
+	// tenant of the call, see CallTenant
+	tenant string
+	// TTL of the cached analysis, see CallCacheTTL
+	cacheTTL time.Duration
+	// links located in the cleaned text, see CallLinkMentions
+	linkMentions bool
+}
+
+func newCallOptions(opts []CallOption) *callOptions {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,116 @@
+package textrazor
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCanaryMirrors is the maximum number of mirrored analyses running at the same time
+const DefaultCanaryMirrors = 8
+
+// CanaryRecord is the result of an analysis mirrored by a Canary
+type CanaryRecord struct {
+	// Params and Analysis are the parameters and the result of the primary analysis
+	Params   Params
+	Analysis *Analysis
+	// CanaryParams, CanaryAnalysis and Err are the parameters, the result and the error of the mirrored analysis
+	CanaryParams   Params
+	CanaryAnalysis *Analysis
+	Err            error
+	Duration       time.Duration
+}
+
+// Canary mirrors a sample of the analyses of a client with alternate parameters, e.g. another classifier,
+// to evaluate them on real traffic. Mirrored analyses run in the background, their results are recorded
+// and never returned: callers get the result of the primary analysis, as if they used the client.
+//
+// At most DefaultCanaryMirrors analyses are mirrored at the same time, the sampled analyses beyond it aren't
+// mirrored and are counted by Dropped, so a slow alternate never delays the primary analyses.
+type Canary struct {
+	client *Client
+	// Percent of the successful analyses mirrored, from 0 to 100
+	Percent float64
+	// Alternate, if set, runs the mirrored analyses, e.g. a client with other options
+	Alternate *Client
+	// Alter returns the parameters of a mirrored analysis from a copy of the primary parameters
+	Alter func(Params) Params
+	// Record is called with each mirrored analysis, from its goroutine
+	Record func(CanaryRecord)
+
+	sample  func() float64
+	mirrors group
+	dropped atomic.Int64
+}
+
+// NewCanary returns a Canary mirroring percent of the analyses of c with the parameters returned by alter
+func NewCanary(c *Client, percent float64, alter func(Params) Params, record func(CanaryRecord)) *Canary {
+	return &Canary{client: c, Percent: percent, Alter: alter, Record: record, sample: rand.Float64, mirrors: group{running: &c.stats.goroutines, slots: make(chan struct{}, DefaultCanaryMirrors)}}
+}
+
+// CanaryClassifiers returns an Alter function replacing the classifiers, to evaluate another classifier
+func CanaryClassifiers(IDs ...string) func(Params) Params {
+	return func(p Params) Params {
+		p["classifiers"] = append([]string(nil), IDs...)
+		return p
+	}
+}
+
+// Analyze is like Client.Analyze, mirroring a sample of the analyses
+func (c *Canary) Analyze(params Params) (*Analysis, error) {
+	return c.AnalyzeContext(context.Background(), params)
+}
+
+// AnalyzeContext is like Client.AnalyzeContext, mirroring a sample of the analyses.
+// Mirrored analyses aren't canceled with ctx but keep its values, and use opts.
+func (c *Canary) AnalyzeContext(ctx context.Context, params Params, opts ...CallOption) (*Analysis, error) {
+	analysis, err := c.client.AnalyzeContext(ctx, params, opts...)
+	if err != nil || c.sample()*100 >= c.Percent {
+		return analysis, err
+	}
+
+	record := CanaryRecord{Params: copyParams(params), Analysis: analysis.Clone(), CanaryParams: copyParams(params)}
+	if c.Alter != nil {
+		record.CanaryParams = c.Alter(record.CanaryParams)
+	}
+	mirror := c.client
+	if c.Alternate != nil {
+		mirror = c.Alternate
+	}
+	ctx = context.WithoutCancel(ctx)
+	mirrored := c.mirrors.TryGo(func() error {
+		start := time.Now()
+		record.CanaryAnalysis, record.Err = mirror.AnalyzeContext(ctx, copyParams(record.CanaryParams), opts...)
+		record.Duration = time.Since(start)
+		if c.Record != nil {
+			c.Record(record)
+		}
+		return nil
+	})
+	if !mirrored {
+		c.dropped.Add(1)
+	}
+	return analysis, nil
+}
+
+// AnalyzeText is like Client.AnalyzeText, mirroring a sample of the analyses
+func (c *Canary) AnalyzeText(text string, params Params) (*Analysis, error) {
+	return c.AnalyzeTextContext(context.Background(), text, params)
+}
+
+// AnalyzeTextContext is like Client.AnalyzeTextContext, mirroring a sample of the analyses
+func (c *Canary) AnalyzeTextContext(ctx context.Context, text string, params Params, opts ...CallOption) (*Analysis, error) {
+	params.Set("text", text)
+	return c.AnalyzeContext(ctx, params, opts...)
+}
+
+// Dropped returns the number of sampled analyses not mirrored because DefaultCanaryMirrors were running
+func (c *Canary) Dropped() int64 {
+	return c.dropped.Load()
+}
+
+// Wait waits for the mirrored analyses in progress, e.g. before exiting
+func (c *Canary) Wait() {
+	c.mirrors.Wait()
+}
@@ -0,0 +1,124 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Canary tests
+
+func TestCanary(t *testing.T) {
+	transport := &classifyingTransport{categories: map[string][]ScoredCategory{
+		testText: {{ClassifierID: "sports_v1", CategoryID: "1", Label: "football", Score: 0.8}},
+	}}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	var (
+		mu      sync.Mutex
+		records []CanaryRecord
+	)
+	canary := NewCanary(client, 50, CanaryClassifiers("sports_v2"), func(r CanaryRecord) {
+		mu.Lock()
+		records = append(records, r)
+		mu.Unlock()
+	})
+	samples := []float64{0.2, 0.7, 0.49, 0.5}
+	canary.sample = func() float64 {
+		s := samples[0]
+		samples = samples[1:]
+		return s
+	}
+
+	for i := 0; i < 4; i++ {
+		a, err := canary.AnalyzeText(testText, Params{"extractors": {"entities"}, "classifiers": {"sports_v1"}})
+		if err != nil || len(a.Categories) != 1 {
+			t.Fatal("expect the primary analysis, got", a, err)
+		}
+	}
+	canary.Wait()
+
+	if len(records) != 2 || len(transport.classifiers) != 6 {
+		t.Fatal("expect 2 of the 4 analyses to be mirrored, got", len(records), "records and", len(transport.classifiers), "requests")
+	}
+	r := records[0]
+	if r.Err != nil || r.Params.Get("classifiers") != "sports_v1" || r.CanaryParams.Get("classifiers") != "sports_v2" || r.CanaryAnalysis == nil || r.Analysis == nil {
+		t.Errorf("expect the analysis to be mirrored with sports_v2, got %+v", r)
+	}
+}
+
+func TestCanaryAlternate(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities))
+	alternate := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, 200, "", true))
+
+	var record CanaryRecord
+	canary := NewCanary(client, 100, nil, func(r CanaryRecord) { record = r })
+	canary.Alternate = alternate
+
+	// a canceled context doesn't cancel the mirrored analysis, which fails with the transport
+	ctx, cancel := context.WithCancel(context.Background())
+	a, err := canary.AnalyzeTextContext(ctx, testText, Params{"extractors": {"entities"}})
+	cancel()
+	canary.Wait()
+	if err != nil || len(a.Entities) != 4 {
+		t.Fatal("expect the primary analysis to succeed, got", err)
+	}
+	if record.Err == nil || record.Err == context.Canceled || record.CanaryAnalysis != nil {
+		t.Errorf("expect the mirrored analysis to fail with the alternate client, got %+v", record)
+	}
+}
+
+func TestCanaryPrimaryError(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, 200, "", true))
+	canary := NewCanary(client, 100, nil, func(r CanaryRecord) { t.Error("unexpected mirrored analysis", r) })
+	if _, err := canary.AnalyzeText(testText, Params{"extractors": {"entities"}}); err == nil {
+		t.Error("expect the primary error")
+	}
+	canary.Wait()
+}
+
+// heldTransport blocks the requests until release is closed
+type heldTransport struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (t *heldTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.started <- struct{}{}
+	<-t.release
+	return nil, errors.New("released")
+}
+
+func TestCanaryMirrorsLimit(t *testing.T) {
+	defer checkLeaks(t)()
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities))
+	transport := &heldTransport{started: make(chan struct{}, DefaultCanaryMirrors), release: make(chan struct{})}
+
+	var records atomic.Int64
+	canary := NewCanary(client, 100, nil, func(CanaryRecord) { records.Add(1) })
+	canary.Alternate = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	// the analyses sampled while the mirrors are busy are dropped, without waiting
+	for i := 0; i < DefaultCanaryMirrors+2; i++ {
+		if _, err := canary.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < DefaultCanaryMirrors; i++ {
+		<-transport.started
+	}
+	if n := canary.Dropped(); n != 2 {
+		t.Error("expect 2 dropped mirrors, got", n)
+	}
+	close(transport.release)
+	canary.Wait()
+	if n := records.Load(); n != DefaultCanaryMirrors {
+		t.Errorf("expect %d mirrored analyses, got %d", DefaultCanaryMirrors, n)
+	}
+}
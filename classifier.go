@@ -0,0 +1,83 @@
+package textrazor
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ClassifierFormat defines the format of an exported classifier
+type ClassifierFormat int
+
+// Valid ClassifierFormat values, the formats accepted by CreateClassifierFromJSON and CreateClassifierFromCSV
+const (
+	ClassifierJSON ClassifierFormat = iota
+	ClassifierCSV
+)
+
+// AllClassifierCategories returns every category of a Classifier, requesting as many pages as needed
+func (c *Client) AllClassifierCategories(ID string) ([]Category, error) {
+	return c.AllClassifierCategoriesContext(context.Background(), ID)
+}
+
+// AllClassifierCategoriesContext is like AllClassifierCategories with a context
+func (c *Client) AllClassifierCategoriesContext(ctx context.Context, ID string, opts ...CallOption) ([]Category, error) {
+	var categories []Category
+	for o := (ListOptions{Limit: DefaultPageSize}); ; {
+		cl, err := c.ListClassifierCategoriesContext(ctx, ID, o, opts...)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, cl.Categories...)
+		o.Offset += len(cl.Categories)
+		if len(cl.Categories) == 0 || o.Offset >= cl.Total {
+			return categories, nil
+		}
+	}
+}
+
+// ExportClassifier writes every category of a Classifier to w, in a format accepted by
+// CreateClassifierFromJSON or CreateClassifierFromCSV, to backup or copy a classifier
+func (c *Client) ExportClassifier(ID string, w io.Writer, format ClassifierFormat) error {
+	return c.ExportClassifierContext(context.Background(), ID, w, format)
+}
+
+// ExportClassifierContext is like ExportClassifier with a context
+func (c *Client) ExportClassifierContext(ctx context.Context, ID string, w io.Writer, format ClassifierFormat, opts ...CallOption) error {
+	if format != ClassifierJSON && format != ClassifierCSV {
+		return fmt.Errorf("unknown classifier format %d", format)
+	}
+	categories, err := c.AllClassifierCategoriesContext(ctx, ID, opts...)
+	if err != nil {
+		return err
+	}
+	if format == ClassifierCSV {
+		return writeCategoriesCSV(w, categories)
+	}
+	return writeCategoriesJSON(w, categories)
+}
+
+func writeCategoriesJSON(w io.Writer, categories []Category) error {
+	if categories == nil {
+		categories = []Category{}
+	}
+	b, err := json.MarshalIndent(categories, "", "  ")
+	if err != nil {
+		return fmt.Errorf("classifier encoding failed: %v", err)
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func writeCategoriesCSV(w io.Writer, categories []Category) error {
+	cw := csv.NewWriter(w)
+	for _, cat := range categories {
+		if err := cw.Write([]string{cat.CategoryID, cat.Label, cat.Query}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
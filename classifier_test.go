@@ -0,0 +1,72 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			ExportClassifier tests
+
+// categoriesTransport serves the pages of a classifier of total categories
+type categoriesTransport struct {
+	total    int
+	requests int
+}
+
+func (t *categoriesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	var o ListOptions
+	fmt.Sscan(req.URL.Query().Get("limit"), &o.Limit)
+	fmt.Sscan(req.URL.Query().Get("offset"), &o.Offset)
+
+	list := CategoryList{Offset: o.Offset, Limit: o.Limit, Total: t.total, Categories: []Category{}}
+	for i := o.Offset; i < o.Offset+o.Limit && i < t.total; i++ {
+		list.Categories = append(list.Categories, Category{CategoryID: fmt.Sprint(100 + i), Label: fmt.Sprint("Sport, ", i), Query: fmt.Sprintf("concept('sport>%d')", i)})
+	}
+	b, _ := json.Marshal(map[string]interface{}{"ok": true, "response": list})
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(b)), Request: req}, nil
+}
+
+func TestExportClassifier(t *testing.T) {
+	transport := &categoriesTransport{total: 250}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	var buf bytes.Buffer
+	if err := client.ExportClassifier(catDictID, &buf, ClassifierJSON); err != nil {
+		t.Fatal(err)
+	}
+	var categories []Category
+	if err := json.Unmarshal(buf.Bytes(), &categories); err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 250 || categories[249].CategoryID != "349" || transport.requests != 3 {
+		t.Error("expect 250 categories in 3 requests, got", len(categories), transport.requests)
+	}
+
+	buf.Reset()
+	if err := client.ExportClassifier(catDictID, &buf, ClassifierCSV); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 250 || lines[0] != `100,"Sport, 0",concept('sport>0')` {
+		t.Error("expect 250 CSV lines, got", len(lines), lines[0])
+	}
+
+	if err := client.ExportClassifier(catDictID, &buf, ClassifierFormat(42)); err == nil {
+		t.Error("expect an unknown format to fail")
+	}
+}
+
+func TestExportEmptyClassifier(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &categoriesTransport{})
+	var buf bytes.Buffer
+	if err := client.ExportClassifier(catDictID, &buf, ClassifierJSON); err != nil || buf.String() != "[]\n" {
+		t.Error("expect an empty JSON array, got", buf.String(), err)
+	}
+}
@@ -0,0 +1,153 @@
+package textrazor
+
+// Clone returns a deep copy of the analysis, resolved word references point into the copy
+func (a *Analysis) Clone() *Analysis {
+	if a == nil {
+		return nil
+	}
+	return a.clone()
+}
+
+func (a *Analysis) clone() *Analysis {
+	c := *a
+	if a.HTTPResponse != nil {
+		r := *a.HTTPResponse
+		r.Headers = a.HTTPResponse.Headers.Clone()
+		r.Body = append([]byte(nil), a.HTTPResponse.Body...)
+		r.Dictionaries = append([]Dictionary(nil), a.HTTPResponse.Dictionaries...)
+		r.Response = &c
+		c.HTTPResponse = &r
+	}
+
+	c.Entailments = cloneEntailments(a.Entailments)
+	c.Entities = cloneEntities(a.Entities)
+	c.Topics = append([]Topic(nil), a.Topics...)
+	c.CoarseTopics = append([]Topic(nil), a.CoarseTopics...)
+	c.Categories = append([]ScoredCategory(nil), a.Categories...)
+	c.NounPhrases = cloneNounPhrases(a.NounPhrases)
+	c.Properties = cloneProperties(a.Properties)
+	c.Relations = cloneRelations(a.Relations)
+	c.Sentences = cloneSentences(a.Sentences)
+	c.MatchingRules = copyStrings(a.MatchingRules)
+
+	if a.resolveRefs {
+		c.ResolveReferences()
+	}
+	return &c
+}
+
+// The clone functions below return deep copies of the sections of an analysis, without their word references
+
+func cloneEntailments(s []Entailment) []Entailment {
+	if s == nil {
+		return nil
+	}
+	c := make([]Entailment, len(s))
+	for i, e := range s {
+		e.EntailedTree = e.EntailedTree.clone()
+		e.WordPositions = copyInts(e.WordPositions)
+		e.Words = nil
+		c[i] = e
+	}
+	return c
+}
+
+func cloneEntities(s []Entity) []Entity {
+	if s == nil {
+		return nil
+	}
+	c := make([]Entity, len(s))
+	for i, e := range s {
+		e.Types = copyStrings(e.Types)
+		e.FreebaseTypes = copyStrings(e.FreebaseTypes)
+		e.MatchingTokens = copyInts(e.MatchingTokens)
+		e.Data = e.Data.clone()
+		e.Extra = cloneExtra(e.Extra)
+		e.Words = nil
+		c[i] = e
+	}
+	return c
+}
+
+func cloneNounPhrases(s []NounPhrase) []NounPhrase {
+	if s == nil {
+		return nil
+	}
+	c := make([]NounPhrase, len(s))
+	for i, p := range s {
+		c[i] = NounPhrase{ID: p.ID, WordPositions: copyInts(p.WordPositions)}
+	}
+	return c
+}
+
+func cloneProperties(s []Property) []Property {
+	if s == nil {
+		return nil
+	}
+	c := make([]Property, len(s))
+	for i, p := range s {
+		c[i] = Property{ID: p.ID, WordPositions: copyInts(p.WordPositions), PropertyPositions: copyInts(p.PropertyPositions)}
+	}
+	return c
+}
+
+func cloneRelations(s []Relation) []Relation {
+	if s == nil {
+		return nil
+	}
+	c := make([]Relation, len(s))
+	for i, r := range s {
+		var params []RelationParam
+		if r.Params != nil {
+			params = make([]RelationParam, len(r.Params))
+			for j, p := range r.Params {
+				params[j] = RelationParam{WordPositions: copyInts(p.WordPositions), Relation: p.Relation}
+			}
+		}
+		c[i] = Relation{ID: r.ID, Params: params, WordPositions: copyInts(r.WordPositions)}
+	}
+	return c
+}
+
+func cloneSentences(s []Sentence) []Sentence {
+	if s == nil {
+		return nil
+	}
+	c := make([]Sentence, len(s))
+	for i, sentence := range s {
+		c[i].Position = sentence.Position
+		if sentence.Words == nil {
+			continue
+		}
+		words := make([]Word, len(sentence.Words))
+		for j, w := range sentence.Words {
+			words[j] = cloneWord(w)
+		}
+		c[i].Words = words
+	}
+	return c
+}
+
+func cloneWord(w Word) Word {
+	if w.Senses != nil {
+		w.Senses = append([]Sense(nil), w.Senses...)
+	}
+	if w.SpellingSuggestions != nil {
+		w.SpellingSuggestions = append([]SpellingSuggestion(nil), w.SpellingSuggestions...)
+	}
+	return w
+}
+
+func copyInts(s []int) []int {
+	if s == nil {
+		return nil
+	}
+	return append([]int(nil), s...)
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
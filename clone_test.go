@@ -0,0 +1,66 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Clone tests
+
+// decodeAnalysis decodes an analysis fixture
+func decodeAnalysis(t *testing.T, body string) *Analysis {
+	analysis := &Analysis{}
+	r := HTTPResponse{Response: analysis}
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatal(err)
+	}
+	return analysis
+}
+
+func TestClone(t *testing.T) {
+	for _, body := range []string{textrazortest.AnalysisEntities, textrazortest.AnalysisRelations, textrazortest.AnalysisNounPhrases, textrazortest.AnalysisWords} {
+		analysis := decodeAnalysis(t, body)
+		analysis.HTTPResponse = &HTTPResponse{Status: 200, Body: []byte(body), Response: analysis}
+		c := analysis.Clone()
+		if !reflect.DeepEqual(c, analysis) {
+			t.Error("expect the clone to equal the analysis")
+		}
+		if c.HTTPResponse == analysis.HTTPResponse || c.HTTPResponse.Response != c {
+			t.Error("expect the clone to have its own HTTPResponse")
+		}
+
+		for i := range c.Entities {
+			c.Entities[i].MatchingTokens[0] = -1
+		}
+		for i := range c.Sentences {
+			c.Sentences[i].Words[0].Token = "mutated"
+		}
+		for i := range c.Relations {
+			c.Relations[i].Params[0].WordPositions[0] = -1
+		}
+		for _, e := range analysis.Entities {
+			if e.MatchingTokens[0] == -1 {
+				t.Error("expect entities not to be shared")
+			}
+		}
+		for _, s := range analysis.Sentences {
+			if s.Words[0].Token == "mutated" {
+				t.Error("expect words not to be shared")
+			}
+		}
+		for _, r := range analysis.Relations {
+			if r.Params[0].WordPositions[0] == -1 {
+				t.Error("expect relations not to be shared")
+			}
+		}
+	}
+
+	var nilAnalysis *Analysis
+	if nilAnalysis.Clone() != nil {
+		t.Error("expect a nil clone")
+	}
+}
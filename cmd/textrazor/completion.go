@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bengentil/textrazor-go/output"
+)
+
+// completeCommand is the hidden command called by the completion scripts, it prints the candidates
+// of the last word of its arguments, a word per line
+const completeCommand = "__complete"
+
+// completionScripts are the completion scripts of the shells, %[1]s is the name of the command
+var completionScripts = map[string]string{
+	"bash": `# bash completion for %[1]s, e.g. in ~/.bashrc:
+#   source <(%[1]s completion bash)
+_%[1]s() {
+	local IFS=$'\n'
+	COMPREPLY=($(%[1]s ` + completeCommand + ` "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _%[1]s %[1]s
+`,
+	"zsh": `#compdef %[1]s
+# zsh completion for %[1]s, e.g. in ~/.zshrc:
+#   source <(%[1]s completion zsh)
+_%[1]s() {
+	local -a candidates
+	candidates=("${(@f)$(%[1]s ` + completeCommand + ` "${(@)words[2,$CURRENT]}" 2>/dev/null)}")
+	compadd -a candidates
+}
+compdef _%[1]s %[1]s
+`,
+	"fish": `# fish completion for %[1]s, e.g. in ~/.config/fish/completions/%[1]s.fish:
+#   %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+complete -c %[1]s -f -a '(%[1]s ` + completeCommand + ` (commandline -opc)[2..-1] (commandline -ct))'
+`,
+}
+
+func completion(c *cli, fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		script, ok := completionScripts[args[0]]
+		if !ok {
+			return &usageError{msg: fmt.Sprintf("unknown shell %q, expect bash, zsh or fish", args[0]), usage: fs.Usage}
+		}
+		_, err := fmt.Fprintf(c.stdout, script, "textrazor")
+		return err
+	}
+}
+
+// complete prints the candidates of the last word of a command line
+func (c *cli) complete(words []string) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	word := words[len(words)-1]
+	var candidates []string
+	if len(words) == 1 {
+		candidates = commandNames()
+	} else if cmd, ok := commands[words[0]]; ok {
+		fs := c.flagSet(words[0], cmd)
+		fs.SetOutput(io.Discard)
+		cmd.setup(c, fs)
+		switch prev := strings.TrimLeft(words[len(words)-2], "-"); {
+		case prev == "format" && strings.HasPrefix(words[len(words)-2], "-"):
+			for _, f := range output.Formats {
+				candidates = append(candidates, string(f))
+			}
+		case strings.HasPrefix(word, "-"):
+			fs.VisitAll(func(f *flag.Flag) {
+				candidates = append(candidates, "-"+f.Name)
+			})
+			if strings.HasPrefix(word, "--") {
+				for i := range candidates {
+					candidates[i] = "-" + candidates[i]
+				}
+			}
+		case words[0] == "completion" && len(words) == 2:
+			for shell := range completionScripts {
+				candidates = append(candidates, shell)
+			}
+			sort.Strings(candidates)
+		}
+	}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			fmt.Fprintln(c.stdout, candidate)
+		}
+	}
+}
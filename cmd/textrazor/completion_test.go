@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			Completion tests
+
+func TestComplete(t *testing.T) {
+	var tests = []struct {
+		words  []string
+		expect string
+	}{
+		{nil, "account\nanalyze\ncategories\ncompletion\ndictionaries\nplan\nrepl\n"},
+		{[]string{"a"}, "account\nanalyze\n"},
+		{[]string{"unknown", ""}, ""},
+		{[]string{"analyze", "-ex"}, "-extractors\n"},
+		{[]string{"analyze", "--ur"}, "--url\n"},
+		{[]string{"account", "-"}, "-endpoint\n-format\n-json-errors\n-key\n"},
+		{[]string{"analyze", "-format", ""}, "table\njson\ncsv\n"},
+		{[]string{"dictionaries", "--format", "j"}, "json\n"},
+		{[]string{"completion", ""}, "bash\nfish\nzsh\n"},
+		{[]string{"completion", "bash", ""}, ""},
+		{[]string{"analyze", "text"}, ""},
+	}
+	for _, tt := range tests {
+		var stdout strings.Builder
+		c := &cli{stdout: &stdout, stderr: &stdout, getenv: func(string) string { return "" }}
+		if code := c.run(append([]string{completeCommand}, tt.words...)); code != 0 || stdout.String() != tt.expect {
+			t.Errorf("%q: expect %q, got %d %q", tt.words, tt.expect, code, stdout.String())
+		}
+	}
+}
+
+func TestCompletionScripts(t *testing.T) {
+	var tests = []struct {
+		shell, expect string
+		code          int
+	}{
+		{"bash", "complete -o default -F _textrazor textrazor\n", 0},
+		{"zsh", "compdef _textrazor textrazor\n", 0},
+		{"fish", "complete -c textrazor -f -a '(textrazor __complete (commandline -opc)[2..-1] (commandline -ct))'\n", 0},
+		{"powershell", "", 2},
+	}
+	for _, tt := range tests {
+		var stdout, stderr strings.Builder
+		c := &cli{stdout: &stdout, stderr: &stderr, getenv: func(string) string { return "" }}
+		code := c.run([]string{"completion", tt.shell})
+		if code != tt.code || !strings.HasSuffix(stdout.String(), tt.expect) {
+			t.Errorf("%s: expect the exit code %d and %q, got %d\n%s%s", tt.shell, tt.code, tt.expect, code, stdout.String(), stderr.String())
+		}
+	}
+}
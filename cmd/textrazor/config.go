@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bengentil/textrazor-go/output"
+)
+
+// config is the config file of the command, its values are the defaults of the flags
+type config struct {
+	// Key is the API key, or a reference to it: env:NAME for an environment variable, file:PATH for a file
+	Key         string
+	Endpoint    string
+	Extractors  []string
+	Classifiers []string
+	Format      string
+}
+
+// configPath returns the path of the config file, $TEXTRAZOR_CONFIG or textrazor/config.yaml
+// in $XDG_CONFIG_HOME, ~/.config by default
+func configPath(getenv func(string) string) string {
+	if p := getenv("TEXTRAZOR_CONFIG"); p != "" {
+		return p
+	}
+	dir := getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home := getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "textrazor", "config.yaml")
+}
+
+// loadConfig reads the config file, a missing file is an empty config
+func loadConfig(getenv func(string) string) (config, error) {
+	path := configPath(getenv)
+	if path == "" {
+		return config{}, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config{}, nil
+	} else if err != nil {
+		return config{}, fmt.Errorf("reading the config failed: %v", err)
+	}
+	defer f.Close()
+	cfg, err := parseConfig(f)
+	if err != nil {
+		return config{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// parseConfig parses the YAML subset of the config file: "name: value" pairs, where a list is either
+// a flow sequence [a, b] or a block of "- item" lines, quoted scalars and comments
+func parseConfig(r io.Reader) (config, error) {
+	var cfg config
+	lists := map[string]*[]string{"extractors": &cfg.Extractors, "classifiers": &cfg.Classifiers}
+	scalars := map[string]*string{"key": &cfg.Key, "endpoint": &cfg.Endpoint, "format": &cfg.Format}
+
+	var list *[]string
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimRight(stripComment(s.Text()), " \t")
+		if strings.TrimSpace(line) == "" || line == "---" {
+			continue
+		}
+		if item := strings.TrimSpace(line); strings.HasPrefix(item, "- ") || item == "-" {
+			if list == nil || line[0] != ' ' && line[0] != '-' {
+				return cfg, fmt.Errorf("line %d: unexpected list item", n)
+			}
+			value, err := unquote(strings.TrimSpace(strings.TrimPrefix(item, "-")))
+			if err != nil {
+				return cfg, fmt.Errorf("line %d: %v", n, err)
+			}
+			*list = append(*list, value)
+			continue
+		}
+		list = nil
+		if line[0] == ' ' || line[0] == '\t' {
+			return cfg, fmt.Errorf("line %d: unexpected indentation", n)
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return cfg, fmt.Errorf("line %d: expect name: value", n)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+
+		if l, ok := lists[name]; ok {
+			*l = nil
+			if value == "" {
+				list = l
+				continue
+			}
+			if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+				return cfg, fmt.Errorf("line %d: %s is a list", n, name)
+			}
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = strings.TrimSpace(item); item == "" {
+					continue
+				}
+				item, err := unquote(item)
+				if err != nil {
+					return cfg, fmt.Errorf("line %d: %v", n, err)
+				}
+				*l = append(*l, item)
+			}
+			continue
+		}
+		p, ok := scalars[name]
+		if !ok {
+			return cfg, fmt.Errorf("line %d: unknown setting %q", n, name)
+		}
+		var err error
+		if *p, err = unquote(value); err != nil {
+			return cfg, fmt.Errorf("line %d: %v", n, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return cfg, err
+	}
+	if cfg.Format != "" {
+		var f output.Format
+		if err := f.Set(cfg.Format); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// stripComment removes the comment of a line, a # at its start or after a space outside of quotes
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquote returns the value of a plain, single quoted or double quoted scalar
+func unquote(s string) (string, error) {
+	switch {
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		return strconv.Unquote(s)
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'"):
+		return "", fmt.Errorf("unterminated string %s", s)
+	}
+	return s, nil
+}
+
+// apiKey resolves the key reference of the config
+func (cfg config) apiKey(getenv func(string) string) (string, error) {
+	switch {
+	case strings.HasPrefix(cfg.Key, "env:"):
+		return getenv(strings.TrimPrefix(cfg.Key, "env:")), nil
+	case strings.HasPrefix(cfg.Key, "file:"):
+		path := strings.TrimPrefix(cfg.Key, "file:")
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			path = filepath.Join(getenv("HOME"), rest)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading the API key failed: %v", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return cfg.Key, nil
+}
+
+// orDefault returns s, or def when s is empty
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			Config tests
+
+func TestParseConfig(t *testing.T) {
+	var tests = []struct {
+		yaml   string
+		expect config
+		err    string
+	}{
+		{"", config{}, ""},
+		{"# textrazor\n---\nkey: env:MY_KEY # the key\nendpoint: 'http://localhost:8080/'\nformat: \"json\"\n",
+			config{Key: "env:MY_KEY", Endpoint: "http://localhost:8080/", Format: "json"}, ""},
+		{"extractors: [entities, 'topics', \"words\"]\nclassifiers: []\n", config{Extractors: []string{"entities", "topics", "words"}}, ""},
+		{"extractors:\n  - entities\n  - topics # later\n\nkey: abc#1\n", config{Key: "abc#1", Extractors: []string{"entities", "topics"}}, ""},
+		{"classifiers:\n- textrazor_iab\n", config{Classifiers: []string{"textrazor_iab"}}, ""},
+		{"key: \"a # b\"\n", config{Key: "a # b"}, ""},
+		{"extractors: entities\n", config{}, "line 1: extractors is a list"},
+		{"- entities\n", config{}, "line 1: unexpected list item"},
+		{"key: abc\n  - entities\n", config{}, "line 2: unexpected list item"},
+		{"  key: abc\n", config{}, "line 1: unexpected indentation"},
+		{"colors: true\n", config{}, `line 1: unknown setting "colors"`},
+		{"key\n", config{}, "line 1: expect name: value"},
+		{"key: \"abc\n", config{}, "line 1: unterminated string"},
+		{"format: xml\n", config{}, `unknown format "xml"`},
+	}
+	for _, tt := range tests {
+		cfg, err := parseConfig(strings.NewReader(tt.yaml))
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%q: expect the error %q, got %v", tt.yaml, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(cfg, tt.expect) {
+			t.Errorf("%q: expect %+v, got %+v %v", tt.yaml, tt.expect, cfg, err)
+		}
+	}
+}
+
+func TestConfigPath(t *testing.T) {
+	var tests = []struct {
+		env    map[string]string
+		expect string
+	}{
+		{map[string]string{"TEXTRAZOR_CONFIG": "/etc/textrazor.yaml", "HOME": "/home/me"}, "/etc/textrazor.yaml"},
+		{map[string]string{"XDG_CONFIG_HOME": "/xdg", "HOME": "/home/me"}, "/xdg/textrazor/config.yaml"},
+		{map[string]string{"HOME": "/home/me"}, "/home/me/.config/textrazor/config.yaml"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if path := configPath(func(name string) string { return tt.env[name] }); path != filepath.FromSlash(tt.expect) {
+			t.Errorf("%v: expect %q, got %q", tt.env, tt.expect, path)
+		}
+	}
+}
+
+func TestConfigAPIKey(t *testing.T) {
+	home := t.TempDir()
+	if err := os.WriteFile(filepath.Join(home, "key"), []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{"HOME": home, "MY_KEY": "from-env"}
+	getenv := func(name string) string { return env[name] }
+
+	var tests = []struct {
+		key, expect string
+	}{
+		{"", ""},
+		{"literal", "literal"},
+		{"env:MY_KEY", "from-env"},
+		{"env:MISSING", ""},
+		{"file:" + filepath.Join(home, "key"), "from-file"},
+		{"file:~/key", "from-file"},
+	}
+	for _, tt := range tests {
+		if key, err := (config{Key: tt.key}).apiKey(getenv); err != nil || key != tt.expect {
+			t.Errorf("%q: expect %q, got %q %v", tt.key, tt.expect, key, err)
+		}
+	}
+	if _, err := (config{Key: "file:~/missing"}).apiKey(getenv); err == nil {
+		t.Error("expect an error reading a missing key file")
+	}
+}
+
+func TestCommandsConfig(t *testing.T) {
+	var texts []string
+	server := newServer(t, &texts)
+	defer server.Close()
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	write := func(yaml string) {
+		if err := os.WriteFile(configFile, []byte(yaml), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, "key"), []byte("key\n"), 0o600)
+	env := map[string]string{"TEXTRAZOR_CONFIG": configFile}
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr strings.Builder
+		c := &cli{stdin: strings.NewReader(""), stdout: &stdout, stderr: &stderr, getenv: func(name string) string { return env[name] }}
+		code := c.run(args)
+		return code, stdout.String(), stderr.String()
+	}
+
+	// the key, the endpoint, the format and the extractors come from the config
+	write("key: file:" + filepath.Join(dir, "key") + "\nendpoint: " + server.URL + "\nformat: csv\nextractors: [entities]\n")
+	if code, stdout, stderr := run("analyze", "text"); code != 0 || !strings.HasPrefix(stdout, "kind,id,text") {
+		t.Errorf("expect a CSV analysis, got %d\n%s\n%s", code, stdout, stderr)
+	}
+	if code, stdout, stderr := run("account"); code != 0 || !strings.HasPrefix(stdout, "PLAN,") {
+		t.Errorf("expect the account in CSV, got %d\n%s\n%s", code, stdout, stderr)
+	}
+	// the flags and the environment override the config
+	if code, stdout, stderr := run("account", "-format", "json"); code != 0 || !strings.HasPrefix(stdout, "{") {
+		t.Errorf("expect the account in JSON, got %d\n%s\n%s", code, stdout, stderr)
+	}
+	env["TEXTRAZOR_API_KEY"] = "other"
+	if code, _, _ := run("account"); code != 3 {
+		t.Errorf("expect the key of the environment to be used, got %d", code)
+	}
+	delete(env, "TEXTRAZOR_API_KEY")
+
+	write("key: env:MISSING\nendpoint: " + server.URL + "\n")
+	if code, _, stderr := run("account"); code != 3 || !strings.Contains(stderr, "missing API key") {
+		t.Errorf("expect a missing key error, got %d %s", code, stderr)
+	}
+	write("key: [abc]\nextractors: abc\n")
+	if code, _, stderr := run("account"); code != 2 || !strings.Contains(stderr, "config.yaml: line 2") {
+		t.Errorf("expect a config error, got %d %s", code, stderr)
+	}
+}
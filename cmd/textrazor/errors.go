@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// Exit codes of the command, they are stable so scripts can tell the failures apart
+const (
+	exitOK = 0
+	// exitError is any other error
+	exitError = 1
+	// exitUsage is an invalid command line or config file
+	exitUsage = 2
+	// exitAuth is a missing or rejected API key
+	exitAuth = 3
+	// exitQuota is an exhausted daily quota or a rate limited request
+	exitQuota = 4
+	// exitValidation is a request rejected by the API, e.g. an unknown classifier, or an invalid input
+	exitValidation = 5
+	// exitTransport is an unreachable API, a timeout or a server error, retrying may succeed
+	exitTransport = 6
+)
+
+// errMissingKey is returned when no API key is set
+var errMissingKey = errors.New("missing API key, set -key, TEXTRAZOR_API_KEY or the key of the config file")
+
+// errInvalidInput is wrapped by the errors of the inputs rejected before sending a request
+var errInvalidInput = errors.New("invalid input")
+
+// usageError is an invalid command line, printed with the usage
+type usageError struct {
+	msg string
+	// usage prints the usage, nil when it isn't printed
+	usage func()
+	// silent skips msg in the text output, e.g. when the flag package already printed it
+	silent bool
+}
+
+func (e *usageError) Error() string {
+	if e.msg == "" {
+		return "invalid usage"
+	}
+	return e.msg
+}
+
+// errorReport is an error printed with -json-errors, on a single line of the standard error
+type errorReport struct {
+	// Kind is the class of the error, named after the exit code: usage, authentication, quota, validation, transport or error
+	Kind     string `json:"kind"`
+	ExitCode int    `json:"exitCode"`
+	Message  string `json:"message"`
+	// Status, APIError and APIMessage are the status code and the error fields of the failed API response
+	Status     int    `json:"status,omitempty"`
+	APIError   string `json:"apiError,omitempty"`
+	APIMessage string `json:"apiMessage,omitempty"`
+}
+
+// classify returns the kind and the exit code of an error
+func classify(err error) (string, int) {
+	var (
+		usageErr *usageError
+		apiErr   *textrazor.APIError
+		netErr   net.Error
+		urlErr   *url.Error
+	)
+	switch {
+	case errors.As(err, &usageErr):
+		return "usage", exitUsage
+	case errors.Is(err, textrazor.ErrAuthentication) || errors.Is(err, errMissingKey):
+		return "authentication", exitAuth
+	case errors.Is(err, textrazor.ErrQuotaExceeded) || errors.Is(err, textrazor.ErrRateLimited):
+		return "quota", exitQuota
+	case errors.Is(err, textrazor.ErrRequestTooLarge) || errors.Is(err, errInvalidInput):
+		return "validation", exitValidation
+	case errors.As(err, &apiErr) && apiErr.StatusCode >= 500:
+		return "transport", exitTransport
+	case errors.As(err, &apiErr) && apiErr.StatusCode > 0:
+		return "validation", exitValidation
+	case errors.As(err, &netErr) || errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF):
+		return "transport", exitTransport
+	}
+	return "error", exitError
+}
+
+// fail prints an error, as JSON with -json-errors, and returns its exit code
+func (c *cli) fail(err error) int {
+	kind, code := classify(err)
+	if c.jsonErrors {
+		report := errorReport{Kind: kind, ExitCode: code, Message: err.Error()}
+		var apiErr *textrazor.APIError
+		if errors.As(err, &apiErr) {
+			report.Status, report.APIError, report.APIMessage = apiErr.StatusCode, apiErr.ErrorMessage, apiErr.Message
+		}
+		json.NewEncoder(c.stderr).Encode(report)
+		return code
+	}
+
+	var usageErr *usageError
+	if !errors.As(err, &usageErr) {
+		fmt.Fprintln(c.stderr, "textrazor:", err)
+		return code
+	}
+	if usageErr.msg != "" && !usageErr.silent {
+		fmt.Fprintln(c.stderr, "textrazor:", usageErr.msg)
+	}
+	if usageErr.usage != nil {
+		usageErr.usage()
+	}
+	return code
+}
+
+// jsonErrorsFlag reports whether the -json-errors flag is set, before the flags are parsed
+// so the errors of the command line are reported in JSON too
+func jsonErrorsFlag(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "-json-errors", "--json-errors", "-json-errors=true", "--json-errors=true":
+			return true
+		}
+	}
+	return false
+}
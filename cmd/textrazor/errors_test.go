@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			Exit code tests
+
+func TestExitCodes(t *testing.T) {
+	var status int
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	var tests = []struct {
+		name     string
+		status   int
+		body     string
+		args     []string
+		code     int
+		kind     string
+		apiError string
+	}{
+		{"missing command", 0, "", nil, exitUsage, "usage", ""},
+		{"unknown command", 0, "", []string{"unknown"}, exitUsage, "usage", ""},
+		{"unknown flag", 0, "", []string{"account", "-unknown"}, exitUsage, "usage", ""},
+		{"invalid arguments", 0, "", []string{"categories"}, exitUsage, "usage", ""},
+		{"empty text", 0, "", []string{"analyze", " "}, exitValidation, "validation", ""},
+		{"invalid key", http.StatusUnauthorized, `{"ok": false, "error": "Invalid API key"}`, []string{"account"}, exitAuth, "authentication", "Invalid API key"},
+		{"daily quota", http.StatusPaymentRequired, `{"ok": false, "error": "Daily request limit reached"}`, []string{"account"}, exitQuota, "quota", "Daily request limit reached"},
+		{"rate limited", http.StatusTooManyRequests, `{"ok": false}`, []string{"account"}, exitQuota, "quota", ""},
+		{"unknown classifier", http.StatusNotFound, `{"ok": false, "error": "Unknown classifier"}`, []string{"categories", "test"}, exitValidation, "validation", "Unknown classifier"},
+		{"rejected request", http.StatusOK, `{"ok": false, "error": "Invalid extractor"}`, []string{"analyze", "-extractors", "unknown", "text"}, exitValidation, "validation", "Invalid extractor"},
+		{"server error", http.StatusBadGateway, "Bad Gateway", []string{"account"}, exitTransport, "transport", ""},
+		{"unreachable", 0, "", []string{"account", "-endpoint", closed.URL}, exitTransport, "transport", ""},
+		{"invalid response", http.StatusOK, "{", []string{"account"}, exitError, "error", ""},
+	}
+	for _, tt := range tests {
+		for _, jsonErrors := range []bool{false, true} {
+			status, body = tt.status, tt.body
+			args := tt.args
+			if jsonErrors && len(args) > 0 {
+				args = append([]string{args[0], "-json-errors"}, args[1:]...)
+			} else if jsonErrors {
+				args = []string{"-json-errors"}
+			}
+			code, _, stderr := runCommand(server, "", args...)
+			if code != tt.code {
+				t.Errorf("%s: expect the exit code %d, got %d\n%s", tt.name, tt.code, code, stderr)
+			}
+			if !jsonErrors {
+				if strings.HasPrefix(stderr, "{") {
+					t.Errorf("%s: expect a text error, got %s", tt.name, stderr)
+				}
+				continue
+			}
+
+			var report errorReport
+			if err := json.Unmarshal([]byte(stderr), &report); err != nil || strings.Count(stderr, "\n") != 1 {
+				t.Errorf("%s: expect a JSON error on a single line, got %v\n%s", tt.name, err, stderr)
+				continue
+			}
+			if report.Kind != tt.kind || report.ExitCode != tt.code || report.Message == "" || report.APIError != tt.apiError {
+				t.Errorf("%s: unexpected report %+v", tt.name, report)
+			}
+			// the status is only reported for the errors of the API
+			expectStatus := tt.status
+			if tt.kind == "error" {
+				expectStatus = 0
+			}
+			if report.Status != expectStatus {
+				t.Errorf("%s: unexpected status %d", tt.name, report.Status)
+			}
+		}
+	}
+}
+
+func TestJSONErrorsFlag(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		expect bool
+	}{
+		{[]string{"account"}, false},
+		{[]string{"account", "-json-errors"}, true},
+		{[]string{"account", "--json-errors=true"}, true},
+		{[]string{"analyze", "-json-errors=false", "text"}, false},
+		{[]string{"analyze", "--", "-json-errors"}, false},
+	}
+	for _, tt := range tests {
+		if jsonErrors := jsonErrorsFlag(tt.args); jsonErrors != tt.expect {
+			t.Errorf("%v: expect %v, got %v", tt.args, tt.expect, jsonErrors)
+		}
+	}
+
+	// the usage is printed when requested, whatever the format of the errors
+	var stderr strings.Builder
+	c := &cli{stderr: &stderr, getenv: func(string) string { return "" }}
+	if code := c.run([]string{"account", "-json-errors", "-help"}); code != exitOK || !strings.HasPrefix(stderr.String(), "usage: textrazor account\n") {
+		t.Errorf("expect the usage, got %d %s", code, stderr.String())
+	}
+}
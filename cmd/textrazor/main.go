@@ -0,0 +1,332 @@
+// Command textrazor analyzes texts and manages the dictionaries and classifiers of a TextRazor account.
+//
+//	textrazor analyze [-extractors entities,topics] [-classifiers id,...] [-url URL | text...]
+//	textrazor account
+//	textrazor dictionaries
+//	textrazor categories CLASSIFIER
+//	textrazor repl [-extractors list] [-classifiers list]
+//	textrazor plan [-account] [-concurrency n] [-rate n] [-documents n -size bytes | file...]
+//	textrazor completion bash|zsh|fish
+//
+// The text to analyze is read from the standard input when it isn't given. The repl command analyzes each line
+// typed or pasted with the same client, see its :help for the extractors and the format.
+// The plan command estimates the runtime and the quota usage of a batch before sending it, without any request
+// unless -account reads the limits of the account. Every command accepts
+// -key, the API key, -format table|json|csv and -json-errors, printing the errors as JSON objects
+// {"kind": ..., "exitCode": ..., "message": ..., "status": ..., "apiError": ..., "apiMessage": ...} on the standard error.
+//
+// The exit codes are:
+//
+//	0  success
+//	1  any other error
+//	2  usage: invalid command line or config file
+//	3  authentication: missing or rejected API key
+//	4  quota: daily quota exhausted or rate limited
+//	5  validation: request rejected by the API, e.g. an unknown classifier, or invalid input
+//	6  transport: API unreachable, timeout or server error, retrying may succeed
+//
+// The defaults of the flags are read from ~/.config/textrazor/config.yaml, or the file named by $TEXTRAZOR_CONFIG, e.g.
+//
+//	# the key itself, or a reference to an environment variable or a file
+//	key: env:TEXTRAZOR_API_KEY
+//	endpoint: https://api.textrazor.com/
+//	extractors: [entities, topics, words]
+//	format: json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bengentil/textrazor-go"
+	"github.com/bengentil/textrazor-go/output"
+)
+
+// cli holds the streams and the settings of a run
+type cli struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	getenv         func(string) string
+
+	config        config
+	key, endpoint string
+	format        output.Format
+	jsonErrors    bool
+	out           *output.Printer
+}
+
+// command is a subcommand, setup defines its flags on fs and returns the function running it with the remaining arguments
+type command struct {
+	usage string
+	setup func(c *cli, fs *flag.FlagSet) func(args []string) error
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"analyze":      {"analyze [-extractors list] [-classifiers list] [-url URL | text...]", analyze},
+		"account":      {"account", account},
+		"dictionaries": {"dictionaries", dictionaries},
+		"categories":   {"categories CLASSIFIER", categories},
+		"completion":   {"completion bash|zsh|fish", completion},
+		"repl":         {"repl [-extractors list] [-classifiers list]", replCommand},
+		"plan":         {"plan [-account] [-concurrency n] [-rate n] [-documents n -size bytes | file...]", plan},
+	}
+}
+
+// errUsage is returned by a command when its arguments are invalid, the usage is printed
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv}
+	os.Exit(c.run(os.Args[1:]))
+}
+
+// run runs the command of args and returns the exit code
+func (c *cli) run(args []string) int {
+	c.jsonErrors = jsonErrorsFlag(args)
+	err := c.exec(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	return c.fail(err)
+}
+
+func (c *cli) exec(args []string) error {
+	if len(args) == 0 {
+		return &usageError{msg: "missing command", silent: true, usage: c.usage}
+	}
+	cfg, err := loadConfig(c.getenv)
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	c.config = cfg
+	if args[0] == completeCommand {
+		c.complete(args[1:])
+		return nil
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return &usageError{msg: fmt.Sprintf("unknown command %q", args[0]), usage: c.usage}
+	}
+	fs := c.flagSet(args[0], cmd)
+	runCommand := cmd.setup(c, fs)
+	usage := fs.Usage
+	if c.jsonErrors {
+		// the errors are reported in JSON, the usage is only printed when requested
+		fs.SetOutput(io.Discard)
+		fs.Usage = func() {}
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) && c.jsonErrors {
+			fs.SetOutput(c.stderr)
+			usage()
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		// the flag package already printed the error and the usage, unless the errors are JSON
+		return &usageError{msg: err.Error(), silent: true}
+	}
+	c.out = output.New(c.stdout, c.format)
+	err = runCommand(fs.Args())
+	if errors.Is(err, errUsage) {
+		return &usageError{usage: usage}
+	}
+	return err
+}
+
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "usage: textrazor <command> [flags]\n\ncommands:")
+	for _, name := range commandNames() {
+		fmt.Fprintln(c.stderr, "  textrazor", commands[name].usage)
+	}
+}
+
+// commandNames returns the sorted names of the commands
+func commandNames() []string {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// flagSet returns the flag set of a command with the flags shared by every command
+func (c *cli) flagSet(name string, cmd command) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "usage: textrazor %s\n", cmd.usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&c.key, "key", "", "API key, $TEXTRAZOR_API_KEY or the key of the config file by default")
+	fs.StringVar(&c.endpoint, "endpoint", orDefault(c.config.Endpoint, textrazor.DefaultSecureEndpoint), "API endpoint")
+	c.format = output.Format(orDefault(c.config.Format, string(output.Table)))
+	fs.Var(&c.format, "format", "output format: table, json or csv")
+	fs.BoolVar(&c.jsonErrors, "json-errors", c.jsonErrors, "print the errors as JSON objects, with their kind and exit code")
+	return fs
+}
+
+// client returns a client of the endpoint with the API key of the flags, the environment or the config file
+func (c *cli) client() (*textrazor.Client, error) {
+	key := c.key
+	if key == "" {
+		key = c.getenv("TEXTRAZOR_API_KEY")
+	}
+	if key == "" {
+		var err error
+		if key, err = c.config.apiKey(c.getenv); err != nil {
+			return nil, err
+		}
+	}
+	if key == "" {
+		return nil, errMissingKey
+	}
+	return textrazor.NewCustomClient(key, textrazor.DefaultUseCompression, true, c.endpoint, c.endpoint,
+		textrazor.DefaultTransport(textrazor.DefaultUseCompression)), nil
+}
+
+// analysisFlags defines the -extractors and -classifiers flags, defaulting to the config, and returns the params they set
+func (c *cli) analysisFlags(fs *flag.FlagSet) func() textrazor.Params {
+	defaultExtractors := "entities,topics"
+	if len(c.config.Extractors) > 0 {
+		defaultExtractors = strings.Join(c.config.Extractors, ",")
+	}
+	extractors := fs.String("extractors", defaultExtractors, "comma separated extractors")
+	classifiers := fs.String("classifiers", strings.Join(c.config.Classifiers, ","), "comma separated classifiers")
+	return func() textrazor.Params {
+		params := textrazor.Params{"extractors": splitList(*extractors)}
+		if *classifiers != "" {
+			params["classifiers"] = splitList(*classifiers)
+		}
+		return params
+	}
+}
+
+func analyze(c *cli, fs *flag.FlagSet) func(args []string) error {
+	analysisParams := c.analysisFlags(fs)
+	url := fs.String("url", "", "URL of the document to analyze")
+	return func(args []string) error {
+		if *url != "" && len(args) > 0 {
+			return errUsage
+		}
+		client, err := c.client()
+		if err != nil {
+			return err
+		}
+		params := analysisParams()
+
+		var a *textrazor.Analysis
+		if *url != "" {
+			a, err = client.AnalyzeURLContext(context.Background(), *url, params)
+		} else {
+			text := strings.Join(args, " ")
+			if len(args) == 0 {
+				b, err := io.ReadAll(c.stdin)
+				if err != nil {
+					return fmt.Errorf("reading the text failed: %v", err)
+				}
+				text = string(b)
+			}
+			if strings.TrimSpace(text) == "" {
+				return fmt.Errorf("%w: empty text", errInvalidInput)
+			}
+			a, err = client.AnalyzeTextContext(context.Background(), text, params)
+		}
+		if err != nil {
+			return err
+		}
+		return c.out.Analysis(a)
+	}
+}
+
+func account(c *cli, fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		client, err := c.client()
+		if err != nil {
+			return err
+		}
+		a, err := client.GetAccountContext(context.Background())
+		if err != nil {
+			return err
+		}
+		return c.out.Print(a, []string{"PLAN", "CONCURRENT_LIMIT", "CONCURRENT_USED", "DAILY_REQUESTS", "USED_TODAY"}, [][]string{{
+			a.Plan, strconv.Itoa(a.ConcurrentRequestLimit), strconv.Itoa(a.ConcurrentRequestsUsed),
+			strconv.Itoa(a.PlanDailyIncludedRequests), strconv.Itoa(a.RequestsUsedToday),
+		}})
+	}
+}
+
+func dictionaries(c *cli, fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		client, err := c.client()
+		if err != nil {
+			return err
+		}
+		resp, err := client.GetDictionariesContext(context.Background())
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, len(resp.Dictionaries))
+		for i, d := range resp.Dictionaries {
+			rows[i] = []string{d.ID, d.MatchType, strconv.FormatBool(d.CaseInsensitive), d.Language}
+		}
+		dicts := resp.Dictionaries
+		if dicts == nil {
+			dicts = []textrazor.Dictionary{}
+		}
+		return c.out.Print(dicts, []string{"ID", "MATCH_TYPE", "CASE_INSENSITIVE", "LANGUAGE"}, rows)
+	}
+}
+
+func categories(c *cli, fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		client, err := c.client()
+		if err != nil {
+			return err
+		}
+		cats, err := client.AllClassifierCategoriesContext(context.Background(), args[0])
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, len(cats))
+		for i, cat := range cats {
+			rows[i] = []string{cat.CategoryID, cat.Label, cat.Query}
+		}
+		if cats == nil {
+			cats = []textrazor.Category{}
+		}
+		return c.out.Print(cats, []string{"ID", "LABEL", "QUERY"}, rows)
+	}
+}
+
+// splitList splits a comma separated list, skipping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Command tests
+
+// newServer returns a server replying with the fixtures of textrazortest, and records the analyzed texts
+func newServer(t *testing.T, texts *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-TextRazor-Key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"ok": false, "error": "Invalid API key"}`))
+			return
+		}
+		switch {
+		case r.URL.Path == "/":
+			r.ParseForm()
+			*texts = append(*texts, r.Form.Get("text")+r.Form.Get("url"))
+			w.Write([]byte(textrazortest.AnalysisFull))
+		case r.URL.Path == "/account/":
+			w.Write([]byte(textrazortest.Account))
+		case r.URL.Path == "/entities/":
+			w.Write([]byte(textrazortest.Dictionaries))
+		case strings.HasPrefix(r.URL.Path, "/categories/"):
+			w.Write([]byte(textrazortest.Categories))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func runCommand(server *httptest.Server, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	c := &cli{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr, getenv: func(name string) string {
+		if name == "TEXTRAZOR_API_KEY" {
+			return "key"
+		}
+		return ""
+	}}
+	if len(args) > 0 {
+		args = append([]string{args[0], "-endpoint", server.URL}, args[1:]...)
+	}
+	code := c.run(args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	var texts []string
+	server := newServer(t, &texts)
+	defer server.Close()
+
+	var tests = []struct {
+		args   []string
+		stdin  string
+		code   int
+		expect string
+	}{
+		{[]string{"analyze", "Barclays", "misled"}, "", 0, "\nEntities:\nID  ENTITY "},
+		{[]string{"analyze", "-format", "csv", "-extractors", "entities"}, "from stdin", 0, "kind,id,text,score,startingPos,endingPos\nentity,Barclays,Barclays,0.7246,0,8\n"},
+		{[]string{"analyze", "-format", "json", "-url", "https://www.bbc.co.uk/news"}, "", 0, `"entityId": "Barclays"`},
+		{[]string{"analyze", "-url", "https://www.bbc.co.uk/news", "text"}, "", 2, ""},
+		{[]string{"account", "-format", "csv"}, "", 0, "PLAN,CONCURRENT_LIMIT,CONCURRENT_USED,DAILY_REQUESTS,USED_TODAY\nFREE,"},
+		{[]string{"dictionaries"}, "", 0, "ID  "},
+		{[]string{"categories", "--format=csv", "test"}, "", 0, "ID,LABEL,QUERY\n"},
+		{[]string{"categories"}, "", 2, ""},
+		{[]string{"analyze", "-format", "xml"}, "", 2, ""},
+		{[]string{"analyze", "-key", "other", "text"}, "", 3, ""},
+		{[]string{"unknown"}, "", 2, ""},
+		{nil, "", 2, ""},
+	}
+	for _, tt := range tests {
+		code, stdout, stderr := runCommand(server, tt.stdin, tt.args...)
+		if code != tt.code || !strings.Contains(stdout, tt.expect) {
+			t.Errorf("%v: expect the exit code %d and %q, got %d\n%s\n%s", tt.args, tt.code, tt.expect, code, stdout, stderr)
+		}
+	}
+	if len(texts) != 3 || texts[0] != "Barclays misled" || texts[1] != "from stdin" || texts[2] != "https://www.bbc.co.uk/news" {
+		t.Error("unexpected analyzed texts", texts)
+	}
+
+	_, stdout, _ := runCommand(server, "", "dictionaries", "-format", "json")
+	var dicts []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &dicts); err != nil || len(dicts) != 2 {
+		t.Errorf("expect the dictionaries in JSON, got %s %v", stdout, err)
+	}
+}
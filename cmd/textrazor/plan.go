@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// plan estimates the runtime and the quota usage of a batch of analyses, see textrazor.Simulate
+func plan(c *cli, flags *flag.FlagSet) func(args []string) error {
+	documents := flags.Int("documents", 0, "number of documents, when no file is given")
+	size := flags.Int("size", 2000, "size of the documents in bytes, when no file is given")
+	concurrency := flags.Int("concurrency", textrazor.DefaultManyConcurrency, "concurrent analyses")
+	perMinute := flags.Float64("rate", 0, "requests per minute, no limit if 0")
+	latency := flags.Duration("latency", textrazor.DefaultLatencyModel.Base, "latency of the analysis of an empty document")
+	perKB := flags.Duration("latency-per-kb", textrazor.DefaultLatencyModel.PerKB, "latency added per KB of document")
+	useAccount := flags.Bool("account", false, "read the concurrency limit and the daily quota from the account")
+	limit := flags.Int("concurrency-limit", 0, "concurrency limit of the account, no limit if 0")
+	quota := flags.Int("daily-quota", 0, "daily requests of the plan, not checked if 0")
+	used := flags.Int("used-today", 0, "requests already sent today")
+	return func(args []string) error {
+		if len(args) > 0 && *documents > 0 {
+			return errUsage
+		}
+		s := textrazor.Simulation{
+			Concurrency:      *concurrency,
+			ConcurrencyLimit: *limit,
+			RateLimit:        *perMinute / 60,
+			DailyQuota:       *quota,
+			UsedToday:        *used,
+			Latency:          textrazor.LatencyModel{Base: *latency, PerKB: *perKB},
+		}
+		if len(args) > 0 {
+			sizes, err := fileSizes(args)
+			if err != nil {
+				return err
+			}
+			s.Sizes = sizes
+		} else {
+			s.Sizes = make([]int, *documents)
+			for i := range s.Sizes {
+				s.Sizes[i] = *size
+			}
+		}
+		if len(s.Sizes) == 0 {
+			return fmt.Errorf("%w: no documents, give files or -documents", errInvalidInput)
+		}
+
+		if *useAccount {
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			a, err := client.GetAccountContext(context.Background())
+			if err != nil {
+				return err
+			}
+			s.ConcurrencyLimit, s.DailyQuota, s.UsedToday = a.ConcurrentRequestLimit, a.PlanDailyIncludedRequests, a.RequestsUsedToday
+		}
+
+		r := textrazor.Simulate(s)
+		return c.out.Print(r, []string{"REQUESTS", "BYTES", "RUNTIME", "PEAK_CONCURRENCY", "DAYS", "PEAK_DAILY_REQUESTS", "OVERAGE"}, [][]string{{
+			strconv.Itoa(r.Requests), strconv.FormatInt(r.Bytes, 10), r.Runtime.Round(time.Second).String(),
+			strconv.Itoa(r.PeakConcurrency), strconv.Itoa(r.Days), strconv.Itoa(r.PeakDailyRequests), strconv.Itoa(r.Overage),
+		}})
+	}
+}
+
+// fileSizes returns the sizes of the files, and of the files found in the directories
+func fileSizes(paths []string) ([]int, error) {
+	var sizes []int
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			sizes = append(sizes, int(info.Size()))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidInput, err)
+		}
+	}
+	return sizes, nil
+}
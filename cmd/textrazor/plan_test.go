@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			Plan tests
+
+func TestPlan(t *testing.T) {
+	var texts []string
+	server := newServer(t, &texts)
+	defer server.Close()
+
+	dir := t.TempDir()
+	for name, size := range map[string]int{"a.txt": 1000, "b.txt": 3000, "sub/c.txt": 0} {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tests = []struct {
+		args   []string
+		code   int
+		stdout string
+	}{
+		{[]string{"-documents", "10", "-size", "1000", "-latency", "1s", "-latency-per-kb", "0", "-concurrency", "2", "-format", "csv"}, exitOK,
+			"REQUESTS,BYTES,RUNTIME,PEAK_CONCURRENCY,DAYS,PEAK_DAILY_REQUESTS,OVERAGE\n10,10000,5s,2,1,10,0\n"},
+		{[]string{"-latency", "1s", "-latency-per-kb", "1s", "-format", "csv", dir}, exitOK,
+			"REQUESTS,BYTES,RUNTIME,PEAK_CONCURRENCY,DAYS,PEAK_DAILY_REQUESTS,OVERAGE\n3,4000,4s,3,1,3,0\n"},
+		// the account allows 2 concurrent requests and 500 daily requests, 17 are used
+		{[]string{"-account", "-documents", "490", "-concurrency", "8", "-latency", "1s", "-format", "csv"}, exitOK,
+			"REQUESTS,BYTES,RUNTIME,PEAK_CONCURRENCY,DAYS,PEAK_DAILY_REQUESTS,OVERAGE\n490,980000,4m15s,2,1,507,7\n"},
+		{[]string{"-documents", "3", "-rate", "30", "-latency", "1s", "-latency-per-kb", "0", "-format", "csv"}, exitOK,
+			"REQUESTS,BYTES,RUNTIME,PEAK_CONCURRENCY,DAYS,PEAK_DAILY_REQUESTS,OVERAGE\n3,6000,5s,1,1,3,0\n"},
+		{[]string{}, exitValidation, ""},
+		{[]string{"-documents", "3", dir}, exitUsage, ""},
+		{[]string{filepath.Join(dir, "missing")}, exitValidation, ""},
+	}
+	for _, tt := range tests {
+		code, stdout, stderr := runCommand(server, "", append([]string{"plan"}, tt.args...)...)
+		if code != tt.code || stdout != tt.stdout {
+			t.Errorf("%s: expect %d %q, got %d %q %s", strings.Join(tt.args, " "), tt.code, tt.stdout, code, stdout, stderr)
+		}
+	}
+	if len(texts) != 0 {
+		t.Error("expect no analysis, got", texts)
+	}
+}
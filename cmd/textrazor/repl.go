@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/bengentil/textrazor-go"
+	"github.com/bengentil/textrazor-go/output"
+)
+
+// replHelp is printed by the :help command of the REPL
+const replHelp = `Type or paste a text, or a URL, to analyze it. Commands:
+  :extractors [list]    show or set the extractors, e.g. :extractors entities,topics, "-" for none
+  :toggle name...       add or remove extractors, e.g. :toggle words relations
+  :classifiers [list]   show or set the classifiers, "-" for none
+  :format [format]      show or set the output format: table, json or csv
+  :paste                analyze the lines up to a line with a single "."
+  :help                 show this help
+  :quit                 leave, like end of file
+`
+
+// repl is a session of the repl command
+type repl struct {
+	c                       *cli
+	client                  *textrazor.Client
+	in                      *bufio.Scanner
+	extractors, classifiers []string
+}
+
+func replCommand(c *cli, fs *flag.FlagSet) func(args []string) error {
+	analysisParams := c.analysisFlags(fs)
+	return func(args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		client, err := c.client()
+		if err != nil {
+			return err
+		}
+		params := analysisParams()
+		r := &repl{c: c, client: client, in: bufio.NewScanner(c.stdin), extractors: params["extractors"], classifiers: params["classifiers"]}
+		r.in.Buffer(nil, 1<<20)
+		return r.run()
+	}
+}
+
+// run reads the inputs up to the end of the input or :quit, an input failing doesn't end the session
+func (r *repl) run() error {
+	fmt.Fprintln(r.c.stderr, `textrazor repl, :help for the commands`)
+	for {
+		fmt.Fprint(r.c.stderr, "textrazor> ")
+		if !r.in.Scan() {
+			fmt.Fprintln(r.c.stderr)
+			return r.in.Err()
+		}
+		line := strings.TrimSpace(r.in.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, ":") {
+			r.report(r.analyze(line))
+			continue
+		}
+
+		name, arg, _ := strings.Cut(line[1:], " ")
+		arg = strings.TrimSpace(arg)
+		switch name {
+		case "q", "quit", "exit":
+			return nil
+		case "h", "help":
+			fmt.Fprint(r.c.stderr, replHelp)
+		case "extractors":
+			if arg == "-" {
+				r.extractors = nil
+			} else if arg != "" {
+				r.extractors = splitList(arg)
+			}
+			r.status()
+		case "toggle":
+			for _, name := range strings.Fields(strings.ReplaceAll(arg, ",", " ")) {
+				r.extractors = toggle(r.extractors, name)
+			}
+			r.status()
+		case "classifiers":
+			if arg == "-" {
+				r.classifiers = nil
+			} else if arg != "" {
+				r.classifiers = splitList(arg)
+			}
+			r.status()
+		case "format":
+			if arg != "" {
+				var f output.Format
+				if err := f.Set(arg); err != nil {
+					r.report(err)
+					continue
+				}
+				r.c.out.Format = f
+			}
+			fmt.Fprintln(r.c.stderr, "format:", r.c.out.Format)
+		case "paste":
+			r.report(r.paste())
+		default:
+			r.report(fmt.Errorf("unknown command :%s, :help for the commands", name))
+		}
+	}
+}
+
+// paste analyzes the lines read up to a line with a single "."
+func (r *repl) paste() error {
+	fmt.Fprintln(r.c.stderr, `paste the text, end with a line with a single "."`)
+	var lines []string
+	for r.in.Scan() && r.in.Text() != "." {
+		lines = append(lines, r.in.Text())
+	}
+	if err := r.in.Err(); err != nil {
+		return err
+	}
+	return r.analyze(strings.Join(lines, "\n"))
+}
+
+// analyze analyzes a text or a URL and prints the analysis, an interrupt cancels the analysis but not the session
+func (r *repl) analyze(input string) error {
+	if len(r.extractors) == 0 {
+		return fmt.Errorf("%w: no extractors, set them with :extractors", errInvalidInput)
+	}
+	if strings.TrimSpace(input) == "" {
+		return fmt.Errorf("%w: empty text", errInvalidInput)
+	}
+	params := textrazor.Params{"extractors": r.extractors}
+	if len(r.classifiers) > 0 {
+		params["classifiers"] = r.classifiers
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	start := time.Now()
+	var (
+		a   *textrazor.Analysis
+		err error
+	)
+	if isURL(input) {
+		a, err = r.client.AnalyzeURLContext(ctx, input, params)
+	} else {
+		a, err = r.client.AnalyzeTextContext(ctx, input, params)
+	}
+	if err != nil {
+		return err
+	}
+	if err := r.c.out.Analysis(a); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.c.stderr, "%d entities, %d topics, %d categories, %d sentences in %v\n",
+		len(a.Entities), len(a.Topics), len(a.Categories), len(a.Sentences), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// status prints the extractors and classifiers of the session
+func (r *repl) status() {
+	classifiers := strings.Join(r.classifiers, ",")
+	if classifiers == "" {
+		classifiers = "none"
+	}
+	fmt.Fprintf(r.c.stderr, "extractors: %s\nclassifiers: %s\n", strings.Join(r.extractors, ","), classifiers)
+}
+
+// report prints the error of an input, if any
+func (r *repl) report(err error) {
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(r.c.stderr, "interrupted")
+	} else if err != nil {
+		fmt.Fprintln(r.c.stderr, "error:", err)
+	}
+}
+
+// toggle adds name to list, or removes it when it is there
+func toggle(list []string, name string) []string {
+	for i, item := range list {
+		if item == name {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return append(list[:len(list):len(list)], name)
+}
+
+// isURL reports whether an input is a URL to analyze rather than a text
+func isURL(s string) bool {
+	return !strings.ContainsAny(s, " \t\n") && (strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://"))
+}
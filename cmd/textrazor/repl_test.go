@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			REPL tests
+
+func TestRepl(t *testing.T) {
+	var texts []string
+	server := newServer(t, &texts)
+	defer server.Close()
+
+	input := strings.Join([]string{
+		"first text",
+		"",
+		":toggle words entities",
+		":extractors",
+		":format csv",
+		"https://example.com/article",
+		":paste",
+		"second",
+		"text",
+		".",
+		":format xml",
+		":unknown",
+		":extractors -",
+		"ignored",
+		":extractors entities",
+		"third text",
+		":quit",
+		"ignored",
+	}, "\n")
+	code, stdout, stderr := runCommand(server, input, "repl", "-extractors", "entities")
+	if code != exitOK {
+		t.Fatalf("expect exit code 0, got %d: %s", code, stderr)
+	}
+	if expect := []string{"first text", "https://example.com/article", "second\ntext", "third text"}; !reflect.DeepEqual(texts, expect) {
+		t.Errorf("expect the analyzed texts %q, got %q", expect, texts)
+	}
+	if strings.Count(stdout, "kind,id,text,score") != 3 || !strings.Contains(stdout, "Entities") {
+		t.Errorf("expect a table then csv analyses, got %q", stdout)
+	}
+	for _, expect := range []string{
+		"extractors: words\nclassifiers: none",
+		"format: csv",
+		`error: unknown format "xml"`,
+		"error: unknown command :unknown",
+		"error: invalid input: no extractors",
+		" entities, ",
+	} {
+		if !strings.Contains(stderr, expect) {
+			t.Errorf("expect %q in the standard error, got %q", expect, stderr)
+		}
+	}
+
+	// the end of the input ends the session
+	if code, _, stderr := runCommand(server, "text", "repl"); code != exitOK {
+		t.Errorf("expect exit code 0 at the end of the input, got %d: %s", code, stderr)
+	}
+	if code, _, _ := runCommand(server, "", "repl", "text"); code != exitUsage {
+		t.Errorf("expect exit code %d with arguments, got %d", exitUsage, code)
+	}
+}
+
+func TestToggle(t *testing.T) {
+	var tests = []struct {
+		list   []string
+		name   string
+		expect []string
+	}{
+		{nil, "entities", []string{"entities"}},
+		{[]string{"entities", "topics"}, "words", []string{"entities", "topics", "words"}},
+		{[]string{"entities", "topics", "words"}, "topics", []string{"entities", "words"}},
+		{[]string{"entities"}, "entities", []string{}},
+	}
+	for _, tt := range tests {
+		if got := toggle(tt.list, tt.name); !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("toggle(%q, %q): expect %q, got %q", tt.list, tt.name, tt.expect, got)
+		}
+	}
+}
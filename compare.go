@@ -0,0 +1,125 @@
+package textrazor
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// Document is a text to analyze, identified in the results by its ID
+type Document struct {
+	ID   string
+	Text string
+}
+
+// CategoryChange is the difference of a category between two classifiers, for a document
+type CategoryChange struct {
+	CategoryID string
+	Label      string
+	// Old and New are the scores given by each classifier, 0 if the category isn't assigned
+	Old float64
+	New float64
+	// Assigned reports whether the category is assigned by the old and the new classifier
+	OldAssigned bool
+	NewAssigned bool
+}
+
+// Delta returns the score difference, positive when the new classifier scores the category higher
+func (c CategoryChange) Delta() float64 { return c.New - c.Old }
+
+// DocumentComparison lists the category changes of a document, the largest score delta first
+type DocumentComparison struct {
+	Document string
+	Changes  []CategoryChange
+	// Err is the error of the analysis of the document, it has no changes
+	Err error
+}
+
+// ClassifierComparison is the result of CompareClassifiers
+type ClassifierComparison struct {
+	Old       string
+	New       string
+	Documents []DocumentComparison
+}
+
+// Changed returns the documents whose category assignments differ, a score change alone isn't reported
+func (c *ClassifierComparison) Changed() []DocumentComparison {
+	var changed []DocumentComparison
+	for _, d := range c.Documents {
+		for _, ch := range d.Changes {
+			if ch.OldAssigned != ch.NewAssigned {
+				changed = append(changed, d)
+				break
+			}
+		}
+	}
+	return changed
+}
+
+// CompareClassifiers classifies each document with 2 classifiers, e.g. "sports_v1" and "sports_v2",
+// and reports the category differences, to check a new version of a classifier before switching to it.
+//
+// Both classifiers run in a single analysis per document, with the entities extractor if params has none. A failed analysis is reported in
+// DocumentComparison.Err and the comparison goes on with the next document, until the context is done.
+func (c *Client) CompareClassifiers(docs []Document, oldID, newID string, params Params) (*ClassifierComparison, error) {
+	return c.CompareClassifiersContext(context.Background(), docs, oldID, newID, params)
+}
+
+// CompareClassifiersContext is like CompareClassifiers with a context
+func (c *Client) CompareClassifiersContext(ctx context.Context, docs []Document, oldID, newID string, params Params, opts ...CallOption) (*ClassifierComparison, error) {
+	comparison := &ClassifierComparison{Old: oldID, New: newID, Documents: make([]DocumentComparison, 0, len(docs))}
+	for _, d := range docs {
+		if err := ctx.Err(); err != nil {
+			return comparison, err
+		}
+		p := copyParams(params)
+		p["classifiers"] = []string{oldID, newID}
+		if p.Get("extractors") == "" {
+			p.Set("extractors", "entities")
+		}
+		analysis, err := c.AnalyzeTextContext(ctx, d.Text, p, opts...)
+		if err != nil {
+			comparison.Documents = append(comparison.Documents, DocumentComparison{Document: d.ID, Err: err})
+			continue
+		}
+		comparison.Documents = append(comparison.Documents, DocumentComparison{Document: d.ID, Changes: compareCategories(analysis.Categories, oldID, newID)})
+	}
+	return comparison, nil
+}
+
+// compareCategories returns the changes between the categories of 2 classifiers, matched by id
+func compareCategories(categories []ScoredCategory, oldID, newID string) []CategoryChange {
+	byID := map[string]*CategoryChange{}
+	var changes []*CategoryChange
+	for _, cat := range categories {
+		if cat.ClassifierID != oldID && cat.ClassifierID != newID {
+			continue
+		}
+		ch, ok := byID[cat.CategoryID]
+		if !ok {
+			ch = &CategoryChange{CategoryID: cat.CategoryID, Label: cat.Label}
+			byID[cat.CategoryID] = ch
+			changes = append(changes, ch)
+		}
+		// the same id can be used by both classifiers, when comparing a classifier with itself
+		if cat.ClassifierID == oldID && !ch.OldAssigned {
+			ch.Old, ch.OldAssigned = cat.Score, true
+		} else if cat.ClassifierID == newID {
+			ch.New, ch.NewAssigned = cat.Score, true
+			if cat.Label != "" {
+				ch.Label = cat.Label
+			}
+		}
+	}
+
+	var result []CategoryChange
+	for _, ch := range changes {
+		if ch.OldAssigned != ch.NewAssigned || ch.Old != ch.New {
+			result = append(result, *ch)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return math.Abs(result[i].Delta()) > math.Abs(result[j].Delta())
+	})
+	return result
+}
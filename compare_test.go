@@ -0,0 +1,94 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+//***************************************************************
+// 			CompareClassifiers tests
+
+// classifyingTransport replies with the categories of the analyzed text, an unknown text is an error
+type classifyingTransport struct {
+	categories  map[string][]ScoredCategory
+	classifiers [][]string
+}
+
+func (t *classifyingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := ioutil.ReadAll(req.Body)
+	form, _ := url.ParseQuery(string(body))
+	t.classifiers = append(t.classifiers, form["classifiers"])
+
+	reply := map[string]interface{}{"ok": true}
+	status := http.StatusOK
+	categories, ok := t.categories[form.Get("text")]
+	if ok {
+		reply["response"] = map[string]interface{}{"categories": categories}
+	} else {
+		status = http.StatusBadRequest
+		reply["ok"] = false
+		reply["error"] = "unknown text"
+	}
+	b, _ := json.Marshal(reply)
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(b)), Request: req}, nil
+}
+
+func TestCompareClassifiers(t *testing.T) {
+	transport := &classifyingTransport{categories: map[string][]ScoredCategory{
+		"stable": {
+			{ClassifierID: "sports_v1", CategoryID: "1", Label: "football", Score: 0.8},
+			{ClassifierID: "sports_v2", CategoryID: "1", Label: "football", Score: 0.8},
+		},
+		"rescored": {
+			{ClassifierID: "sports_v1", CategoryID: "1", Label: "football", Score: 0.5},
+			{ClassifierID: "sports_v2", CategoryID: "1", Label: "football", Score: 0.6},
+		},
+		"changed": {
+			{ClassifierID: "sports_v1", CategoryID: "1", Label: "football", Score: 0.4},
+			{ClassifierID: "sports_v2", CategoryID: "2", Label: "rugby", Score: 0.9},
+			{ClassifierID: "other", CategoryID: "3", Label: "cinema", Score: 0.9},
+		},
+	}}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	docs := []Document{{"a", "stable"}, {"b", "rescored"}, {"c", "changed"}, {"d", "missing"}}
+	comparison, err := client.CompareClassifiers(docs, "sports_v1", "sports_v2", Params{"classifiers": {"other"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transport.classifiers) != 4 || !reflect.DeepEqual(transport.classifiers[0], []string{"sports_v1", "sports_v2"}) {
+		t.Error("expect both classifiers in a request per document, got", transport.classifiers)
+	}
+
+	var tests = []struct {
+		doc    string
+		expect []CategoryChange
+	}{
+		{"a", nil},
+		{"b", []CategoryChange{{CategoryID: "1", Label: "football", Old: 0.5, New: 0.6, OldAssigned: true, NewAssigned: true}}},
+		{"c", []CategoryChange{
+			{CategoryID: "2", Label: "rugby", New: 0.9, NewAssigned: true},
+			{CategoryID: "1", Label: "football", Old: 0.4, OldAssigned: true},
+		}},
+	}
+	for i, tt := range tests {
+		d := comparison.Documents[i]
+		if d.Document != tt.doc || d.Err != nil || !reflect.DeepEqual(d.Changes, tt.expect) {
+			t.Errorf("%s: expect %+v, got %+v", tt.doc, tt.expect, d)
+		}
+	}
+	if d := comparison.Documents[3]; d.Document != "d" || d.Err == nil {
+		t.Errorf("expect the failed analysis of d to be reported, got %+v", d)
+	}
+	if changed := comparison.Changed(); len(changed) != 1 || changed[0].Document != "c" {
+		t.Error("expect only c to change of categories, got", changed)
+	}
+	if delta := comparison.Documents[2].Changes[1].Delta(); delta != -0.4 {
+		t.Error("expect a -0.4 delta, got", delta)
+	}
+}
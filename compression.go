@@ -0,0 +1,79 @@
+package textrazor
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CallMeta describes how the response of a request was received
+type CallMeta struct {
+	// CompressionRequested reports whether the request accepted a gzip response
+	CompressionRequested bool
+	// ContentEncoding is the Content-Encoding of the response, "" for identity
+	ContentEncoding string
+	// Decompressed reports whether the body was received compressed and decompressed,
+	// by the client or the transport
+	Decompressed bool
+	// WireBytes is the size of the body read from the transport
+	WireBytes int
+	// TruncatedFrom is the size of the analyzed text before it was truncated, 0 if it wasn't, see WithTruncation
+	TruncatedFrom int
+}
+
+// errDecompression wraps the errors of the decompression of a gzip response
+var errDecompression = errors.New("gzip response decompression failed")
+
+// gzipMagic starts every gzip stream, JSON bodies never start with it
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// setAcceptEncoding sets the encodings accepted by the request according to the compression setting of the client,
+// unless a call header set it, so responses are handled the same way whatever the transport
+func (c *Client) setAcceptEncoding(h http.Header) bool {
+	if h.Get("Accept-Encoding") == "" {
+		if c.useCompression {
+			h.Set("Accept-Encoding", "gzip")
+		} else {
+			h.Set("Accept-Encoding", "identity")
+		}
+	}
+	return strings.Contains(h.Get("Accept-Encoding"), "gzip")
+}
+
+// decompressBody returns a reader of the body of a response, decompressed when it is gzip encoded, whether it was requested or not
+func decompressBody(resp *http.Response, body *bufio.Reader, meta *CallMeta) (io.Reader, error) {
+	meta.ContentEncoding = resp.Header.Get("Content-Encoding")
+	if resp.Uncompressed {
+		// decompressed by the transport, which removed the header
+		meta.ContentEncoding = "gzip"
+		meta.Decompressed = true
+		return body, nil
+	}
+	if !strings.EqualFold(meta.ContentEncoding, "gzip") {
+		if magic, _ := body.Peek(len(gzipMagic)); !bytes.Equal(magic, gzipMagic) {
+			return body, nil
+		}
+	}
+	r, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errDecompression, err)
+	}
+	meta.Decompressed = true
+	return gzipBody{r}, nil
+}
+
+// gzipBody reads a gzip encoded body, its errors are decompression errors
+type gzipBody struct{ r *gzip.Reader }
+
+func (b gzipBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err != nil && err != io.EOF {
+		err = fmt.Errorf("%w: %w", errDecompression, err)
+	}
+	return n, err
+}
@@ -0,0 +1,103 @@
+package textrazor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Compression tests
+
+func gzipped(t *testing.T, s string) []byte {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	w.Write([]byte(s))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestCompressionFallback(t *testing.T) {
+	compressed := gzipped(t, textrazortest.Account)
+	var tests = []struct {
+		name           string
+		useCompression bool
+		serverGzip     bool
+		serverHeader   bool
+		expectAccept   string
+		expectEncoding string
+		decompressed   bool
+	}{
+		{"compressed", true, true, true, "gzip", "gzip", true},
+		{"compression ignored", true, false, false, "gzip", "", false},
+		{"identity", false, false, false, "identity", "", false},
+		{"unrequested compression", false, true, true, "identity", "gzip", true},
+		{"compression without header", false, true, false, "identity", "", true},
+	}
+	for _, tt := range tests {
+		var accept string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept = r.Header.Get("Accept-Encoding")
+			if !tt.serverGzip {
+				w.Write([]byte(textrazortest.Account))
+				return
+			}
+			if tt.serverHeader {
+				w.Header().Set("Content-Encoding", "gzip")
+			}
+			w.Write(compressed)
+		}))
+		client := NewCustomClient(testAPIKey, tt.useCompression, false, server.URL, "", DefaultTransport(tt.useCompression))
+		account, err := client.GetAccount()
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		meta := account.HTTPResponse.Meta
+		if accept != tt.expectAccept || meta.CompressionRequested != tt.useCompression || meta.ContentEncoding != tt.expectEncoding || meta.Decompressed != tt.decompressed {
+			t.Errorf("%s: unexpected Accept-Encoding %s or meta %+v", tt.name, accept, meta)
+		}
+		if account.Plan == "" || !strings.HasPrefix(string(account.HTTPResponse.Body), "{") {
+			t.Errorf("%s: expect a decoded account, got %+v", tt.name, account)
+		}
+		if tt.decompressed && meta.WireBytes != len(compressed) {
+			t.Errorf("%s: expect %d bytes on the wire, got %d", tt.name, len(compressed), meta.WireBytes)
+		}
+	}
+}
+
+// uncompressingTransport replies like a transport which decompressed the response
+type uncompressingTransport struct{}
+
+func (uncompressingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Uncompressed: true,
+		Body: ioutil.NopCloser(strings.NewReader(textrazortest.Account)), Request: req}, nil
+}
+
+func TestCompressionByTransport(t *testing.T) {
+	client := NewCustomClient(testAPIKey, true, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, uncompressingTransport{})
+	account, err := client.GetAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta := account.HTTPResponse.Meta; !meta.Decompressed || meta.ContentEncoding != "gzip" {
+		t.Error("expect the transport decompression to be recorded, got", meta)
+	}
+}
+
+func TestCompressionCorrupted(t *testing.T) {
+	transport := &textrazortest.Transport{Status: http.StatusOK, Body: string(gzipMagic) + "not gzip"}
+	client := NewCustomClient(testAPIKey, true, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+	if _, err := client.GetAccount(); err == nil || !strings.Contains(err.Error(), "decompression failed") {
+		t.Error("expect a decompression error, got", err)
+	}
+}
@@ -0,0 +1,117 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WithAccountConcurrencyLimit limits the number of concurrent Analyze calls to
+// the ConcurrentRequestLimit of the account plan, so they aren't rejected by the API.
+//
+// The account is queried once, on the first Analyze call.
+func WithAccountConcurrencyLimit() Option {
+	return func(c *Client) { c.concurrency = &concurrencyLimiter{} }
+}
+
+// WithConcurrencyLimit limits the number of concurrent Analyze calls to n
+func WithConcurrencyLimit(n int) Option {
+	return func(c *Client) { c.concurrency = newConcurrencyLimiter(n) }
+}
+
+// concurrencyLimiter is a semaphore, sized from the account plan when created empty
+type concurrencyLimiter struct {
+	mu     sync.Mutex
+	slots  chan struct{}
+	lookup accountLimit
+}
+
+func newConcurrencyLimiter(n int) *concurrencyLimiter {
+	if n < 1 {
+		n = 1
+	}
+	return &concurrencyLimiter{slots: make(chan struct{}, n)}
+}
+
+// acquire waits for a free slot, the returned function releases it
+func (l *concurrencyLimiter) acquire(ctx context.Context, c *Client) (func(), error) {
+	slots, err := l.init(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// init sizes the semaphore from the account plan, see accountLimit
+func (l *concurrencyLimiter) init(ctx context.Context, c *Client) (chan struct{}, error) {
+	l.mu.Lock()
+	slots := l.slots
+	l.mu.Unlock()
+	if slots != nil {
+		return slots, nil
+	}
+	limit, err := l.lookup.get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.slots == nil {
+		l.slots = newConcurrencyLimiter(limit).slots
+	}
+	return l.slots, nil
+}
+
+// accountLimit looks up the ConcurrentRequestLimit of the account once, a failed lookup is retried by the next caller.
+// The lookup runs without holding a lock: the concurrent callers wait for its result or for their own context.
+type accountLimit struct {
+	mu    sync.Mutex
+	limit int
+	// running is closed when the lookup in progress ends, nil if there is none
+	running chan struct{}
+}
+
+// get returns the concurrency limit of the account of c, at least 1
+func (a *accountLimit) get(ctx context.Context, c *Client) (int, error) {
+	for {
+		a.mu.Lock()
+		if a.limit > 0 {
+			a.mu.Unlock()
+			return a.limit, nil
+		}
+		if running := a.running; running != nil {
+			a.mu.Unlock()
+			select {
+			case <-running:
+				continue
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		running := make(chan struct{})
+		a.running = running
+		a.mu.Unlock()
+
+		account, err := c.GetAccountContext(ctx)
+		a.mu.Lock()
+		if err == nil {
+			a.limit = account.ConcurrentRequestLimit
+			if a.limit < 1 {
+				a.limit = 1
+			}
+		}
+		a.running = nil
+		limit := a.limit
+		a.mu.Unlock()
+		close(running)
+		if err != nil {
+			return 0, fmt.Errorf("concurrency limit lookup failed: %w", err)
+		}
+		return limit, nil
+	}
+}
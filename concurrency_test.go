@@ -0,0 +1,132 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Concurrency limiter tests
+
+// concurrencyTransport replies to /account/ with the Account fixture (limit of 2)
+// and records the maximum number of concurrent analysis requests
+type concurrencyTransport struct {
+	current, max, accountCalls int32
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/account/" {
+		atomic.AddInt32(&t.accountCalls, 1)
+		return textrazortest.NewTransport(http.StatusOK, textrazortest.Account).RoundTrip(req)
+	}
+	n := atomic.AddInt32(&t.current, 1)
+	for {
+		m := atomic.LoadInt32(&t.max)
+		if n <= m || atomic.CompareAndSwapInt32(&t.max, m, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	atomic.AddInt32(&t.current, -1)
+	return textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities).RoundTrip(req)
+}
+
+func TestAccountConcurrencyLimit(t *testing.T) {
+	transport := &concurrencyTransport{}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithAccountConcurrencyLimit())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if transport.max != 2 {
+		t.Error("expect at most 2 concurrent requests, got", transport.max)
+	}
+	if transport.accountCalls != 1 {
+		t.Error("expect the account to be queried once, got", transport.accountCalls)
+	}
+}
+
+func TestConcurrencyLimitCanceled(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &concurrencyTransport{}, WithConcurrencyLimit(1))
+	release, err := client.concurrency.acquire(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.AnalyzeTextContext(ctx, testText, Params{"extractors": {"entities"}}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expect context.DeadlineExceeded while waiting for a slot, got", err)
+	}
+}
+
+func TestAccountConcurrencyLimitError(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, http.StatusOK, errorResponseBody, false), WithAccountConcurrencyLimit())
+	if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err == nil {
+		t.Error("expect the account lookup failure to be returned")
+	}
+	if client.concurrency.slots != nil {
+		t.Error("expect the limiter to stay uninitialized after a failed lookup")
+	}
+}
+
+// stalledTransport blocks the requests until they are canceled
+type stalledTransport struct{ started chan struct{} }
+
+func (t *stalledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.started <- struct{}{}
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestAccountConcurrencyLimitLookup(t *testing.T) {
+	transport := &stalledTransport{started: make(chan struct{}, 2)}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithAccountConcurrencyLimit())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := client.concurrency.acquire(ctx, client)
+		done <- err
+	}()
+	<-transport.started
+
+	// the other callers wait for the lookup in progress, or for their own context
+	short, cancelShort := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelShort()
+	if _, err := client.concurrency.acquire(short, client); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expect context.DeadlineExceeded while waiting for the lookup, got", err)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Error("expect the lookup to be canceled, got", err)
+	}
+	if len(transport.started) != 0 {
+		t.Error("expect a single lookup at a time")
+	}
+
+	// the failed lookup is retried by the next caller
+	short, cancelShort = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelShort()
+	client.concurrency.acquire(short, client)
+	if len(transport.started) != 1 {
+		t.Error("expect the lookup to be retried")
+	}
+}
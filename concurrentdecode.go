@@ -0,0 +1,84 @@
+package textrazor
+
+import "runtime"
+
+// DefaultConcurrentDecodeSize is the size from which the sections of analyses are decoded concurrently
+// with WithConcurrentDecode(0), smaller analyses are decoded sequentially
+const DefaultConcurrentDecodeSize = 1 << 20
+
+// WithConcurrentDecode decodes the sections of the analyses of at least minSize bytes, their sentences, entities,
+// entailments..., in concurrent goroutines once the JSON is split at the top level. 0 means DefaultConcurrentDecodeSize.
+//
+// It is meant to reduce the decoding time of very large analyses on multi-core machines, it allocates the same memory
+// but scans the JSON twice more: analyses are decoded sequentially when GOMAXPROCS is 1, where it is slower.
+// Measure the gain on the target machine with BenchmarkConcurrentDecode, e.g. go test -bench ConcurrentDecode -cpu 1,4.
+// The only recorded results are from a single-CPU machine, on a 14MB analysis: about 71ms sequential and 118ms
+// concurrent with -cpu 4, so no multi-core gain has been measured yet and the option is off by default.
+func WithConcurrentDecode(minSize int) Option {
+	return func(c *Client) {
+		if minSize <= 0 {
+			minSize = DefaultConcurrentDecodeSize
+		}
+		c.concurrentDecode = minSize
+	}
+}
+
+// concurrentSections decode the sections of an analysis decoded concurrently, by field of analysisFields
+var concurrentSections = map[string]func(*jsonLexer, *Analysis){
+	"entailments": func(l *jsonLexer, a *Analysis) { decodeEntailmentSlice(l, &a.Entailments) },
+	"entities":    func(l *jsonLexer, a *Analysis) { decodeEntitySlice(l, &a.Entities) },
+	"nounPhrases": func(l *jsonLexer, a *Analysis) { decodeNounPhraseSlice(l, &a.NounPhrases) },
+	"properties":  func(l *jsonLexer, a *Analysis) { decodePropertySlice(l, &a.Properties) },
+	"relations":   func(l *jsonLexer, a *Analysis) { decodeRelationSlice(l, &a.Relations) },
+	"sentences":   func(l *jsonLexer, a *Analysis) { decodeSentenceSlice(l, &a.Sentences) },
+}
+
+// decode decodes the JSON object of an analysis, concurrently when it is large enough
+func (a *Analysis) decode(b []byte) error {
+	if a.concurrentDecode > 0 && len(b) >= a.concurrentDecode && runtime.GOMAXPROCS(0) > 1 {
+		return decodeSections(b, a)
+	}
+	return decodeJSON(b, true, func(l *jsonLexer) { unmarshalAnalysis(l, a) })
+}
+
+// decodeSections decodes each section of concurrentSections in a goroutine, and the other fields in the calling
+// goroutine skipping the sections. The offsets of the sections are found by a first pass skipping the values.
+func decodeSections(b []byte, a *Analysis) error {
+	scan := &jsonLexer{data: b}
+	offsets := map[string]int{}
+	duplicated := false
+	scan.object(func(key []byte) {
+		field := scan.field(key, analysisFields)
+		if _, ok := concurrentSections[field]; ok && scan.peek() == '[' {
+			if _, ok := offsets[field]; ok {
+				duplicated = true
+			}
+			offsets[field] = scan.pos
+		}
+		scan.skip()
+	})
+	// on errors or duplicated sections, the sequential decoding behaves like encoding/json
+	if scan.err != nil || duplicated || len(offsets) < 2 {
+		return decodeJSON(b, true, func(l *jsonLexer) { unmarshalAnalysis(l, a) })
+	}
+
+	g := &group{}
+	deferred := make(map[int]bool, len(offsets))
+	for field, pos := range offsets {
+		decode, pos := concurrentSections[field], pos
+		deferred[pos] = true
+		g.Go(func() error {
+			l := &jsonLexer{data: b, pos: pos, interned: new([256]string)}
+			decode(l, a)
+			return l.err
+		})
+	}
+	err := decodeJSON(b, true, func(l *jsonLexer) {
+		l.deferred = deferred
+		unmarshalAnalysis(l, a)
+	})
+	if sectionErr := g.Wait(); err == nil {
+		err = sectionErr
+	}
+	return err
+}
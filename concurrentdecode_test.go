@@ -0,0 +1,103 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Concurrent decoding tests
+
+// responseObject returns the analysis object of a response body
+func responseObject(t testing.TB, body string) []byte {
+	var r struct{ Response json.RawMessage }
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatal(err)
+	}
+	return r.Response
+}
+
+func TestDecodeSections(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+	_, generated := textrazortest.GenerateAnalysis(textrazortest.GenerateOptions{Seed: 1, Sentences: 100, Entities: 300, Topics: 10})
+	for _, body := range []string{textrazortest.AnalysisFull, textrazortest.AnalysisRelations, textrazortest.AnalysisEntities, generated} {
+		b := responseObject(t, body)
+		expect, got := &Analysis{}, &Analysis{concurrentDecode: 1}
+		if err := expect.UnmarshalJSON(b); err != nil {
+			t.Fatal(err)
+		}
+		if err := got.UnmarshalJSON(b); err != nil {
+			t.Fatal(err)
+		}
+		got.concurrentDecode = 0
+		if !reflect.DeepEqual(got, expect) {
+			t.Errorf("expect the same analysis decoded concurrently, got %+v", got)
+		}
+	}
+
+	var tests = []struct {
+		body string
+		err  string
+	}{
+		{`{"sentences": [{"position": "x"}], "entities": [], "language": "eng"}`, `invalid integer "x"`},
+		{`{"sentences": [], "entities": [{"id": 1}], "language": 3}`, "expect a string, got number"},
+		{`{"sentences": [], "entities": [], "sentences": [{"position": 2}]}`, ""},
+		{`{"sentences": [], "entities": [`, "end of input"},
+	}
+	for _, tt := range tests {
+		expect := &Analysis{}
+		expectErr := expect.UnmarshalJSON([]byte(tt.body))
+		a := &Analysis{concurrentDecode: 1}
+		err := a.UnmarshalJSON([]byte(tt.body))
+		if tt.err == "" {
+			a.concurrentDecode = 0
+			if err != nil || expectErr != nil || !reflect.DeepEqual(a, expect) {
+				t.Errorf("%s: expect %+v, got %+v %v", tt.body, expect, a, err)
+			}
+		} else if err == nil || expectErr == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expect the error %q, got %v", tt.body, tt.err, err)
+		}
+	}
+}
+
+func TestWithConcurrentDecode(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisFull})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithConcurrentDecode(1))
+	a, err := client.AnalyzeText(testText, Params{"extractors": {"entities", "words", "relations"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.concurrentDecode != 1 || len(a.Entities) == 0 || len(a.Sentences) == 0 || len(a.Relations) == 0 {
+		t.Error("expect the analysis decoded concurrently, got", a)
+	}
+	if c := NewClient(testAPIKey, WithConcurrentDecode(0)); c.concurrentDecode != DefaultConcurrentDecodeSize {
+		t.Error("expect the default size, got", c.concurrentDecode)
+	}
+}
+
+func BenchmarkConcurrentDecode(b *testing.B) {
+	_, body := textrazortest.GenerateAnalysis(textrazortest.GenerateOptions{Seed: 1, Sentences: 2000, Entities: 20000, Topics: 100})
+	object := responseObject(b, body)
+	for _, minSize := range []int{0, 1} {
+		name := "sequential"
+		if minSize > 0 {
+			name = "concurrent"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(object)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				a := &Analysis{concurrentDecode: minSize}
+				if err := a.UnmarshalJSON(object); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
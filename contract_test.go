@@ -0,0 +1,97 @@
+package textrazor
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Contract tests
+//
+// compare the textrazortest fixtures and the responses recorded from the live API
+// (testdata/recorded/<kind>_*.json, see integration_test.go) with the structs decoding them
+
+// knownContractFindings lists the differences we know about,
+// remove an entry once it is modeled
+var knownContractFindings = map[string]bool{
+	"response.id: unmodeled field":          true,
+	"response.lastUpdated: unmodeled field": true,
+}
+
+// recordedKinds maps the prefix of recorded files to the response decoding them
+var recordedKinds = map[string]func() Response{
+	"analysis":     func() Response { return &Analysis{} },
+	"account":      func() Response { return &Account{} },
+	"dictionaries": func() Response { return &EmptyResponse{} },
+	"dictionary":   func() Response { return &Dictionary{} },
+	"entries":      func() Response { return &DictionaryEntryList{} },
+	"entry":        func() Response { return &DictionaryEntry{} },
+	"categories":   func() Response { return &CategoryList{} },
+	"category":     func() Response { return &Category{} },
+}
+
+type contractSample struct {
+	name     string
+	body     []byte
+	response Response
+}
+
+func contractSamples(t *testing.T) []contractSample {
+	samples := []contractSample{
+		{"Account", []byte(textrazortest.Account), &Account{}},
+		{"Dictionaries", []byte(textrazortest.Dictionaries), &EmptyResponse{}},
+		{"Dictionary", []byte(textrazortest.Dictionary), &Dictionary{}},
+		{"DictionaryEntries", []byte(textrazortest.DictionaryEntries), &DictionaryEntryList{}},
+		{"DictionaryEntry", []byte(textrazortest.DictionaryEntry), &DictionaryEntry{}},
+		{"Categories", []byte(textrazortest.Categories), &CategoryList{}},
+		{"Category", []byte(textrazortest.Category), &Category{}},
+		{"OK", []byte(textrazortest.OK), &EmptyResponse{}},
+		{"Error", []byte(textrazortest.Error), &EmptyResponse{}},
+	}
+	for name, body := range textrazortest.AnalysisFixtures {
+		samples = append(samples, contractSample{name, []byte(body), &Analysis{}})
+	}
+
+	files, _ := filepath.Glob(filepath.Join("testdata", "recorded", "*.json"))
+	for _, f := range files {
+		kind := strings.SplitN(filepath.Base(f), "_", 2)[0]
+		newResponse, ok := recordedKinds[kind]
+		if !ok {
+			t.Error("unknown kind of recorded response", f)
+			continue
+		}
+		body, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		samples = append(samples, contractSample{f, body, newResponse()})
+	}
+	return samples
+}
+
+func TestContract(t *testing.T) {
+	found := map[string]bool{}
+	for _, s := range contractSamples(t) {
+		findings, err := schemaFindings(s.body, s.response)
+		if err != nil {
+			t.Error(s.name, err)
+			continue
+		}
+		for _, f := range findings {
+			found[f] = true
+			if !knownContractFindings[f] {
+				t.Errorf("%s: %s\n\tmodel it in the %T struct, or add it to knownContractFindings", s.name, f, s.response)
+			}
+		}
+	}
+	for f := range knownContractFindings {
+		if !found[f] {
+			t.Errorf("%s: not found anymore, remove it from knownContractFindings", f)
+		}
+	}
+}
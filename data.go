@@ -0,0 +1,114 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// EntryData holds the custom data of a dictionary entry, returned with the entities matching it.
+// Like url.Values, each key has a list of values, the format of the API.
+type EntryData map[string][]string
+
+// Get returns the first value of a key, or "" if there is none
+func (d EntryData) Get(key string) string {
+	if len(d[key]) == 0 {
+		return ""
+	}
+	return d[key][0]
+}
+
+// Set replaces the values of a key with a single value
+func (d EntryData) Set(key, value string) {
+	d[key] = []string{value}
+}
+
+// Add appends a value to a key
+func (d EntryData) Add(key, value string) {
+	d[key] = append(d[key], value)
+}
+
+// Del deletes the values of a key
+func (d EntryData) Del(key string) {
+	delete(d, key)
+}
+
+// Keys returns the sorted keys
+func (d EntryData) Keys() []string {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Equal reports whether d and o hold the same values, nil and empty data are equal
+func (d EntryData) Equal(o EntryData) bool {
+	if len(d) != len(o) {
+		return false
+	}
+	for k, values := range d {
+		other, ok := o[k]
+		if !ok || len(values) != len(other) {
+			return false
+		}
+		for i := range values {
+			if values[i] != other[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (d EntryData) clone() EntryData {
+	if d == nil {
+		return nil
+	}
+	c := make(EntryData, len(d))
+	for k, v := range d {
+		c[k] = copyStrings(v)
+	}
+	return c
+}
+
+// UnmarshalJSON decodes EntryData, tolerating single values instead of lists,
+// and numbers or booleans instead of strings
+func (d *EntryData) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*d = nil
+		return nil
+	}
+
+	data := make(EntryData, len(raw))
+	for k, v := range raw {
+		v = bytes.TrimSpace(v)
+		var items []json.RawMessage
+		if len(v) > 0 && v[0] == '[' {
+			if err := json.Unmarshal(v, &items); err != nil {
+				return err
+			}
+		} else {
+			items = []json.RawMessage{v}
+		}
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			s, ok, err := jsonScalar(item)
+			if err != nil || (len(item) > 0 && (item[0] == '{' || item[0] == '[')) {
+				return fmt.Errorf("invalid data value for '%s': %s", k, item)
+			}
+			if ok {
+				values = append(values, s)
+			}
+		}
+		data[k] = values
+	}
+	*d = data
+	return nil
+}
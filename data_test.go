@@ -0,0 +1,83 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+//***************************************************************
+// 			EntryData tests
+
+func TestEntryDataUnmarshal(t *testing.T) {
+	var tests = []struct {
+		json       string
+		expect     EntryData
+		shouldFail bool
+	}{
+		{`{"ticker": ["BARC"], "exchange": ["LSE", "NYSE"]}`, EntryData{"ticker": {"BARC"}, "exchange": {"LSE", "NYSE"}}, false},
+		{`{"born": "1943"}`, EntryData{"born": {"1943"}}, false},
+		{`{"born": 1943, "alive": [true]}`, EntryData{"born": {"1943"}, "alive": {"true"}}, false},
+		{`{"born": null, "died": []}`, EntryData{"born": {}, "died": {}}, false},
+		{`{}`, EntryData{}, false},
+		{`null`, nil, false},
+		{`{"born": {"year": 1943}}`, nil, true},
+		{`{"born": [["1943"]]}`, nil, true},
+		{`["1943"]`, nil, true},
+	}
+	for _, tt := range tests {
+		var d EntryData
+		err := json.Unmarshal([]byte(tt.json), &d)
+		if (err != nil) != tt.shouldFail {
+			t.Error(tt.json, "unexpected error", err)
+			continue
+		}
+		if !tt.shouldFail && !reflect.DeepEqual(d, tt.expect) {
+			t.Errorf("%s: expect %#v, got %#v", tt.json, tt.expect, d)
+		}
+	}
+}
+
+func TestEntryDataRoundTrip(t *testing.T) {
+	e := DictionaryEntry{ID: "DEV1", Text: "Ken Thompson", Data: EntryData{}}
+	e.Data.Set("born", "1943")
+	e.Data.Add("languages", "B")
+	e.Data.Add("languages", "Go")
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded DictionaryEntry
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Data.Equal(e.Data) || decoded.Data.Get("born") != "1943" || decoded.Data.Get("missing") != "" {
+		t.Errorf("expect %v to survive a round trip, got %v", e.Data, decoded.Data)
+	}
+	if keys := decoded.Data.Keys(); !reflect.DeepEqual(keys, []string{"born", "languages"}) {
+		t.Error("expect sorted keys, got", keys)
+	}
+	decoded.Data.Del("born")
+	if decoded.Data.Equal(e.Data) {
+		t.Error("expect data to differ after a deletion")
+	}
+}
+
+func TestEntryDataEqual(t *testing.T) {
+	var tests = []struct {
+		a, b   EntryData
+		expect bool
+	}{
+		{nil, EntryData{}, true},
+		{EntryData{"a": {"1"}}, EntryData{"a": {"1"}}, true},
+		{EntryData{"a": {"1"}}, EntryData{"a": {"1", "2"}}, false},
+		{EntryData{"a": {"1", "2"}}, EntryData{"a": {"2", "1"}}, false},
+		{EntryData{"a": {"1"}}, EntryData{"b": {"1"}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.expect {
+			t.Error(tt.a, tt.b, "expect", tt.expect, "got", got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phases of an API call reported by DeadlineError
+const (
+	PhaseQuota       = "quota"
+	PhaseConcurrency = "concurrency"
+	PhaseRateLimiter = "rate limiter"
+	PhaseHTTP        = "http"
+	PhaseRetryWait   = "retry wait"
+	PhaseDecode      = "decode"
+)
+
+// PhaseDuration is the time spent in a phase of an API call
+type PhaseDuration struct {
+	Phase    string
+	Duration time.Duration
+}
+
+// DeadlineError is returned when the deadline of the context, or the timeout of the call, is exceeded.
+// errors.Is(err, context.DeadlineExceeded) is true.
+//
+// Breakdown lists the time spent in each phase of the call, in order of first occurrence.
+// Phases may overlap, e.g. the concurrency phase includes the account lookup sizing the limit.
+type DeadlineError struct {
+	Err       error
+	Elapsed   time.Duration
+	Breakdown []PhaseDuration
+}
+
+func (e *DeadlineError) Error() string {
+	phases := make([]string, len(e.Breakdown))
+	for i, p := range e.Breakdown {
+		phases[i] = fmt.Sprintf("%s %v", p.Phase, p.Duration.Round(time.Millisecond))
+	}
+	return fmt.Sprintf("deadline exceeded after %v (%s): %v", e.Elapsed.Round(time.Millisecond), strings.Join(phases, ", "), e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *DeadlineError) Unwrap() error { return e.Err }
+
+// Is reports whether target is context.DeadlineExceeded
+func (e *DeadlineError) Is(target error) bool { return target == context.DeadlineExceeded }
+
+// callTimer records the time spent in each phase of an API call, it is shared by nested requests through the context
+type callTimer struct {
+	start time.Time
+
+	mu     sync.Mutex
+	phases []PhaseDuration
+}
+
+type callTimerKey struct{}
+
+// withCallTimer returns the timer of the call, and whether it was created by this call
+func withCallTimer(ctx context.Context) (context.Context, *callTimer, bool) {
+	if t, ok := ctx.Value(callTimerKey{}).(*callTimer); ok {
+		return ctx, t, false
+	}
+	t := &callTimer{start: time.Now()}
+	return context.WithValue(ctx, callTimerKey{}, t), t, true
+}
+
+// track adds the time elapsed since start to phase, it does nothing on a nil timer
+func (t *callTimer) track(phase string, start time.Time) {
+	if t == nil {
+		return
+	}
+	d := time.Since(start)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.phases {
+		if t.phases[i].Phase == phase {
+			t.phases[i].Duration += d
+			return
+		}
+	}
+	t.phases = append(t.phases, PhaseDuration{phase, d})
+}
+
+// wrap returns a DeadlineError with the breakdown of the call if err is caused by an exceeded deadline
+func (t *callTimer) wrap(err error) error {
+	var deadlineErr *DeadlineError
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) || errors.As(err, &deadlineErr) {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &DeadlineError{Err: err, Elapsed: time.Since(t.start), Breakdown: append([]PhaseDuration(nil), t.phases...)}
+}
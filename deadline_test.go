@@ -0,0 +1,67 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			Deadline breakdown tests
+
+func TestDeadlineBreakdown(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = 20 * time.Millisecond
+
+	var tests = []struct {
+		name         string
+		transport    http.RoundTripper
+		opts         []Option
+		expectPhases []string
+	}{
+		{"hanging request", hangingTransport{}, nil, []string{PhaseHTTP}},
+		{"rate limited", FakeTransport(t, http.StatusTooManyRequests, `{"ok":false}`, false), []Option{WithRateLimitRetries(10, 0)}, []string{PhaseHTTP, PhaseDecode, PhaseRetryWait}},
+		{"throttled", hangingTransport{}, []Option{WithRateLimiter(NewRateLimiter(1, 1)), WithQuotaGuard(NewQuotaGuard(10, QuotaWait))}, []string{PhaseQuota, PhaseRateLimiter, PhaseHTTP}},
+	}
+
+	for _, tt := range tests {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, tt.transport, tt.opts...)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		_, err := client.AnalyzeTextContext(ctx, testText, Params{"extractors": {"entities"}})
+		cancel()
+
+		var deadlineErr *DeadlineError
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &deadlineErr) {
+			t.Error(tt.name, "expect a DeadlineError, got", err)
+			continue
+		}
+		t.Log(err)
+		if len(deadlineErr.Breakdown) != len(tt.expectPhases) {
+			t.Error(tt.name, "expect phases", tt.expectPhases, "got", deadlineErr.Breakdown)
+			continue
+		}
+		var total time.Duration
+		for i, p := range deadlineErr.Breakdown {
+			if p.Phase != tt.expectPhases[i] {
+				t.Error(tt.name, "expect phase", tt.expectPhases[i], "got", p.Phase)
+			}
+			total += p.Duration
+		}
+		if total > deadlineErr.Elapsed || deadlineErr.Elapsed < 50*time.Millisecond {
+			t.Error(tt.name, "expect the phases to fit in the elapsed time, got", total, deadlineErr.Elapsed)
+		}
+	}
+}
+
+func TestDeadlineErrorNotWrapped(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, hangingTransport{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetAccountContext(ctx)
+	var deadlineErr *DeadlineError
+	if !errors.Is(err, context.Canceled) || errors.As(err, &deadlineErr) {
+		t.Error("expect a canceled context not to be reported as a deadline, got", err)
+	}
+}
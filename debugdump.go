@@ -0,0 +1,98 @@
+package textrazor
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maskedHeaders are the headers holding credentials, masked in the dumps
+var maskedHeaders = []string{apiKeyHeader, "Authorization", "Proxy-Authorization"}
+
+// WithDebugDump writes a transcript of every HTTP request attempt and response to w, e.g. to attach to a support
+// ticket: each request as a curl command reproducing it, each response with its status, headers and body.
+// The API key and the other credentials are masked, the texts analyzed are written as is.
+//
+// The transcripts of concurrent calls are written whole but may interleave, the responses follow their requests.
+func WithDebugDump(w io.Writer) Option {
+	var mu sync.Mutex
+	write := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		io.WriteString(w, s)
+	}
+	return func(c *Client) {
+		OnRequest(func(req *http.Request) { write(CurlCommand(req) + "\n") })(c)
+		OnResponse(func(r *HTTPResponse) { write(dumpResponse(r)) })(c)
+	}
+}
+
+// CurlCommand returns a curl command sending req, with the API key and the other credentials masked.
+// The body is read with GetBody, it is omitted when req can't provide a copy of it.
+func CurlCommand(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString("curl -X " + req.Method + " " + shellQuote(req.URL.String()))
+	for _, k := range sortedKeys(req.Header) {
+		for _, v := range req.Header[k] {
+			b.WriteString(" -H " + shellQuote(k+": "+maskHeader(k, v)))
+		}
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, err := io.ReadAll(body)
+			body.Close()
+			if err == nil && len(data) > 0 {
+				b.WriteString(" --data-binary " + shellQuote(string(data)))
+			}
+		}
+	}
+	return b.String()
+}
+
+// dumpResponse returns the transcript of a response, like curl -i prefixed with "< "
+func dumpResponse(r *HTTPResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "< HTTP %d %s\n", r.Status, http.StatusText(r.Status))
+	for _, k := range sortedKeys(r.Headers) {
+		for _, v := range r.Headers[k] {
+			fmt.Fprintf(&b, "< %s: %s\n", k, maskHeader(k, v))
+		}
+	}
+	b.WriteString("<\n")
+	if len(r.Body) > 0 {
+		b.Write(r.Body)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// maskHeader returns the value v of the header k, masked when it holds credentials,
+// the last 4 characters of long values are kept to tell the keys apart
+func maskHeader(k, v string) string {
+	for _, masked := range maskedHeaders {
+		if strings.EqualFold(k, masked) {
+			if len(v) < 16 {
+				return "****"
+			}
+			return "****" + v[len(v)-4:]
+		}
+	}
+	return v
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func sortedKeys(h http.Header) []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
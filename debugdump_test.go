@@ -0,0 +1,74 @@
+package textrazor
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Debug dump tests
+
+func TestCurlCommand(t *testing.T) {
+	req, _ := http.NewRequest("POST", "https://api.textrazor.com/", strings.NewReader("text=it's+here&extractors=entities"))
+	req.Header.Set(apiKeyHeader, "0123456789abcdef0123")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	expect := `curl -X POST 'https://api.textrazor.com/' -H 'Content-Type: application/x-www-form-urlencoded' -H 'X-Textrazor-Key: ****0123' --data-binary 'text=it'\''s+here&extractors=entities'`
+	if got := CurlCommand(req); got != expect {
+		t.Errorf("expect %s, got %s", expect, got)
+	}
+	// the body can be dumped again
+	if got := CurlCommand(req); got != expect {
+		t.Errorf("expect %s on the second dump, got %s", expect, got)
+	}
+
+	var tests = []struct {
+		key, expect string
+	}{
+		{"short", "****"},
+		{"0123456789abcde", "****"},
+		{"0123456789abcdef", "****cdef"},
+	}
+	for _, tt := range tests {
+		req.Header.Set(apiKeyHeader, tt.key)
+		if got := CurlCommand(req); !strings.Contains(got, "'X-Textrazor-Key: "+tt.expect+"'") || strings.Contains(got, tt.key) {
+			t.Errorf("%s: expect the key masked as %s, got %s", tt.key, tt.expect, got)
+		}
+	}
+}
+
+func TestWithDebugDump(t *testing.T) {
+	var dump bytes.Buffer
+	transport := textrazortest.NewSequenceTransport(rateLimited, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithRateLimitRetries(1, time.Second), WithDebugDump(&dump), WithResponseBody(false))
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	a, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.HTTPResponse.Body != nil {
+		t.Error("expect the body discarded for the client, got", len(a.HTTPResponse.Body))
+	}
+	got := dump.String()
+	if strings.Contains(got, testAPIKey) {
+		t.Error("expect the API key masked, got", got)
+	}
+	if n := strings.Count(got, "curl -X POST "); n != 2 {
+		t.Errorf("expect the 2 attempts dumped, got %d in %s", n, got)
+	}
+	for _, expect := range []string{"-H 'X-Textrazor-Key: ****'", "--data-binary 'extractors=entities&", "< HTTP 429 Too Many Requests\n", "< HTTP 200 OK\n", textrazortest.AnalysisEntities} {
+		if !strings.Contains(got, expect) {
+			t.Errorf("expect %q in the dump, got %s", expect, got)
+		}
+	}
+	if strings.Index(got, "< HTTP 429") > strings.LastIndex(got, "curl -X") {
+		t.Error("expect the responses after their requests, got", got)
+	}
+}
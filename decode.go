@@ -0,0 +1,490 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+//go:generate go run gendecoders.go Analysis Entity Topic Entailment NounPhrase Property Relation RelationParam Sentence Word Sense SpellingSuggestion
+
+// The structs of an analysis, its entities, topics, sentences, words..., are decoded by the functions
+// of decoders_generated.go rather than by the reflection of encoding/json.
+// They behave like encoding/json, field names are matched case-insensitively and unknown fields ignored,
+// but numbers are also accepted as strings, like the tolerant decoding of numbers.go.
+// The fields of other types are decoded by encoding/json.
+// json.Unmarshal still validates the whole input before calling them, ParseAnalysis doesn't.
+
+// ParseAnalysis decodes the body of an analysis response, e.g. a recorded body or the Response of an ArchivedSample,
+// in a single pass with the generated decoders, for programs decoding many stored analyses.
+// Unlike json.Unmarshal, the fields which aren't modeled are skipped without being validated.
+//
+// The HTTPResponse of the analysis holds the fields of the body, but not its Status, Headers or Body.
+// A response which isn't ok returns an *APIError.
+func ParseAnalysis(body []byte) (*Analysis, error) {
+	a := &Analysis{}
+	r := &HTTPResponse{Response: a}
+	a.setHTTPResponse(r)
+	err := decodeJSON(body, true, func(l *jsonLexer) {
+		l.object(func(key []byte) {
+			switch l.field(key, httpResponseFields) {
+			case "time":
+				l.float(&r.Time)
+			case "response":
+				unmarshalAnalysis(l, a)
+			case "ok":
+				l.bool(&r.Ok)
+			case "error":
+				l.string(&r.Error)
+			case "message":
+				l.string(&r.Message)
+			default:
+				l.skip()
+			}
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("http response body parsing failed: %v", err)
+	}
+	if !r.Ok {
+		return nil, newAPIError(r)
+	}
+	return a, nil
+}
+
+var httpResponseFields = []string{"time", "response", "ok", "error", "message"}
+
+// UnmarshalJSON decodes an Entity, tolerating numeric fields encoded as strings
+func (e *Entity) UnmarshalJSON(b []byte) error {
+	return decodeJSON(b, false, func(l *jsonLexer) { unmarshalEntity(l, e) })
+}
+
+// UnmarshalJSON decodes a Topic, tolerating an id or a score encoded as a string
+func (t *Topic) UnmarshalJSON(b []byte) error {
+	return decodeJSON(b, false, func(l *jsonLexer) { unmarshalTopic(l, t) })
+}
+
+// UnmarshalJSON decodes an Entailment, tolerating an id or scores encoded as strings
+func (e *Entailment) UnmarshalJSON(b []byte) error {
+	return decodeJSON(b, false, func(l *jsonLexer) { unmarshalEntailment(l, e) })
+}
+
+// UnmarshalJSON decodes a Sentence
+func (s *Sentence) UnmarshalJSON(b []byte) error {
+	return decodeJSON(b, false, func(l *jsonLexer) { unmarshalSentence(l, s) })
+}
+
+// UnmarshalJSON decodes a Word
+func (w *Word) UnmarshalJSON(b []byte) error {
+	return decodeJSON(b, false, func(l *jsonLexer) { unmarshalWord(l, w) })
+}
+
+// decodeJSON decodes the JSON value b with decode, b must hold a single value.
+// intern shares the short strings read, worth it for the many repeated tokens, lemmas, parts of speech... of an analysis.
+func decodeJSON(b []byte, intern bool, decode func(l *jsonLexer)) error {
+	l := &jsonLexer{data: b}
+	if intern {
+		l.interned = new([256]string)
+	}
+	decode(l)
+	if l.err == nil && l.peek() != 0 {
+		l.fail("invalid data after top-level value")
+	}
+	return l.err
+}
+
+// jsonLexer reads the JSON values of the generated decoders.
+// The first error is kept and ends the input, the values read afterwards are left unchanged.
+type jsonLexer struct {
+	data []byte
+	pos  int
+	err  error
+	// interned are the last short strings read, by hash, nil if strings aren't interned
+	interned *[256]string
+	// deferred are the offsets of the arrays skipped as they are decoded concurrently, see decodeSections
+	deferred map[int]bool
+}
+
+func (l *jsonLexer) fail(format string, a ...interface{}) {
+	if l.err == nil {
+		l.err = fmt.Errorf("%s at offset %d", fmt.Sprintf(format, a...), l.pos)
+	}
+	l.pos = len(l.data)
+}
+
+// peek skips the white space and returns the next byte, 0 at the end of the input
+func (l *jsonLexer) peek() byte {
+	for ; l.pos < len(l.data); l.pos++ {
+		switch c := l.data[l.pos]; c {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return c
+		}
+	}
+	return 0
+}
+
+// found describes the next value, for errors
+func (l *jsonLexer) found() string {
+	switch c := l.peek(); c {
+	case 0:
+		return "end of input"
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+func (l *jsonLexer) literal(s string) bool {
+	if l.peek() != s[0] || !bytes.HasPrefix(l.data[l.pos:], []byte(s)) {
+		return false
+	}
+	l.pos += len(s)
+	return true
+}
+
+// null reads a null, if it is the next value
+func (l *jsonLexer) null() bool { return l.literal("null") }
+
+func (l *jsonLexer) expect(c byte, what string) bool {
+	if l.peek() != c {
+		l.fail("expect %s, got %s", what, l.found())
+		return false
+	}
+	l.pos++
+	return true
+}
+
+// object calls field with the key of each field of an object, field must read its value, null is an empty object
+func (l *jsonLexer) object(field func(key []byte)) {
+	if l.null() || !l.expect('{', "object") {
+		return
+	}
+	if l.peek() == '}' {
+		l.pos++
+		return
+	}
+	for l.err == nil {
+		if l.peek() != '"' {
+			l.fail("expect a field name, got %s", l.found())
+			return
+		}
+		key, escaped := l.rawString()
+		if escaped {
+			key = []byte(unquote(key))
+		}
+		if !l.expect(':', "':'") {
+			return
+		}
+		field(key)
+		switch l.peek() {
+		case ',':
+			l.pos++
+		case '}':
+			l.pos++
+			return
+		default:
+			l.fail("expect ',' or '}' after an object field, got %s", l.found())
+		}
+	}
+}
+
+// array calls elem for each element of an array, elem must read it
+func (l *jsonLexer) array(elem func()) {
+	if !l.expect('[', "array") {
+		return
+	}
+	if l.peek() == ']' {
+		l.pos++
+		return
+	}
+	for l.err == nil {
+		elem()
+		switch l.peek() {
+		case ',':
+			l.pos++
+		case ']':
+			l.pos++
+			return
+		default:
+			l.fail("expect ',' or ']' after an array element, got %s", l.found())
+		}
+	}
+}
+
+// field returns the name of fields matching key, exactly or case-insensitively like encoding/json, "" if none
+func (l *jsonLexer) field(key []byte, fields []string) string {
+	for _, f := range fields {
+		if string(key) == f {
+			return f
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(string(key), f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// rawString reads a string and returns its bytes between the quotes, and whether it has escape sequences
+func (l *jsonLexer) rawString() ([]byte, bool) {
+	start := l.pos + 1
+	escaped := false
+	for i := start; i < len(l.data); i++ {
+		switch l.data[i] {
+		case '\\':
+			escaped = true
+			i++
+		case '"':
+			l.pos = i + 1
+			return l.data[start:i], escaped
+		}
+	}
+	l.fail("unterminated string")
+	return nil, false
+}
+
+// str reads a string
+func (l *jsonLexer) str() string {
+	b, escaped := l.rawString()
+	if !escaped {
+		ascii := true
+		for _, c := range b {
+			if c >= utf8.RuneSelf {
+				ascii = false
+				break
+			}
+		}
+		if ascii || utf8.Valid(b) {
+			return l.intern(b)
+		}
+	}
+	return unquote(b)
+}
+
+// intern returns b as a string, the same string as the last time b was read if it is short
+func (l *jsonLexer) intern(b []byte) string {
+	if l.interned == nil || len(b) == 0 || len(b) > 16 {
+		return string(b)
+	}
+	h := uint8(len(b))
+	for _, c := range b {
+		h = h*31 + c
+	}
+	if s := l.interned[h]; s == string(b) {
+		return s
+	}
+	s := string(b)
+	l.interned[h] = s
+	return s
+}
+
+// unquote decodes the escape sequences of a string and replaces invalid UTF-8 like encoding/json
+func unquote(b []byte) string {
+	var s strings.Builder
+	s.Grow(len(b))
+	for i := 0; i < len(b); {
+		c := b[i]
+		if c != '\\' {
+			r, size := utf8.DecodeRune(b[i:])
+			s.WriteRune(r)
+			i += size
+			continue
+		}
+		if i+1 >= len(b) {
+			break
+		}
+		i += 2
+		switch b[i-1] {
+		case 'b':
+			s.WriteByte('\b')
+		case 'f':
+			s.WriteByte('\f')
+		case 'n':
+			s.WriteByte('\n')
+		case 'r':
+			s.WriteByte('\r')
+		case 't':
+			s.WriteByte('\t')
+		case 'u':
+			r := hexRune(b[i:])
+			i += 4
+			if utf16.IsSurrogate(r) {
+				r2 := rune(-1)
+				if i+1 < len(b) && b[i] == '\\' && b[i+1] == 'u' {
+					r2 = hexRune(b[i+2:])
+				}
+				if dec := utf16.DecodeRune(r, r2); dec != utf8.RuneError {
+					r = dec
+					i += 6
+				} else {
+					r = utf8.RuneError
+				}
+			}
+			s.WriteRune(r)
+		default:
+			s.WriteByte(b[i-1])
+		}
+	}
+	return s.String()
+}
+
+// hexRune decodes the 4 hexadecimal digits of a \u escape sequence, -1 if invalid
+func hexRune(b []byte) rune {
+	if len(b) < 4 {
+		return -1
+	}
+	r, err := strconv.ParseUint(string(b[:4]), 16, 32)
+	if err != nil {
+		return -1
+	}
+	return rune(r)
+}
+
+// number reads the bytes of a number
+func (l *jsonLexer) number() []byte {
+	start := l.pos
+	for ; l.pos < len(l.data); l.pos++ {
+		switch c := l.data[l.pos]; {
+		case c >= '0' && c <= '9', c == '-', c == '+', c == '.', c == 'e', c == 'E':
+		default:
+			return l.data[start:l.pos]
+		}
+	}
+	return l.data[start:]
+}
+
+// numeric reads a number or a string, the text of the number, false for null or an empty string
+func (l *jsonLexer) numeric() ([]byte, bool) {
+	switch c := l.peek(); {
+	case c == 'n' && l.null():
+		return nil, false
+	case c == '"':
+		b, escaped := l.rawString()
+		if escaped {
+			b = []byte(unquote(b))
+		}
+		return b, len(b) > 0
+	case c == '-' || c >= '0' && c <= '9':
+		return l.number(), true
+	}
+	l.fail("expect a number, got %s", l.found())
+	return nil, false
+}
+
+// string reads a string into p, null leaves it unchanged
+func (l *jsonLexer) string(p *string) {
+	switch l.peek() {
+	case '"':
+		*p = l.str()
+	case 'n':
+		if l.null() {
+			return
+		}
+		fallthrough
+	default:
+		l.fail("expect a string, got %s", l.found())
+	}
+}
+
+// bool reads a boolean into p, null leaves it unchanged
+func (l *jsonLexer) bool(p *bool) {
+	switch {
+	case l.literal("true"):
+		*p = true
+	case l.literal("false"):
+		*p = false
+	case !l.null():
+		l.fail("expect a boolean, got %s", l.found())
+	}
+}
+
+// float reads a number, or a numeric string, into p
+func (l *jsonLexer) float(p *float64) {
+	b, ok := l.numeric()
+	if !ok {
+		return
+	}
+	v, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		l.fail("invalid number %q", b)
+		return
+	}
+	*p = v
+}
+
+// int reads an integer, an integral float like 12.0 or a numeric string, into p
+func (l *jsonLexer) int(p *int) {
+	b, ok := l.numeric()
+	if !ok {
+		return
+	}
+	if v, err := strconv.Atoi(string(b)); err == nil {
+		*p = v
+		return
+	}
+	v, err := strconv.ParseFloat(string(b), 64)
+	if err != nil || v != float64(int(v)) {
+		l.fail("invalid integer %q", b)
+		return
+	}
+	*p = int(v)
+}
+
+// skip reads a value and ignores it
+func (l *jsonLexer) skip() {
+	switch c := l.peek(); {
+	case c == '"':
+		l.rawString()
+	case c == '{':
+		l.object(func([]byte) { l.skip() })
+	case c == '[':
+		l.array(l.skip)
+	case c == '-' || c >= '0' && c <= '9':
+		l.number()
+	case l.literal("true"), l.literal("false"), l.literal("null"):
+	default:
+		l.fail("expect a value, got %s", l.found())
+	}
+}
+
+// extra reads a value into the fields which aren't modeled, see Entity.Extra
+func (l *jsonLexer) extra(fields *map[string]json.RawMessage, key []byte) {
+	l.peek()
+	start := l.pos
+	l.skip()
+	if l.err != nil {
+		return
+	}
+	if *fields == nil {
+		*fields = map[string]json.RawMessage{}
+	}
+	(*fields)[string(key)] = append(json.RawMessage(nil), l.data[start:l.pos]...)
+}
+
+// unmarshal decodes a value into v with encoding/json, for the types without generated decoder
+func (l *jsonLexer) unmarshal(v interface{}) {
+	l.peek()
+	start := l.pos
+	l.skip()
+	if l.err != nil {
+		return
+	}
+	if err := json.Unmarshal(l.data[start:l.pos], v); err != nil {
+		l.fail("%v", err)
+	}
+}
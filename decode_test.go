@@ -0,0 +1,242 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Generated decoders tests
+
+// reflectAnalysis is decoded by the reflection of encoding/json, like Analysis before its generated decoder,
+// its fields match the fields of Analysis so they encode to the same JSON
+type reflectAnalysis struct {
+	CustomAnnotationOutput string `json:"customAnnotationOutput"`
+	Language               string `json:"language"`
+	LanguageIsReliable     bool   `json:"languageIsReliable"`
+	CleanedText            string `json:"cleanedText"`
+	RawText                string `json:"rawText"`
+	Entailments            []struct {
+		ID            flexInt       `json:"id"`
+		ContextScore  flexFloat64   `json:"contextScore"`
+		EntailedTree  *EntailedWord `json:"entailedTree"`
+		WordPositions []int         `json:"wordPositions"`
+		PriorScore    flexFloat64   `json:"priorScore"`
+		Score         flexFloat64   `json:"score"`
+	} `json:"entailments"`
+	Entities     []reflectEntity  `json:"entities"`
+	Topics       []reflectTopic   `json:"topics"`
+	CoarseTopics []reflectTopic   `json:"coarseTopics"`
+	Categories   []ScoredCategory `json:"categories"`
+	NounPhrases  []NounPhrase     `json:"nounPhrases"`
+	Properties   []Property       `json:"properties"`
+	Relations    []Relation       `json:"relations"`
+	Sentences    []struct {
+		Position int           `json:"position"`
+		Words    []reflectWord `json:"words"`
+	} `json:"sentences"`
+	MatchingRules []string `json:"matchingRules"`
+	Fingerprint   string   `json:"fingerprint,omitempty"`
+}
+
+type reflectEntity struct {
+	ID              flexInt     `json:"id"`
+	EntityID        string      `json:"entityId"`
+	EntityEnglishID string      `json:"entityEnglishId"`
+	CustomEntityID  string      `json:"customEntityId"`
+	ConfidenceScore flexFloat64 `json:"confidenceScore"`
+	Types           []string    `json:"type"`
+	FreebaseTypes   []string    `json:"freebaseTypes"`
+	FreebaseID      string      `json:"freebaseId"`
+	WikidataID      string      `json:"wikidataId"`
+	MatchingTokens  []int       `json:"matchingTokens"`
+	MatchedText     string      `json:"matchedText"`
+	StartingPos     int         `json:"startingPos"`
+	EndingPos       int         `json:"endingPos"`
+	Data            EntryData   `json:"data"`
+	RelevanceScore  flexFloat64 `json:"relevanceScore"`
+	WikiLink        string      `json:"wikiLink"`
+}
+
+type reflectTopic struct {
+	ID         flexInt     `json:"id"`
+	Label      string      `json:"label"`
+	Score      flexFloat64 `json:"score"`
+	WikiLink   string      `json:"wikiLink"`
+	WikidataID string      `json:"wikidataId"`
+}
+
+// reflectWord has the fields of Word, without its UnmarshalJSON method
+type reflectWord Word
+
+func TestGeneratedDecoders(t *testing.T) {
+	for name, body := range textrazortest.AnalysisFixtures {
+		var generated, reflected HTTPResponse
+		generated.Response = &Analysis{}
+		if err := json.Unmarshal([]byte(body), &generated); err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		reflected.Response = &rawResponse{v: &reflectAnalysis{}}
+		if err := json.Unmarshal([]byte(body), &reflected); err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		g, _ := json.Marshal(generated.Response)
+		r, _ := json.Marshal(reflected.Response.(*rawResponse).v)
+		if !bytes.Equal(g, r) {
+			t.Errorf("%s: expect the analysis decoded by encoding/json\n%s\ngot\n%s", name, r, g)
+		}
+	}
+}
+
+// rawResponse decodes a response into v
+type rawResponse struct{ v interface{} }
+
+func (r *rawResponse) setHTTPResponse(*HTTPResponse) {}
+func (r *rawResponse) UnmarshalJSON(b []byte) error  { return json.Unmarshal(b, r.v) }
+
+func TestParseAnalysis(t *testing.T) {
+	for name, body := range textrazortest.AnalysisFixtures {
+		expect := HTTPResponse{Response: &Analysis{}}
+		if err := json.Unmarshal([]byte(body), &expect); err != nil {
+			t.Fatal(err)
+		}
+		a, err := ParseAnalysis([]byte(body))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		e := expect.Response.(*Analysis)
+		e.HTTPResponse = nil
+		r := a.HTTPResponse
+		a.HTTPResponse = nil
+		if !reflect.DeepEqual(a, e) || r.Time != expect.Time || !r.Ok || r.Response != Response(a) {
+			t.Errorf("%s: expect the analysis decoded by json.Unmarshal, got %+v", name, a)
+		}
+	}
+
+	var apiErr *APIError
+	if _, err := ParseAnalysis([]byte(`{"ok": false, "error": "Invalid extractor"}`)); !errors.As(err, &apiErr) || apiErr.ErrorMessage != "Invalid extractor" {
+		t.Errorf("expect an API error, got %v", err)
+	}
+	if _, err := ParseAnalysis([]byte(`{"ok": true, "response": []}`)); err == nil || err.Error() != "http response body parsing failed: expect object, got array at offset 25" {
+		t.Errorf("expect a parsing error, got %v", err)
+	}
+}
+
+func TestGeneratedDecodersValues(t *testing.T) {
+	var tests = []struct {
+		body   string
+		expect Analysis
+		err    string
+	}{
+		{`{"language": "eng", "languageIsReliable": true, "unknown": {"a": [1, "b", null, true]}}`,
+			Analysis{Language: "eng", LanguageIsReliable: true}, ""},
+		{`{"Language": "eng", "MATCHINGRULES": ["a", null]}`, Analysis{Language: "eng", MatchingRules: []string{"a", ""}}, ""},
+		{`{"language": null, "entities": null, "matchingRules": []}`, Analysis{MatchingRules: []string{}}, ""},
+		{`{"entities": [{"id": "3", "relevanceScore": "0.5", "confidenceScore": 2, "startingPos": 4.0, "matchingTokens": [1, "2"]}]}`,
+			Analysis{Entities: []Entity{{ID: 3, RelevanceScore: 0.5, ConfidenceScore: 2, StartingPos: 4, MatchingTokens: []int{1, 2}}}}, ""},
+		{`{"sentences": [{"position": 0, "words": [{"token": "café 😀 \"q\"\n", "lemma": "größe", "senses": [{"sense": "s", "score": 0.25}]}]}]}`,
+			Analysis{Sentences: []Sentence{{Words: []Word{{Token: "café 😀 \"q\"\n", Lemma: "größe", Senses: []Sense{{"s", 0.25}}}}}}}, ""},
+		{`{"topics": [{"label": "Banking", "score": "0.9"}]}`, Analysis{Topics: []Topic{{Label: "Banking", Score: 0.9}}}, ""},
+		{`{"entities": {}}`, Analysis{}, "expect array, got object at offset 13"},
+		{`{"language": 3}`, Analysis{}, "expect a string, got number at offset 13"},
+		{`{"entities": [{"id": 1.5}]}`, Analysis{}, `invalid integer "1.5"`},
+		{`{"language": "eng"} {}`, Analysis{}, "invalid data after top-level value"},
+	}
+	for _, tt := range tests {
+		var a Analysis
+		err := a.UnmarshalJSON([]byte(tt.body))
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: expect the error %q, got %v", tt.body, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(a, tt.expect) {
+			t.Errorf("%s: expect %+v, got %+v %v", tt.body, tt.expect, a, err)
+		}
+	}
+}
+
+func BenchmarkDecodeAnalysis(b *testing.B) {
+	// the API sends compact JSON, unlike the indented fixtures
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(textrazortest.AnalysisFull)); err != nil {
+		b.Fatal(err)
+	}
+	body := compact.Bytes()
+	b.Run("ParseAnalysis", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ParseAnalysis(body); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := HTTPResponse{Response: &Analysis{}}
+			if err := json.Unmarshal(body, &r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := HTTPResponse{Response: &rawResponse{v: &reflectAnalysis{}}}
+			if err := json.Unmarshal(body, &r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkDecodeLargeAnalysis(b *testing.B) {
+	_, body := textrazortest.GenerateAnalysis(textrazortest.GenerateOptions{Seed: 1, Sentences: 2000, Entities: 5000, Topics: 100})
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseAnalysis([]byte(body)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDecodeAllocBudget(t *testing.T) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(textrazortest.AnalysisFull)); err != nil {
+		t.Fatal(err)
+	}
+	_, medium := textrazortest.GenerateAnalysis(textrazortest.GenerateOptions{Seed: 1, Sentences: 100, Entities: 200, Topics: 20})
+	_, large := textrazortest.GenerateAnalysis(textrazortest.GenerateOptions{Seed: 1, Sentences: 1000, Entities: 2000, Topics: 20})
+
+	// the decoder allocates about 3 bytes per byte of JSON, mostly the strings and the slices of the analysis
+	var tests = []struct {
+		name          string
+		body          []byte
+		allocs, bytes float64
+	}{
+		{"AnalysisFull", compact.Bytes(), 0.04, 4},
+		{"medium", []byte(medium), 0.008, 4},
+		{"large", []byte(large), 0.008, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			textrazortest.CheckAllocs(t, textrazortest.PerInputByte(len(tt.body), tt.allocs, tt.bytes), 3, func() {
+				if _, err := ParseAnalysis(tt.body); err != nil {
+					t.Fatal(err)
+				}
+			})
+		})
+	}
+}
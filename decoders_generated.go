@@ -0,0 +1,562 @@
+// Code generated by gendecoders.go; DO NOT EDIT.
+
+package textrazor
+
+var analysisFields = []string{"customAnnotationOutput", "language", "languageIsReliable", "cleanedText", "rawText", "entailments", "entities", "topics", "coarseTopics", "categories", "nounPhrases", "properties", "relations", "sentences", "matchingRules", "fingerprint"}
+
+func unmarshalAnalysis(l *jsonLexer, v *Analysis) {
+	l.object(func(key []byte) {
+		switch l.field(key, analysisFields) {
+		case "customAnnotationOutput":
+			l.string(&v.CustomAnnotationOutput)
+		case "language":
+			l.string(&v.Language)
+		case "languageIsReliable":
+			l.bool(&v.LanguageIsReliable)
+		case "cleanedText":
+			l.string(&v.CleanedText)
+		case "rawText":
+			l.string(&v.RawText)
+		case "entailments":
+			decodeEntailmentSlice(l, &v.Entailments)
+		case "entities":
+			decodeEntitySlice(l, &v.Entities)
+		case "topics":
+			decodeTopicSlice(l, &v.Topics)
+		case "coarseTopics":
+			decodeTopicSlice(l, &v.CoarseTopics)
+		case "categories":
+			l.unmarshal(&v.Categories)
+		case "nounPhrases":
+			decodeNounPhraseSlice(l, &v.NounPhrases)
+		case "properties":
+			decodePropertySlice(l, &v.Properties)
+		case "relations":
+			decodeRelationSlice(l, &v.Relations)
+		case "sentences":
+			decodeSentenceSlice(l, &v.Sentences)
+		case "matchingRules":
+			decodeStringSlice(l, &v.MatchingRules)
+		case "fingerprint":
+			l.string(&v.Fingerprint)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var entityFields = []string{"id", "entityId", "entityEnglishId", "customEntityId", "confidenceScore", "type", "freebaseTypes", "freebaseId", "wikidataId", "matchingTokens", "matchedText", "startingPos", "endingPos", "data", "relevanceScore", "wikiLink"}
+
+func unmarshalEntity(l *jsonLexer, v *Entity) {
+	v.Extra = nil
+	l.object(func(key []byte) {
+		switch l.field(key, entityFields) {
+		case "id":
+			l.int(&v.ID)
+		case "entityId":
+			l.string(&v.EntityID)
+		case "entityEnglishId":
+			l.string(&v.EntityEnglishID)
+		case "customEntityId":
+			l.string(&v.CustomEntityID)
+		case "confidenceScore":
+			l.float(&v.ConfidenceScore)
+		case "type":
+			decodeStringSlice(l, &v.Types)
+		case "freebaseTypes":
+			decodeStringSlice(l, &v.FreebaseTypes)
+		case "freebaseId":
+			l.string(&v.FreebaseID)
+		case "wikidataId":
+			l.string(&v.WikidataID)
+		case "matchingTokens":
+			decodeIntSlice(l, &v.MatchingTokens)
+		case "matchedText":
+			l.string(&v.MatchedText)
+		case "startingPos":
+			l.int(&v.StartingPos)
+		case "endingPos":
+			l.int(&v.EndingPos)
+		case "data":
+			l.unmarshal(&v.Data)
+		case "relevanceScore":
+			l.float(&v.RelevanceScore)
+		case "wikiLink":
+			l.string(&v.WikiLink)
+		default:
+			l.extra(&v.Extra, key)
+		}
+	})
+}
+
+var topicFields = []string{"id", "label", "score", "wikiLink", "wikidataId"}
+
+func unmarshalTopic(l *jsonLexer, v *Topic) {
+	l.object(func(key []byte) {
+		switch l.field(key, topicFields) {
+		case "id":
+			l.int(&v.ID)
+		case "label":
+			l.string(&v.Label)
+		case "score":
+			l.float(&v.Score)
+		case "wikiLink":
+			l.string(&v.WikiLink)
+		case "wikidataId":
+			l.string(&v.WikidataID)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var entailmentFields = []string{"id", "contextScore", "entailedTree", "wordPositions", "priorScore", "score"}
+
+func unmarshalEntailment(l *jsonLexer, v *Entailment) {
+	l.object(func(key []byte) {
+		switch l.field(key, entailmentFields) {
+		case "id":
+			l.int(&v.ID)
+		case "contextScore":
+			l.float(&v.ContextScore)
+		case "entailedTree":
+			l.unmarshal(&v.EntailedTree)
+		case "wordPositions":
+			decodeIntSlice(l, &v.WordPositions)
+		case "priorScore":
+			l.float(&v.PriorScore)
+		case "score":
+			l.float(&v.Score)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var nounPhraseFields = []string{"id", "wordPositions"}
+
+func unmarshalNounPhrase(l *jsonLexer, v *NounPhrase) {
+	l.object(func(key []byte) {
+		switch l.field(key, nounPhraseFields) {
+		case "id":
+			l.int(&v.ID)
+		case "wordPositions":
+			decodeIntSlice(l, &v.WordPositions)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var propertyFields = []string{"id", "wordPositions", "propertyPositions"}
+
+func unmarshalProperty(l *jsonLexer, v *Property) {
+	l.object(func(key []byte) {
+		switch l.field(key, propertyFields) {
+		case "id":
+			l.int(&v.ID)
+		case "wordPositions":
+			decodeIntSlice(l, &v.WordPositions)
+		case "propertyPositions":
+			decodeIntSlice(l, &v.PropertyPositions)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var relationFields = []string{"id", "params", "wordPositions"}
+
+func unmarshalRelation(l *jsonLexer, v *Relation) {
+	l.object(func(key []byte) {
+		switch l.field(key, relationFields) {
+		case "id":
+			l.int(&v.ID)
+		case "params":
+			decodeRelationParamSlice(l, &v.Params)
+		case "wordPositions":
+			decodeIntSlice(l, &v.WordPositions)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var relationParamFields = []string{"wordPositions", "relation"}
+
+func unmarshalRelationParam(l *jsonLexer, v *RelationParam) {
+	l.object(func(key []byte) {
+		switch l.field(key, relationParamFields) {
+		case "wordPositions":
+			decodeIntSlice(l, &v.WordPositions)
+		case "relation":
+			l.string((*string)(&v.Relation))
+		default:
+			l.skip()
+		}
+	})
+}
+
+var sentenceFields = []string{"position", "words"}
+
+func unmarshalSentence(l *jsonLexer, v *Sentence) {
+	l.object(func(key []byte) {
+		switch l.field(key, sentenceFields) {
+		case "position":
+			l.int(&v.Position)
+		case "words":
+			decodeWordSlice(l, &v.Words)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var wordFields = []string{"endingPos", "startingPos", "lemma", "parentPosition", "partOfSpeech", "senses", "spellingSuggestions", "position", "relationToParent", "stem", "token"}
+
+func unmarshalWord(l *jsonLexer, v *Word) {
+	l.object(func(key []byte) {
+		switch l.field(key, wordFields) {
+		case "endingPos":
+			l.int(&v.EndingPos)
+		case "startingPos":
+			l.int(&v.StartingPos)
+		case "lemma":
+			l.string(&v.Lemma)
+		case "parentPosition":
+			l.int(&v.ParentPosition)
+		case "partOfSpeech":
+			l.string(&v.PartOfSpeech)
+		case "senses":
+			decodeSenseSlice(l, &v.Senses)
+		case "spellingSuggestions":
+			decodeSpellingSuggestionSlice(l, &v.SpellingSuggestions)
+		case "position":
+			l.int(&v.Position)
+		case "relationToParent":
+			l.string(&v.RelationToParent)
+		case "stem":
+			l.string(&v.Stem)
+		case "token":
+			l.string(&v.Token)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var senseFields = []string{"sense", "score"}
+
+func unmarshalSense(l *jsonLexer, v *Sense) {
+	l.object(func(key []byte) {
+		switch l.field(key, senseFields) {
+		case "sense":
+			l.string(&v.Sense)
+		case "score":
+			l.float(&v.Score)
+		default:
+			l.skip()
+		}
+	})
+}
+
+var spellingSuggestionFields = []string{"suggestion", "score"}
+
+func unmarshalSpellingSuggestion(l *jsonLexer, v *SpellingSuggestion) {
+	l.object(func(key []byte) {
+		switch l.field(key, spellingSuggestionFields) {
+		case "suggestion":
+			l.string(&v.Suggestion)
+		case "score":
+			l.float(&v.Score)
+		default:
+			l.skip()
+		}
+	})
+}
+
+func decodeEntailmentSlice(l *jsonLexer, s *[]Entailment) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Entailment{}
+	}
+	l.array(func() {
+		var zero Entailment
+		v = append(v, zero)
+		unmarshalEntailment(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeEntitySlice(l *jsonLexer, s *[]Entity) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Entity{}
+	}
+	l.array(func() {
+		var zero Entity
+		v = append(v, zero)
+		unmarshalEntity(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeNounPhraseSlice(l *jsonLexer, s *[]NounPhrase) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []NounPhrase{}
+	}
+	l.array(func() {
+		var zero NounPhrase
+		v = append(v, zero)
+		unmarshalNounPhrase(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodePropertySlice(l *jsonLexer, s *[]Property) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Property{}
+	}
+	l.array(func() {
+		var zero Property
+		v = append(v, zero)
+		unmarshalProperty(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeRelationSlice(l *jsonLexer, s *[]Relation) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Relation{}
+	}
+	l.array(func() {
+		var zero Relation
+		v = append(v, zero)
+		unmarshalRelation(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeRelationParamSlice(l *jsonLexer, s *[]RelationParam) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []RelationParam{}
+	}
+	l.array(func() {
+		var zero RelationParam
+		v = append(v, zero)
+		unmarshalRelationParam(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeSenseSlice(l *jsonLexer, s *[]Sense) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Sense{}
+	}
+	l.array(func() {
+		var zero Sense
+		v = append(v, zero)
+		unmarshalSense(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeSentenceSlice(l *jsonLexer, s *[]Sentence) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Sentence{}
+	}
+	l.array(func() {
+		var zero Sentence
+		v = append(v, zero)
+		unmarshalSentence(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeSpellingSuggestionSlice(l *jsonLexer, s *[]SpellingSuggestion) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []SpellingSuggestion{}
+	}
+	l.array(func() {
+		var zero SpellingSuggestion
+		v = append(v, zero)
+		unmarshalSpellingSuggestion(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeTopicSlice(l *jsonLexer, s *[]Topic) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Topic{}
+	}
+	l.array(func() {
+		var zero Topic
+		v = append(v, zero)
+		unmarshalTopic(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeWordSlice(l *jsonLexer, s *[]Word) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []Word{}
+	}
+	l.array(func() {
+		var zero Word
+		v = append(v, zero)
+		unmarshalWord(l, &v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeIntSlice(l *jsonLexer, s *[]int) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []int{}
+	}
+	l.array(func() {
+		var zero int
+		v = append(v, zero)
+		l.int(&v[len(v)-1])
+	})
+	*s = v
+}
+
+func decodeStringSlice(l *jsonLexer, s *[]string) {
+	if l.null() {
+		*s = nil
+		return
+	}
+	if l.deferred != nil && l.deferred[l.pos] {
+		// decoded by another goroutine, see decodeSections
+		l.skip()
+		return
+	}
+	v := (*s)[:0]
+	if v == nil {
+		v = []string{}
+	}
+	l.array(func() {
+		var zero string
+		v = append(v, zero)
+		l.string(&v[len(v)-1])
+	})
+	*s = v
+}
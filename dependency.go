@@ -0,0 +1,115 @@
+package textrazor
+
+// DependencyTree is a node of the dependency tree of a sentence
+type DependencyTree struct {
+	Word *Word
+	// Children are the nodes of the words depending on Word, by position
+	Children []*DependencyTree
+}
+
+// isRoot reports whether the word has no parent in the dependency tree of its sentence
+func (w *Word) isRoot() bool {
+	return w.RelationToParent == ""
+}
+
+// Parent returns the parent of the word in the dependency tree, found in the sentences of a,
+// or nil for the root and when dependency trees weren't requested
+func (w *Word) Parent(a *Analysis) *Word {
+	if w.isRoot() {
+		return nil
+	}
+	for i := range a.Sentences {
+		for j := range a.Sentences[i].Words {
+			if p := &a.Sentences[i].Words[j]; p.Position == w.ParentPosition {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// Children returns the words depending on the word in the dependency tree, found in the sentences of a, by position
+func (w *Word) Children(a *Analysis) []*Word {
+	var children []*Word
+	for i := range a.Sentences {
+		for j := range a.Sentences[i].Words {
+			if c := &a.Sentences[i].Words[j]; !c.isRoot() && c.ParentPosition == w.Position {
+				children = append(children, c)
+			}
+		}
+	}
+	return children
+}
+
+// Root returns the root of the dependency tree of the sentence, the word without a relation to a parent,
+// or nil when dependency trees weren't requested
+func (s *Sentence) Root() *Word {
+	var root *Word
+	parsed := false
+	for i := range s.Words {
+		w := &s.Words[i]
+		switch {
+		case !w.isRoot():
+			parsed = true
+		case root == nil:
+			root = w
+		}
+	}
+	if !parsed {
+		return nil
+	}
+	return root
+}
+
+// DependencyTree returns the dependency tree of the sentence, or nil when dependency trees weren't requested
+func (s *Sentence) DependencyTree() *DependencyTree {
+	root := s.Root()
+	if root == nil {
+		return nil
+	}
+	children := map[int][]*Word{}
+	for i := range s.Words {
+		if w := &s.Words[i]; !w.isRoot() {
+			children[w.ParentPosition] = append(children[w.ParentPosition], w)
+		}
+	}
+	var build func(w *Word, seen map[*Word]bool) *DependencyTree
+	build = func(w *Word, seen map[*Word]bool) *DependencyTree {
+		// guards against malformed trees with cycles
+		seen[w] = true
+		t := &DependencyTree{Word: w}
+		for _, c := range children[w.Position] {
+			if !seen[c] {
+				t.Children = append(t.Children, build(c, seen))
+			}
+		}
+		return t
+	}
+	return build(root, map[*Word]bool{})
+}
+
+// Walk calls fn for the node and its descendants, depth first, stopping when fn returns false
+func (t *DependencyTree) Walk(fn func(*DependencyTree) bool) bool {
+	if t == nil {
+		return true
+	}
+	if !fn(t) {
+		return false
+	}
+	for _, c := range t.Children {
+		if !c.Walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Words returns the words of the tree, depth first
+func (t *DependencyTree) Words() []*Word {
+	var words []*Word
+	t.Walk(func(n *DependencyTree) bool {
+		words = append(words, n.Word)
+		return true
+	})
+	return words
+}
@@ -0,0 +1,92 @@
+package textrazor
+
+import (
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Dependency tree tests
+
+func TestDependencyNavigation(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisDependencyTrees)
+	words := a.Sentences[0].Words
+
+	root := a.Sentences[0].Root()
+	if root == nil || root.Token != "found" {
+		t.Fatal("expect found to be the root, got", root)
+	}
+	if p := root.Parent(a); p != nil {
+		t.Error("expect the root to have no parent, got", p)
+	}
+	if p := words[0].Parent(a); p != &words[1] {
+		t.Error("expect misled to be the parent of Barclays, got", p)
+	}
+
+	var tests = []struct {
+		word   int
+		expect []string
+	}{
+		{23, []string{"misled", ",", "investigation", "has", "."}},
+		{2, []string{"and", "public"}},
+		{0, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, c := range words[tt.word].Children(a) {
+			got = append(got, c.Token)
+		}
+		if len(got) != len(tt.expect) {
+			t.Errorf("expect the children of %s to be %v, got %v", words[tt.word].Token, tt.expect, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expect[i] {
+				t.Errorf("expect the children of %s to be %v, got %v", words[tt.word].Token, tt.expect, got)
+				break
+			}
+		}
+	}
+}
+
+func TestDependencyTree(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisDependencyTrees)
+	tree := a.Sentences[0].DependencyTree()
+	if tree == nil || tree.Word.Token != "found" || len(tree.Children) != 5 {
+		t.Fatal("expect found with 5 children at the root, got", tree)
+	}
+	if words := tree.Words(); len(words) != len(a.Sentences[0].Words) || words[1].Token != "misled" || words[2].Token != "Barclays" {
+		t.Error("expect every word depth first, got", len(words))
+	}
+
+	// the subtree of "about one of the biggest investments in the bank's history"
+	var about *DependencyTree
+	tree.Walk(func(n *DependencyTree) bool {
+		if n.Word.Token == "about" {
+			about = n
+			return false
+		}
+		return true
+	})
+	if about == nil || len(about.Words()) != 11 {
+		t.Error("expect about to head 11 words, got", about)
+	}
+
+	words := decodeAnalysis(t, textrazortest.AnalysisWords)
+	if words.Sentences[0].Root() != nil || words.Sentences[0].DependencyTree() != nil {
+		t.Error("expect no tree without dependency trees")
+	}
+}
+
+func TestDependencyTreeCycle(t *testing.T) {
+	s := Sentence{Words: []Word{
+		{Position: 0, Token: "a", ParentPosition: 1, RelationToParent: "dep"},
+		{Position: 1, Token: "b", ParentPosition: 0, RelationToParent: "dep"},
+		{Position: 2, Token: "c"},
+		{Position: 3, Token: "d", ParentPosition: 2, RelationToParent: "dep"},
+	}}
+	if tree := s.DependencyTree(); tree == nil || len(tree.Words()) != 2 {
+		t.Error("expect the cycle to be left out of the tree, got", tree)
+	}
+}
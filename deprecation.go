@@ -0,0 +1,90 @@
+package textrazor
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// DeprecatedCall is a call site of a deprecated API, reported once EnableDeprecationWarnings is called
+type DeprecatedCall struct {
+	// API is the deprecated function, Replacement the function to use instead
+	API         string
+	Replacement string
+	// Caller is the file and line calling API
+	Caller string
+	// Count is the number of calls from Caller
+	Count int
+}
+
+func (d DeprecatedCall) String() string {
+	return fmt.Sprintf("%s: %s is deprecated, use %s", d.Caller, d.API, d.Replacement)
+}
+
+// deprecations records the deprecated calls of the process, when enabled
+var deprecations struct {
+	enabled atomic.Bool
+
+	mu     sync.Mutex
+	notify func(DeprecatedCall)
+	calls  map[[2]string]*DeprecatedCall
+}
+
+// EnableDeprecationWarnings records the calls of deprecated APIs made by the process, listed by DeprecatedCalls,
+// to find the code to migrate before the APIs are removed. notify, if not nil, is called on the first call
+// from each call site, e.g. with log.Println. The deprecated APIs keep working, they forward to their replacement.
+func EnableDeprecationWarnings(notify func(DeprecatedCall)) {
+	deprecations.mu.Lock()
+	defer deprecations.mu.Unlock()
+	deprecations.notify = notify
+	if deprecations.calls == nil {
+		deprecations.calls = map[[2]string]*DeprecatedCall{}
+	}
+	deprecations.enabled.Store(true)
+}
+
+// DeprecatedCalls returns the deprecated calls recorded since EnableDeprecationWarnings, sorted by API and caller
+func DeprecatedCalls() []DeprecatedCall {
+	deprecations.mu.Lock()
+	defer deprecations.mu.Unlock()
+	calls := make([]DeprecatedCall, 0, len(deprecations.calls))
+	for _, d := range deprecations.calls {
+		calls = append(calls, *d)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].API != calls[j].API {
+			return calls[i].API < calls[j].API
+		}
+		return calls[i].Caller < calls[j].Caller
+	})
+	return calls
+}
+
+// deprecated records a call of a deprecated API, it's called by the API itself so the caller is 2 frames up
+func deprecated(api, replacement string) {
+	if !deprecations.enabled.Load() {
+		return
+	}
+	caller := "unknown"
+	if _, file, line, ok := runtime.Caller(2); ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	deprecations.mu.Lock()
+	key := [2]string{api, caller}
+	d, ok := deprecations.calls[key]
+	if !ok {
+		d = &DeprecatedCall{API: api, Replacement: replacement, Caller: caller}
+		deprecations.calls[key] = d
+	}
+	d.Count++
+	notify, first := deprecations.notify, !ok
+	call := *d
+	deprecations.mu.Unlock()
+
+	if first && notify != nil {
+		notify(call)
+	}
+}
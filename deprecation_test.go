@@ -0,0 +1,61 @@
+package textrazor
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Deprecation warnings tests
+
+func TestDeprecationWarnings(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint,
+		textrazortest.NewTransport(200, textrazortest.Categories))
+
+	var (
+		mu       sync.Mutex
+		notified []DeprecatedCall
+	)
+	EnableDeprecationWarnings(func(d DeprecatedCall) {
+		mu.Lock()
+		defer mu.Unlock()
+		notified = append(notified, d)
+	})
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetClassifierCategories("sport", 20, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.ListClassifierCategories("sport", ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []DeprecatedCall
+	for _, d := range DeprecatedCalls() {
+		if filepath.Base(strings.Split(d.Caller, ":")[0]) == "deprecation_test.go" {
+			calls = append(calls, d)
+		}
+	}
+	if len(calls) != 1 || calls[0].API != "GetClassifierCategories" || calls[0].Replacement != "ListClassifierCategories" || calls[0].Count != 3 {
+		t.Fatalf("expect 3 calls of GetClassifierCategories from a single call site, got %+v", calls)
+	}
+	if s := calls[0].String(); !strings.HasSuffix(s, ": GetClassifierCategories is deprecated, use ListClassifierCategories") {
+		t.Error("unexpected warning", s)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	n := 0
+	for _, d := range notified {
+		if d.Caller == calls[0].Caller {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Error("expect a single notification per call site, got", n)
+	}
+}
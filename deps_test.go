@@ -0,0 +1,44 @@
+package textrazor
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			Dependencies tests
+
+// corePackages are the directories of the packages of the core module, see README.md
+var corePackages = []string{".", "cmd/textrazor", "export", "interop", "jobs", "output", "textrazortest"}
+
+func TestDependencies(t *testing.T) {
+	for _, dir := range corePackages {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			parsed, err := parser.ParseFile(token.NewFileSet(), f, nil, parser.ImportsOnly)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			for _, imp := range parsed.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				if !isStandardPackage(path) && path != modulePath && !strings.HasPrefix(path, modulePath+"/") {
+					t.Errorf("%s imports %s, the core packages only depend on the standard library", f, path)
+				}
+			}
+		}
+	}
+}
+
+// isStandardPackage reports whether an import path is in the standard library,
+// whose first path element has no dot unlike a domain name
+func isStandardPackage(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
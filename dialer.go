@@ -0,0 +1,101 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DialerOptions configures the connections of the transport created by DialerTransport,
+// e.g. for networks with a broken IPv6 egress or allowlisting the source IP of the requests
+type DialerOptions struct {
+	// Timeout limits the time to establish a connection, DefaultDialTimeout if 0
+	Timeout time.Duration
+	// Network restricts the dialed addresses, "tcp4" or "tcp6", both by default
+	Network string
+	// PreferIPv4 dials the IPv4 addresses first, then the IPv6 ones if it fails
+	PreferIPv4 bool
+	// LocalIP binds the connections to a local IP
+	LocalIP net.IP
+	// Interface binds the connections to the first IP of a network interface matching the dialed network,
+	// it is ignored when LocalIP is set
+	Interface string
+}
+
+// DialerTransport creates a compressed or uncompressed http.Transport dialing with the given options, with the default timeouts
+func DialerTransport(useCompression bool, o DialerOptions) http.RoundTripper {
+	return &http.Transport{
+		DisableCompression:    !useCompression,
+		DialContext:           o.DialContext,
+		TLSHandshakeTimeout:   DefaultTLSHandshakeTimeout,
+		ResponseHeaderTimeout: DefaultResponseHeaderTimeout,
+	}
+}
+
+// DialContext connects to addr on network with the options, it can be used as the DialContext of an http.Transport
+func (o DialerOptions) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if o.Network != "" {
+		network = o.Network
+	}
+	if !o.PreferIPv4 || network != "tcp" {
+		return o.dial(ctx, network, addr)
+	}
+	conn, err := o.dial(ctx, "tcp4", addr)
+	if err == nil || ctx.Err() != nil {
+		return conn, err
+	}
+	conn, err6 := o.dial(ctx, "tcp6", addr)
+	if err6 != nil {
+		return nil, fmt.Errorf("%v, then %v", err, err6)
+	}
+	return conn, nil
+}
+
+func (o DialerOptions) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	d := &net.Dialer{Timeout: o.Timeout}
+	if d.Timeout == 0 {
+		d.Timeout = DefaultDialTimeout
+	}
+	ip := o.LocalIP
+	if ip == nil && o.Interface != "" {
+		var err error
+		if ip, err = interfaceIP(o.Interface, network); err != nil {
+			return nil, err
+		}
+	}
+	if ip != nil {
+		d.LocalAddr = &net.TCPAddr{IP: ip}
+	}
+	return d.DialContext(ctx, network, addr)
+}
+
+// interfaceIP returns the first IP of the named interface usable on network, IPv4 first for "tcp"
+func interfaceIP(name, network string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("network interface lookup failed: %w", err)
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("network interface '%s' addresses lookup failed: %w", name, err)
+	}
+	var v6 net.IP
+	for _, a := range addrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		switch {
+		case ipNet.IP.To4() != nil && network != "tcp6":
+			return ipNet.IP, nil
+		case ipNet.IP.To4() == nil && v6 == nil && network != "tcp4":
+			v6 = ipNet.IP
+		}
+	}
+	if v6 == nil {
+		return nil, fmt.Errorf("network interface '%s' has no address for %s", name, network)
+	}
+	return v6, nil
+}
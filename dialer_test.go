@@ -0,0 +1,74 @@
+package textrazor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Dialer tests
+
+func loopbackInterface(t *testing.T) string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Skip("no network interfaces:", err)
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 && iface.Flags&net.FlagUp != 0 {
+			return iface.Name
+		}
+	}
+	t.Skip("no loopback interface")
+	return ""
+}
+
+func TestDialerTransport(t *testing.T) {
+	var remote string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remote = r.RemoteAddr
+		w.Write([]byte(textrazortest.Account))
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	var tests = []struct {
+		name    string
+		options DialerOptions
+		fail    bool
+	}{
+		{"default", DialerOptions{}, false},
+		{"ipv4 only", DialerOptions{Network: "tcp4"}, false},
+		{"prefer ipv4", DialerOptions{PreferIPv4: true}, false},
+		{"local ip", DialerOptions{LocalIP: net.ParseIP("127.0.0.1")}, false},
+		{"interface", DialerOptions{Interface: loopbackInterface(t), Network: "tcp4"}, false},
+		{"ipv6 only", DialerOptions{Network: "tcp6"}, true},
+		{"unknown interface", DialerOptions{Interface: "textrazor0"}, true},
+	}
+	for _, tt := range tests {
+		remote = ""
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, false, "http://127.0.0.1:"+port, "", DialerTransport(DefaultUseCompression, tt.options))
+		_, err := client.GetAccountContext(context.Background())
+		if (err != nil) != tt.fail {
+			t.Errorf("%s: expect failure %v, got %v", tt.name, tt.fail, err)
+		}
+		if !tt.fail && !strings.HasPrefix(remote, "127.0.0.1:") {
+			t.Errorf("%s: expect a connection from 127.0.0.1, got %s", tt.name, remote)
+		}
+	}
+}
+
+func TestInterfaceIP(t *testing.T) {
+	name := loopbackInterface(t)
+	if ip, err := interfaceIP(name, "tcp4"); err != nil || !ip.Equal(net.IPv4(127, 0, 0, 1)) {
+		t.Error("expect 127.0.0.1, got", ip, err)
+	}
+	if ip, err := interfaceIP(name, "tcp"); err != nil || ip.To4() == nil {
+		t.Error("expect an IPv4 address first, got", ip, err)
+	}
+}
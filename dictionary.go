@@ -0,0 +1,60 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Valid Dictionary.MatchType values
+const (
+	// DictionaryMatchToken matches the entries token by token
+	DictionaryMatchToken = "TOKEN"
+	// DictionaryMatchStem matches the stems of the entries, e.g. "banks" matches "bank"
+	DictionaryMatchStem = "STEM"
+)
+
+// UnmarshalJSON decodes a Dictionary, keeping the fields it doesn't model in Extra
+func (d *Dictionary) UnmarshalJSON(b []byte) error {
+	type dictionary Dictionary
+	if err := json.Unmarshal(b, (*dictionary)(d)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	modeled := jsonFields(reflect.TypeOf(*d))
+	for k := range fields {
+		if _, ok := modeled[strings.ToLower(k)]; ok {
+			delete(fields, k)
+		}
+	}
+	d.Extra = nil
+	if len(fields) > 0 {
+		d.Extra = fields
+	}
+	return nil
+}
+
+// MarshalJSON encodes a Dictionary with the fields of Extra, so the settings of a dictionary
+// survive a GetDictionary and CreateDictionary round trip, even those this package doesn't model
+func (d Dictionary) MarshalJSON() ([]byte, error) {
+	type dictionary Dictionary
+	b, err := json.Marshal(dictionary(d))
+	if err != nil || len(d.Extra) == 0 {
+		return b, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range d.Extra {
+		if _, ok := fields[k]; ok {
+			return nil, fmt.Errorf("dictionary extra field '%s' is a modeled field", k)
+		}
+		fields[k] = v
+	}
+	return json.Marshal(fields)
+}
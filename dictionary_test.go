@@ -0,0 +1,56 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Dictionary tests
+
+func TestDictionaryRoundTrip(t *testing.T) {
+	body := `{"response": {"id": "test_ents", "matchType": "STEM", "CaseInsensitive": true, "language": "eng", "advancedMatching": true, "languageOverride": {"fre": "eng"}}, "ok": true}`
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, body))
+	d, err := client.GetDictionary(dictID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.MatchType != DictionaryMatchStem || !d.CaseInsensitive || len(d.Extra) != 2 || string(d.Extra["advancedMatching"]) != "true" {
+		t.Fatalf("expect the unmodeled fields to be kept, got %+v", d)
+	}
+
+	s, err := d.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &fields); err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 6 || fields["advancedMatching"] != true || fields["caseInsensitive"] != true || fields["languageOverride"] == nil {
+		t.Error("expect every field to be sent back, got", s)
+	}
+}
+
+func TestDictionaryWithoutExtra(t *testing.T) {
+	var d Dictionary
+	if err := json.Unmarshal([]byte(`{"id": "test_ents", "matchType": "TOKEN"}`), &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Extra != nil {
+		t.Error("expect no extra fields, got", d.Extra)
+	}
+	s, _ := d.Encode()
+	if s != `{"matchType":"TOKEN","caseInsensitive":false,"id":"test_ents","language":""}` {
+		t.Error("unexpected encoding", s)
+	}
+
+	d.Extra = map[string]json.RawMessage{"id": json.RawMessage(`"other"`)}
+	if _, err := d.Encode(); err == nil || !strings.Contains(err.Error(), "modeled field") {
+		t.Error("expect an extra field to not override a modeled field, got", err)
+	}
+}
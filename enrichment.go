@@ -0,0 +1,70 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EnrichmentQueriesParam is the analysis parameter requesting extra attributes of the entities, see EnrichmentQuery
+const EnrichmentQueriesParam = "entities.enrichmentQueries"
+
+// EnrichmentQuery is a query of an attribute of the entities in the knowledge bases linked by the API,
+// e.g. "fbase:/location/location/geolocation>/location/geocode/latitude".
+// The values found are returned in the Data of the entities, under the query, see Entity.Enrichment.
+type EnrichmentQuery string
+
+// FreebaseQuery returns the query of the Freebase attribute at path, the properties followed from the entity,
+// e.g. FreebaseQuery("/location/location/geolocation", "/location/geocode/latitude")
+func FreebaseQuery(path ...string) EnrichmentQuery {
+	return EnrichmentQuery("fbase:" + strings.Join(path, ">"))
+}
+
+// DBpediaQuery returns the query of a DBpedia property of the entities, e.g. DBpediaQuery("populationTotal")
+func DBpediaQuery(property string) EnrichmentQuery {
+	return EnrichmentQuery("dbpedia:" + property)
+}
+
+// AddEnrichmentQueries adds queries to the entities.enrichmentQueries parameter,
+// to enrich every analysis, add them to the params of WithDefaultParams
+func (p Params) AddEnrichmentQueries(queries ...EnrichmentQuery) {
+	for _, q := range queries {
+		p.Add(EnrichmentQueriesParam, string(q))
+	}
+}
+
+// Enrichment returns the values of the entity found by an enrichment query, nil if none was found
+func (e *Entity) Enrichment(q EnrichmentQuery) []string {
+	return e.Data[string(q)]
+}
+
+// MarshalJSON encodes an Entity with the fields of Extra, so an encoded analysis decodes to the same entities
+func (e Entity) MarshalJSON() ([]byte, error) {
+	type entity Entity
+	b, err := json.Marshal(entity(e))
+	if err != nil || len(e.Extra) == 0 {
+		return b, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range e.Extra {
+		if _, ok := fields[k]; ok {
+			return nil, fmt.Errorf("entity extra field '%s' is a modeled field", k)
+		}
+		fields[k] = v
+	}
+	return json.Marshal(fields)
+}
+
+func cloneExtra(extra map[string]json.RawMessage) map[string]json.RawMessage {
+	if extra == nil {
+		return nil
+	}
+	c := make(map[string]json.RawMessage, len(extra))
+	for k, v := range extra {
+		c[k] = append(json.RawMessage(nil), v...)
+	}
+	return c
+}
@@ -0,0 +1,82 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+//***************************************************************
+// 			Enrichment tests
+
+func TestEnrichmentQueries(t *testing.T) {
+	latitude := FreebaseQuery("/location/location/geolocation", "/location/geocode/latitude")
+	if latitude != "fbase:/location/location/geolocation>/location/geocode/latitude" {
+		t.Errorf("unexpected Freebase query %q", latitude)
+	}
+	population := DBpediaQuery("populationTotal")
+	if population != "dbpedia:populationTotal" {
+		t.Errorf("unexpected DBpedia query %q", population)
+	}
+
+	params := Params{"extractors": {"entities"}}
+	params.AddEnrichmentQueries(latitude, population)
+	if q := params[EnrichmentQueriesParam]; !reflect.DeepEqual(q, []string{string(latitude), string(population)}) {
+		t.Errorf("expect the queries in the params, got %q", q)
+	}
+
+	var e Entity
+	body := `{"entityId": "London", "data": {"fbase:/location/location/geolocation>/location/geocode/latitude": ["51.507"], "dbpedia:populationTotal": 8908081}}`
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		t.Fatal(err)
+	}
+	if v := e.Enrichment(latitude); !reflect.DeepEqual(v, []string{"51.507"}) {
+		t.Errorf("expect the latitude, got %q", v)
+	}
+	if v := e.Enrichment(population); !reflect.DeepEqual(v, []string{"8908081"}) {
+		t.Errorf("expect the population, got %q", v)
+	}
+	if v := e.Enrichment("dbpedia:areaTotal"); v != nil {
+		t.Errorf("expect no value, got %q", v)
+	}
+}
+
+func TestEntityExtra(t *testing.T) {
+	body := `{"entityId": "London", "relevanceScore": 0.5, "sentiment": {"score": -0.2}, "Kind": "city"}`
+	var e Entity
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]json.RawMessage{"sentiment": json.RawMessage(`{"score": -0.2}`), "Kind": json.RawMessage(`"city"`)}
+	if e.EntityID != "London" || !reflect.DeepEqual(e.Extra, expect) {
+		t.Fatalf("expect the unmodeled fields in Extra, got %+v", e)
+	}
+
+	// the extra fields survive an encoding round trip and a clone
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Entity
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Extra["Kind"] == nil || string(decoded.Extra["sentiment"]) != `{"score":-0.2}` {
+		t.Errorf("expect the extra fields after a round trip, got %s", b)
+	}
+	a := &Analysis{Entities: []Entity{e}}
+	c := a.Clone()
+	c.Entities[0].Extra["sentiment"][2] = 'x'
+	if string(a.Entities[0].Extra["sentiment"]) != `{"score": -0.2}` {
+		t.Error("expect the clone to copy the extra fields")
+	}
+
+	// decoding again replaces the extra fields
+	if err := json.Unmarshal([]byte(`{"entityId": "Paris"}`), &e); err != nil || e.Extra != nil {
+		t.Errorf("expect no extra fields, got %+v %v", e.Extra, err)
+	}
+	e.Extra = map[string]json.RawMessage{"entityId": json.RawMessage(`"x"`)}
+	if _, err := json.Marshal(e); err == nil {
+		t.Error("expect an error for an extra field which is modeled")
+	}
+}
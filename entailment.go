@@ -0,0 +1,77 @@
+package textrazor
+
+import "encoding/json"
+
+// EntailedWord is a node of the tree of words entailed by an Entailment
+type EntailedWord struct {
+	Word string `json:"word"`
+	// WordID identifies the word in the tree, ParentID is the id of its parent, -1 for the root
+	WordID   int `json:"wordId"`
+	ParentID int `json:"parentId"`
+	// Children are the words depending on this word, when the entailment is made of several words
+	Children []*EntailedWord `json:"children"`
+}
+
+// UnmarshalJSON decodes an EntailedWord, tolerating ids encoded as strings
+func (w *EntailedWord) UnmarshalJSON(b []byte) error {
+	type entailedWord EntailedWord
+	aux := struct {
+		*entailedWord
+		WordID   flexInt `json:"wordId"`
+		ParentID flexInt `json:"parentId"`
+	}{entailedWord: (*entailedWord)(w)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	w.WordID = int(aux.WordID)
+	w.ParentID = int(aux.ParentID)
+	return nil
+}
+
+// Walk calls fn for the word and its descendants, depth first, stopping when fn returns false
+func (w *EntailedWord) Walk(fn func(*EntailedWord) bool) bool {
+	if w == nil {
+		return true
+	}
+	if !fn(w) {
+		return false
+	}
+	for _, c := range w.Children {
+		if !c.Walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Words returns the words of the tree, depth first
+func (w *EntailedWord) Words() []string {
+	var words []string
+	w.Walk(func(n *EntailedWord) bool {
+		words = append(words, n.Word)
+		return true
+	})
+	return words
+}
+
+func (w *EntailedWord) clone() *EntailedWord {
+	if w == nil {
+		return nil
+	}
+	c := *w
+	if w.Children != nil {
+		c.Children = make([]*EntailedWord, len(w.Children))
+		for i, child := range w.Children {
+			c.Children[i] = child.clone()
+		}
+	}
+	return &c
+}
+
+// EntailedWord returns the word entailed, the root of EntailedTree, or "" if there is none
+func (e *Entailment) EntailedWord() string {
+	if e.EntailedTree == nil {
+		return ""
+	}
+	return e.EntailedTree.Word
+}
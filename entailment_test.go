@@ -0,0 +1,60 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+//***************************************************************
+// 			Entailed words tests
+
+func TestEntailedWordUnmarshal(t *testing.T) {
+	var tests = []struct {
+		json   string
+		expect []string
+		root   EntailedWord
+	}{
+		{`{"word": "stockholder", "wordId": 0, "parentId": -1}`, []string{"stockholder"}, EntailedWord{Word: "stockholder", ParentID: -1}},
+		{`{"word": "probe", "wordId": "3", "parentId": "-1"}`, []string{"probe"}, EntailedWord{Word: "probe", WordID: 3, ParentID: -1}},
+		{`{"word": "bank", "wordId": 0, "parentId": -1, "children": [{"word": "investment", "wordId": 1, "parentId": 0, "children": [{"word": "large", "wordId": 2, "parentId": 1}]}, {"word": "central", "wordId": 3, "parentId": 0}]}`,
+			[]string{"bank", "investment", "large", "central"}, EntailedWord{Word: "bank", ParentID: -1}},
+	}
+	for _, tt := range tests {
+		var e Entailment
+		if err := json.Unmarshal([]byte(`{"entailedTree": `+tt.json+`}`), &e); err != nil {
+			t.Error(tt.json, err)
+			continue
+		}
+		if got := e.EntailedTree.Words(); !reflect.DeepEqual(got, tt.expect) {
+			t.Error(tt.json, "expect", tt.expect, "got", got)
+		}
+		root := *e.EntailedTree
+		root.Children = nil
+		if !reflect.DeepEqual(root, tt.root) || e.EntailedWord() != tt.root.Word {
+			t.Errorf("%s: expect %+v, got %+v", tt.json, tt.root, root)
+		}
+	}
+}
+
+func TestEntailedWordWalk(t *testing.T) {
+	tree := &EntailedWord{Word: "a", Children: []*EntailedWord{{Word: "b"}, {Word: "c"}}}
+	var visited []string
+	tree.Walk(func(w *EntailedWord) bool {
+		visited = append(visited, w.Word)
+		return w.Word != "b"
+	})
+	if !reflect.DeepEqual(visited, []string{"a", "b"}) {
+		t.Error("expect the walk to stop after b, got", visited)
+	}
+
+	c := tree.clone()
+	c.Children[0].Word = "changed"
+	if tree.Children[0].Word != "b" {
+		t.Error("expect the clone to be deep")
+	}
+	var e Entailment
+	if e.EntailedWord() != "" || e.EntailedTree.Words() != nil {
+		t.Error("expect no words without a tree")
+	}
+}
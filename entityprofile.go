@@ -0,0 +1,125 @@
+package textrazor
+
+import (
+	"strings"
+	"time"
+)
+
+// WikidataQuery returns the query of a Wikidata property of the entities, e.g. WikidataQuery(WikidataOccupation)
+func WikidataQuery(property string) EnrichmentQuery {
+	return EnrichmentQuery("wikidata:" + property)
+}
+
+// Wikidata properties of the attributes of EntityProfile
+const (
+	WikidataGender      = "P21"
+	WikidataOccupation  = "P106"
+	WikidataCitizenship = "P27"
+	WikidataCountry     = "P17"
+	WikidataBirthDate   = "P569"
+	WikidataInception   = "P571"
+)
+
+// profileAttributes are the queries of each attribute of EntityProfile, by preference,
+// the DBpedia properties are used when the Wikidata enrichment isn't enabled
+var profileAttributes = map[string][]EnrichmentQuery{
+	"gender":     {WikidataQuery(WikidataGender), DBpediaQuery("gender")},
+	"occupation": {WikidataQuery(WikidataOccupation), DBpediaQuery("occupation")},
+	"country":    {WikidataQuery(WikidataCitizenship), WikidataQuery(WikidataCountry), DBpediaQuery("nationality"), DBpediaQuery("country")},
+	"birthDate":  {WikidataQuery(WikidataBirthDate), DBpediaQuery("birthDate")},
+	"founded":    {WikidataQuery(WikidataInception), DBpediaQuery("foundingDate")},
+}
+
+// ProfileQueries are the Wikidata enrichment queries of the attributes of EntityProfile,
+// e.g. params.AddEnrichmentQueries(textrazor.ProfileQueries...)
+var ProfileQueries = []EnrichmentQuery{
+	WikidataQuery(WikidataGender),
+	WikidataQuery(WikidataOccupation),
+	WikidataQuery(WikidataCitizenship),
+	WikidataQuery(WikidataCountry),
+	WikidataQuery(WikidataBirthDate),
+	WikidataQuery(WikidataInception),
+}
+
+// EntityProfile reads the common attributes of people and organisations from the enrichment data of an entity,
+// see ProfileQueries. The attributes missing from the data are empty.
+type EntityProfile struct {
+	e *Entity
+}
+
+// Profile returns the profile of the entity
+func (e *Entity) Profile() EntityProfile {
+	return EntityProfile{e: e}
+}
+
+// values returns the values of the first query of an attribute found in the data
+func (p EntityProfile) values(attribute string) []string {
+	for _, q := range profileAttributes[attribute] {
+		if v := p.e.Enrichment(q); len(v) > 0 {
+			return v
+		}
+	}
+	return nil
+}
+
+func (p EntityProfile) value(attribute string) string {
+	if v := p.values(attribute); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// IsPerson reports whether the entity is a person, from its types
+func (p EntityProfile) IsPerson() bool {
+	return p.hasType("Person")
+}
+
+// IsOrganisation reports whether the entity is an organisation, e.g. a company, from its types
+func (p EntityProfile) IsOrganisation() bool {
+	return p.hasType("Organisation")
+}
+
+func (p EntityProfile) hasType(t string) bool {
+	for _, typ := range p.e.Types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Gender returns the gender of a person, e.g. "male"
+func (p EntityProfile) Gender() string {
+	return p.value("gender")
+}
+
+// Occupations returns the occupations of a person, e.g. "journalist"
+func (p EntityProfile) Occupations() []string {
+	return append([]string(nil), p.values("occupation")...)
+}
+
+// Country returns the country of citizenship of a person, or the country of an organisation or a place
+func (p EntityProfile) Country() string {
+	return p.value("country")
+}
+
+// BirthDate returns the date of birth of a person, false when it is missing or invalid
+func (p EntityProfile) BirthDate() (time.Time, bool) {
+	return parseProfileDate(p.value("birthDate"))
+}
+
+// FoundingDate returns the date an organisation was founded, false when it is missing or invalid
+func (p EntityProfile) FoundingDate() (time.Time, bool) {
+	return parseProfileDate(p.value("founded"))
+}
+
+// parseProfileDate parses the dates of the knowledge bases, e.g. "+1690-01-01T00:00:00Z", "1690-01-01" or "1690"
+func parseProfileDate(s string) (time.Time, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
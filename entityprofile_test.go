@@ -0,0 +1,72 @@
+package textrazor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			Entity profile tests
+
+func TestEntityProfile(t *testing.T) {
+	person := &Entity{Types: []string{"Agent", "Person"}, Data: EntryData{
+		"wikidata:P21":       {"female"},
+		"wikidata:P106":      {"journalist", "author"},
+		"wikidata:P27":       {"United Kingdom"},
+		"wikidata:P569":      {"+1965-03-12T00:00:00Z"},
+		"dbpedia:occupation": {"ignored"},
+	}}
+	p := person.Profile()
+	if !p.IsPerson() || p.IsOrganisation() || p.Gender() != "female" || p.Country() != "United Kingdom" {
+		t.Errorf("unexpected profile %+v", p)
+	}
+	if o := p.Occupations(); !reflect.DeepEqual(o, []string{"journalist", "author"}) {
+		t.Error("expect the Wikidata occupations first, got", o)
+	}
+	if d, ok := p.BirthDate(); !ok || !d.Equal(time.Date(1965, 3, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Error("unexpected birth date", d, ok)
+	}
+	if d, ok := p.FoundingDate(); ok {
+		t.Error("expect no founding date, got", d)
+	}
+
+	// without the Wikidata enrichment
+	company := &Entity{Types: []string{"Agent", "Organisation", "Company"}, Data: EntryData{
+		"dbpedia:country":      {"United Kingdom"},
+		"dbpedia:foundingDate": {"1690"},
+	}}
+	p = company.Profile()
+	if p.IsPerson() || !p.IsOrganisation() || p.Country() != "United Kingdom" || p.Gender() != "" || p.Occupations() != nil {
+		t.Errorf("unexpected profile %+v", p)
+	}
+	if d, ok := p.FoundingDate(); !ok || d.Year() != 1690 {
+		t.Error("unexpected founding date", d, ok)
+	}
+
+	params := Params{}
+	params.AddEnrichmentQueries(ProfileQueries...)
+	if q := params[EnrichmentQueriesParam]; len(q) != 6 || q[0] != "wikidata:P21" {
+		t.Error("unexpected queries", q)
+	}
+}
+
+func TestParseProfileDate(t *testing.T) {
+	var tests = []struct {
+		s      string
+		expect time.Time
+		ok     bool
+	}{
+		{"+1690-01-01T00:00:00Z", time.Date(1690, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2004-02-04", time.Date(2004, 2, 4, 0, 0, 0, 0, time.UTC), true},
+		{"2004-02", time.Date(2004, 2, 1, 0, 0, 0, 0, time.UTC), true},
+		{" 1998 ", time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"early 1900s", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		if got, ok := parseProfileDate(tt.s); ok != tt.ok || !got.Equal(tt.expect) {
+			t.Errorf("%q: expect %v %v, got %v %v", tt.s, tt.expect, tt.ok, got, ok)
+		}
+	}
+}
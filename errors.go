@@ -0,0 +1,122 @@
+package textrazor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matching each class of API failure with errors.Is
+var (
+	ErrAuthentication  = errors.New("authentication failed")
+	ErrQuotaExceeded   = errors.New("daily quota exceeded")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrRequestTooLarge = errors.New("request too large")
+)
+
+// APIError is returned when the API rejects a request, either with a non 200 status code or with the 'ok' field set to false
+//
+// the more specific AuthenticationError, QuotaExceededError, RateLimitError and RequestTooLargeError
+// all wrap an APIError, so errors.As(err, &apiErr) works for any of them
+//
+// StatusCode is 0 and HTTPResponse nil when the error is raised by the client before sending the request
+type APIError struct {
+	StatusCode int
+	// ErrorMessage is the 'error' field of the response
+	ErrorMessage string
+	// Message is the 'message' field of the response
+	Message      string
+	HTTPResponse *HTTPResponse
+}
+
+func (e *APIError) Error() string {
+	msg := e.ErrorMessage
+	if e.Message != "" {
+		if msg != "" {
+			msg += ": "
+		}
+		msg += e.Message
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.StatusCode == 0 {
+		// raised by the client, e.g. by a QuotaGuard
+		return msg
+	}
+	return fmt.Sprintf("api error (status %d): %s", e.StatusCode, msg)
+}
+
+// AuthenticationError is returned when the API key is missing or invalid
+type AuthenticationError struct{ *APIError }
+
+// Unwrap returns the underlying APIError
+func (e *AuthenticationError) Unwrap() error { return e.APIError }
+
+// Is reports whether target is ErrAuthentication
+func (e *AuthenticationError) Is(target error) bool { return target == ErrAuthentication }
+
+// QuotaExceededError is returned when the daily requests quota of the account is exhausted
+type QuotaExceededError struct{ *APIError }
+
+// Unwrap returns the underlying APIError
+func (e *QuotaExceededError) Unwrap() error { return e.APIError }
+
+// Is reports whether target is ErrQuotaExceeded
+func (e *QuotaExceededError) Is(target error) bool { return target == ErrQuotaExceeded }
+
+// RateLimitError is returned when too many requests are sent, or when the concurrent requests limit of the account is reached
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is the delay requested by the API before sending another request, 0 when unknown
+	RetryAfter time.Duration
+}
+
+// Unwrap returns the underlying APIError
+func (e *RateLimitError) Unwrap() error { return e.APIError }
+
+// Is reports whether target is ErrRateLimited
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimited }
+
+// RequestTooLargeError is returned when the request body exceeds the maximum size accepted by the API
+type RequestTooLargeError struct{ *APIError }
+
+// Unwrap returns the underlying APIError
+func (e *RequestTooLargeError) Unwrap() error { return e.APIError }
+
+// Is reports whether target is ErrRequestTooLarge
+func (e *RequestTooLargeError) Is(target error) bool { return target == ErrRequestTooLarge }
+
+// newAPIError builds the most specific error for a failed response
+//
+// the API doesn't return error codes, so the class is guessed from the status code, then from the messages
+// when the status code is ambiguous: a quota message mentioning the API key is still a QuotaExceededError
+func newAPIError(r *HTTPResponse) error {
+	apiErr := &APIError{StatusCode: r.Status, ErrorMessage: r.Error, Message: r.Message, HTTPResponse: r}
+
+	switch r.Status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &AuthenticationError{apiErr}
+	case http.StatusPaymentRequired:
+		return &QuotaExceededError{apiErr}
+	case http.StatusTooManyRequests:
+		return &RateLimitError{APIError: apiErr, RetryAfter: retryAfter(r)}
+	case http.StatusRequestEntityTooLarge:
+		return &RequestTooLargeError{apiErr}
+	}
+
+	msg := strings.ToLower(r.Error + " " + r.Message)
+	switch {
+	case strings.Contains(msg, "daily") && (strings.Contains(msg, "limit") || strings.Contains(msg, "quota")):
+		return &QuotaExceededError{apiErr}
+	case strings.Contains(msg, "concurrent"):
+		return &RateLimitError{APIError: apiErr, RetryAfter: retryAfter(r)}
+	case strings.Contains(msg, "too large"):
+		return &RequestTooLargeError{apiErr}
+	case strings.Contains(msg, "api key"):
+		return &AuthenticationError{apiErr}
+	}
+	return apiErr
+}
@@ -0,0 +1,82 @@
+package textrazor
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+//***************************************************************
+// 			Typed errors tests
+
+var apiErrorTests = []struct {
+	responseStatus int
+	responseBody   string
+	sentinel       error
+	check          func(error) bool
+}{
+	{http.StatusUnauthorized, `{"ok":false,"error":"Please provide a valid TextRazor API key."}`, ErrAuthentication, func(err error) bool {
+		var e *AuthenticationError
+		return errors.As(err, &e)
+	}},
+	{http.StatusOK, `{"ok":false,"error":"Please provide a valid TextRazor API key."}`, ErrAuthentication, func(err error) bool {
+		var e *AuthenticationError
+		return errors.As(err, &e)
+	}},
+	{http.StatusBadRequest, `{"ok":false,"error":"Your daily request limit has been exceeded."}`, ErrQuotaExceeded, func(err error) bool {
+		var e *QuotaExceededError
+		return errors.As(err, &e)
+	}},
+	{http.StatusPaymentRequired, `{"ok":false,"error":"The daily request limit of this API key has been exceeded."}`, ErrQuotaExceeded, func(err error) bool {
+		var e *QuotaExceededError
+		return errors.As(err, &e)
+	}},
+	{http.StatusBadRequest, `{"ok":false,"error":"Your daily request limit has been exceeded, upgrade the plan of your API key."}`, ErrQuotaExceeded, func(err error) bool {
+		var e *QuotaExceededError
+		return errors.As(err, &e)
+	}},
+	{http.StatusTooManyRequests, `{"ok":false,"error":"Too many requests for this API key."}`, ErrRateLimited, func(err error) bool {
+		var e *RateLimitError
+		return errors.As(err, &e)
+	}},
+	{http.StatusTooManyRequests, `{"ok":false}`, ErrRateLimited, func(err error) bool {
+		var e *RateLimitError
+		return errors.As(err, &e)
+	}},
+	{http.StatusBadRequest, `{"ok":false,"error":"Too many concurrent requests."}`, ErrRateLimited, func(err error) bool {
+		var e *RateLimitError
+		return errors.As(err, &e)
+	}},
+	{http.StatusRequestEntityTooLarge, "<html>Request Entity Too Large</html>", ErrRequestTooLarge, func(err error) bool {
+		var e *RequestTooLargeError
+		return errors.As(err, &e)
+	}},
+	{http.StatusServiceUnavailable, "", nil, func(err error) bool {
+		var e *APIError
+		return errors.As(err, &e) && e.StatusCode == http.StatusServiceUnavailable
+	}},
+}
+
+func TestAPIErrors(t *testing.T) {
+	for i, tst := range apiErrorTests {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, tst.responseStatus, tst.responseBody, false))
+		_, err := client.GetAccount()
+		if err == nil {
+			t.Error("TestAPIErrors[", i, "] should fail")
+			continue
+		}
+		t.Log(err)
+		if !tst.check(err) {
+			t.Errorf("TestAPIErrors[%d] unexpected error type %T", i, err)
+		}
+		if tst.sentinel != nil && !errors.Is(err, tst.sentinel) {
+			t.Errorf("TestAPIErrors[%d] expect errors.Is(err, %v)", i, tst.sentinel)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("TestAPIErrors[%d] expect %T to wrap an APIError", i, err)
+		} else if apiErr.HTTPResponse == nil || apiErr.StatusCode != tst.responseStatus {
+			t.Errorf("TestAPIErrors[%d] expect APIError to hold the response with status %d, got %v", i, tst.responseStatus, apiErr.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// pennToUPOS maps the Penn Treebank tags of TextRazor words to Universal POS tags
+var pennToUPOS = map[string]string{
+	"CC": "CCONJ", "CD": "NUM", "DT": "DET", "EX": "PRON", "FW": "X", "IN": "ADP",
+	"JJ": "ADJ", "JJR": "ADJ", "JJS": "ADJ", "LS": "X", "MD": "AUX",
+	"NN": "NOUN", "NNS": "NOUN", "NNP": "PROPN", "NNPS": "PROPN",
+	"PDT": "DET", "POS": "PART", "PRP": "PRON", "PRP$": "PRON",
+	"RB": "ADV", "RBR": "ADV", "RBS": "ADV", "RP": "ADP", "SYM": "SYM", "TO": "PART", "UH": "INTJ",
+	"VB": "VERB", "VBD": "VERB", "VBG": "VERB", "VBN": "VERB", "VBP": "VERB", "VBZ": "VERB",
+	"WDT": "DET", "WP": "PRON", "WP$": "PRON", "WRB": "ADV",
+	".": "PUNCT", ",": "PUNCT", ":": "PUNCT", "``": "PUNCT", "''": "PUNCT", "-LRB-": "PUNCT", "-RRB-": "PUNCT",
+	"#": "SYM", "$": "SYM", "HYPH": "PUNCT", "NFP": "PUNCT",
+}
+
+// WriteCoNLLU writes the sentences of an analysis in the CoNLL-U format, https://universaldependencies.org/format.html
+//
+// The part of speech of TextRazor is written as XPOS, and mapped to UPOS. HEAD and DEPREL are set when dependency trees
+// were requested, "root" is the relation of the root. FEATS and DEPS are left empty, MISC holds SpaceAfter=No.
+func WriteCoNLLU(w io.Writer, a *textrazor.Analysis) error {
+	bw := bufio.NewWriter(w)
+	for i := range a.Sentences {
+		s := &a.Sentences[i]
+		fmt.Fprintf(bw, "# sent_id = %d\n# text = %s\n", i+1, strings.ReplaceAll(s.Text(), "\n", " "))
+
+		ids := make(map[int]int, len(s.Words))
+		for j, word := range s.Words {
+			ids[word.Position] = j + 1
+		}
+		parsed := s.Root() != nil
+		for j, word := range s.Words {
+			head, deprel := "_", "_"
+			if parsed {
+				head, deprel = "0", "root"
+				if word.RelationToParent != "" {
+					head, deprel = strconv.Itoa(ids[word.ParentPosition]), word.RelationToParent
+				}
+			}
+			misc := "_"
+			if j+1 < len(s.Words) && s.Words[j+1].StartingPos == word.EndingPos {
+				misc = "SpaceAfter=No"
+			}
+			fmt.Fprintf(bw, "%d\t%s\t%s\t%s\t%s\t_\t%s\t%s\t_\t%s\n", j+1, field(word.Token), field(word.Lemma),
+				upos(word.PartOfSpeech), field(word.PartOfSpeech), head, deprel, misc)
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// upos returns the Universal POS tag of a Penn Treebank tag, X if it is unknown
+func upos(tag string) string {
+	if tag == "" {
+		return "_"
+	}
+	if u, ok := pennToUPOS[tag]; ok {
+		return u
+	}
+	return "X"
+}
+
+// field returns a CoNLL-U field, "_" if it's empty, without tabs and newlines
+func field(s string) string {
+	if s == "" {
+		return "_"
+	}
+	return strings.NewReplacer("\t", " ", "\n", " ").Replace(s)
+}
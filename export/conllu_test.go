@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+func TestWriteCoNLLU(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteCoNLLU(&b, decodeAnalysis(t, textrazortest.AnalysisDependencyTrees)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	var tests = []struct {
+		line   int
+		expect string
+	}{
+		{0, "# sent_id = 1"},
+		{1, "# text = " + textrazortest.Text},
+		{2, "1\tBarclays\tbarclays\tPROPN\tNNP\t_\t2\tnsubj\t_\t_"},
+		{16, "15\tbank\tbank\tNOUN\tNN\t_\t17\tposs\t_\tSpaceAfter=No"},
+		{17, "16\t's\t's\tPART\tPOS\t_\t15\tpossessive\t_\t_"},
+		{25, "24\tfound\tfind\tVERB\tVBN\t_\t0\troot\t_\tSpaceAfter=No"},
+		{26, "25\t.\t.\tPUNCT\t.\t_\t24\tpunct\t_\t_"},
+		{27, ""},
+	}
+	if len(lines) != 29 {
+		t.Fatal("expect 25 words, 2 comments and a blank line, got", len(lines), "lines")
+	}
+	for _, tt := range tests {
+		if lines[tt.line] != tt.expect {
+			t.Errorf("line %d: expect %q, got %q", tt.line, tt.expect, lines[tt.line])
+		}
+	}
+}
+
+func TestWriteCoNLLUWithoutTrees(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteCoNLLU(&b, decodeAnalysis(t, textrazortest.AnalysisWords)); err != nil {
+		t.Fatal(err)
+	}
+	if line := strings.Split(b.String(), "\n")[2]; line != "1\tBarclays\tbarclays\tPROPN\tNNP\t_\t_\t_\t_\t_" {
+		t.Errorf("expect no head nor relation, got %q", line)
+	}
+}
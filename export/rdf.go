@@ -0,0 +1,285 @@
+// Package export serializes TextRazor analyses into formats loaded by other tools.
+//
+// The RDF export describes the analyzed document, the entities it mentions, identified by their Wikidata or
+// Freebase IRIs, its topics and the subject-predicate-object triples of its relations, in Turtle or N-Triples:
+//
+//	<doc> schema:mentions wd:Q245343 ; tr:topic _:topic0 ; tr:triple _:triple0 .
+//	wd:Q245343 schema:name "Barclays"@en ; a dbo:Company ; owl:sameAs fb:m.01yx7f .
+//	_:topic0 tr:label "Banking" ; tr:score "0.9487"^^xsd:double ; owl:sameAs wd:Q22687 .
+//	_:triple0 tr:subject "Barclays" ; tr:predicate "misled" ; tr:object "shareholders" .
+//
+// The properties without a standard equivalent use the Namespace vocabulary.
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// Namespaces of the RDF export
+const (
+	Namespace       = "https://github.com/bengentil/textrazor-go/export#"
+	RDFNamespace    = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XSDNamespace    = "http://www.w3.org/2001/XMLSchema#"
+	OWLNamespace    = "http://www.w3.org/2002/07/owl#"
+	SchemaNamespace = "http://schema.org/"
+	DBpediaOntology = "http://dbpedia.org/ontology/"
+	WikidataEntity  = "http://www.wikidata.org/entity/"
+	FreebaseEntity  = "http://rdf.freebase.com/ns/"
+)
+
+// prefixes abbreviate the IRIs of the Turtle export
+var prefixes = []struct{ prefix, namespace string }{
+	{"rdf", RDFNamespace},
+	{"xsd", XSDNamespace},
+	{"owl", OWLNamespace},
+	{"schema", SchemaNamespace},
+	{"dbo", DBpediaOntology},
+	{"wd", WikidataEntity},
+	{"fb", FreebaseEntity},
+	{"tr", Namespace},
+}
+
+// RDFOptions configures the RDF export
+type RDFOptions struct {
+	// Document is the IRI of the analyzed document, a blank node by default
+	Document string
+}
+
+// term is an IRI, a blank node (_:name) or a literal
+type term struct {
+	iri, blank, literal string
+	// language tag or datatype IRI of a literal
+	lang, datatype string
+}
+
+type statement struct {
+	subject, predicate, object term
+}
+
+func iri(s string) term     { return term{iri: s} }
+func blank(s string) term   { return term{blank: s} }
+func literal(s string) term { return term{literal: s} }
+
+func double(f float64) term {
+	return term{literal: strconv.FormatFloat(f, 'g', -1, 64), datatype: XSDNamespace + "double"}
+}
+
+// statements returns the RDF statements describing an analysis
+func statements(a *textrazor.Analysis, o RDFOptions) []statement {
+	var st []statement
+	add := func(s term, p string, obj term) { st = append(st, statement{s, iri(p), obj}) }
+
+	doc := blank("document")
+	if o.Document != "" {
+		doc = iri(o.Document)
+	}
+	if a.Language != "" {
+		add(doc, Namespace+"language", literal(a.Language))
+	}
+
+	seen := map[term]bool{}
+	for _, e := range a.Entities {
+		node := entityNode(e)
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		add(doc, SchemaNamespace+"mentions", node)
+		switch {
+		case e.EntityEnglishID != "":
+			add(node, SchemaNamespace+"name", term{literal: e.EntityEnglishID, lang: "en"})
+		case e.EntityID != "":
+			add(node, SchemaNamespace+"name", literal(e.EntityID))
+		default:
+			add(node, SchemaNamespace+"name", literal(e.MatchedText))
+		}
+		for _, t := range e.Types {
+			add(node, RDFNamespace+"type", iri(DBpediaOntology+t))
+		}
+		if e.WikidataID != "" && e.FreebaseID != "" {
+			add(node, OWLNamespace+"sameAs", iri(freebaseIRI(e.FreebaseID)))
+		}
+		if e.WikiLink != "" {
+			add(node, SchemaNamespace+"sameAs", iri(e.WikiLink))
+		}
+		if e.CustomEntityID != "" {
+			add(node, Namespace+"customEntityId", literal(e.CustomEntityID))
+		}
+	}
+
+	for i, t := range a.Topics {
+		node := blank("topic" + strconv.Itoa(i))
+		add(doc, Namespace+"topic", node)
+		add(node, Namespace+"label", literal(t.Label))
+		add(node, Namespace+"score", double(t.Score))
+		if t.WikidataID != "" {
+			add(node, OWLNamespace+"sameAs", iri(WikidataEntity+t.WikidataID))
+		}
+		if t.WikiLink != "" {
+			add(node, SchemaNamespace+"sameAs", iri(t.WikiLink))
+		}
+	}
+
+	for i, t := range a.Triples() {
+		node := blank("triple" + strconv.Itoa(i))
+		add(doc, Namespace+"triple", node)
+		add(node, Namespace+"subject", literal(t.Subject))
+		add(node, Namespace+"predicate", literal(t.Predicate))
+		if t.Object != "" {
+			add(node, Namespace+"object", literal(t.Object))
+		}
+		add(node, Namespace+"confidence", double(t.Confidence))
+	}
+	return st
+}
+
+// entityNode returns the Wikidata or Freebase IRI of an entity, or a blank node named after its id or matched text
+func entityNode(e textrazor.Entity) term {
+	switch {
+	case e.WikidataID != "":
+		return iri(WikidataEntity + e.WikidataID)
+	case e.FreebaseID != "":
+		return iri(freebaseIRI(e.FreebaseID))
+	case e.CustomEntityID != "":
+		return blank("custom_" + blankName(e.CustomEntityID))
+	}
+	return blank("entity_" + blankName(e.MatchedText))
+}
+
+// freebaseIRI converts a Freebase MID, e.g. /m/01yx7f, to its RDF IRI
+func freebaseIRI(mid string) string {
+	return FreebaseEntity + strings.ReplaceAll(strings.TrimPrefix(mid, "/"), "/", ".")
+}
+
+// blankName returns a valid blank node label from s
+func blankName(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 128 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			fmt.Fprintf(&b, "_%x", r)
+		}
+	}
+	return b.String()
+}
+
+// WriteNTriples writes the RDF description of an analysis in N-Triples, one statement per line
+func WriteNTriples(w io.Writer, a *textrazor.Analysis, o RDFOptions) error {
+	bw := bufio.NewWriter(w)
+	for _, s := range statements(a, o) {
+		fmt.Fprintf(bw, "%s %s %s .\n", s.subject.nTriples(), s.predicate.nTriples(), s.object.nTriples())
+	}
+	return bw.Flush()
+}
+
+// WriteTurtle writes the RDF description of an analysis in Turtle, grouped by subject
+func WriteTurtle(w io.Writer, a *textrazor.Analysis, o RDFOptions) error {
+	bw := bufio.NewWriter(w)
+	for _, p := range prefixes {
+		fmt.Fprintf(bw, "@prefix %s: <%s> .\n", p.prefix, p.namespace)
+	}
+
+	// statements are grouped by subject, in order of first appearance
+	var subjects []term
+	grouped := map[term][]statement{}
+	for _, s := range statements(a, o) {
+		if _, ok := grouped[s.subject]; !ok {
+			subjects = append(subjects, s.subject)
+		}
+		grouped[s.subject] = append(grouped[s.subject], s)
+	}
+	for _, subject := range subjects {
+		fmt.Fprintf(bw, "\n%s", subject.turtle())
+		group := grouped[subject]
+		for i, s := range group {
+			switch {
+			case i == 0:
+				fmt.Fprintf(bw, " %s %s", s.predicate.turtle(), s.object.turtle())
+			case s.predicate == group[i-1].predicate:
+				fmt.Fprintf(bw, " ,\n\t\t%s", s.object.turtle())
+			default:
+				fmt.Fprintf(bw, " ;\n\t%s %s", s.predicate.turtle(), s.object.turtle())
+			}
+		}
+		bw.WriteString(" .\n")
+	}
+	return bw.Flush()
+}
+
+func (t term) nTriples() string {
+	switch {
+	case t.iri != "":
+		return "<" + escapeIRI(t.iri) + ">"
+	case t.blank != "":
+		return "_:" + t.blank
+	}
+	s := `"` + escapeLiteral(t.literal) + `"`
+	if t.lang != "" {
+		return s + "@" + t.lang
+	}
+	if t.datatype != "" {
+		return s + "^^<" + t.datatype + ">"
+	}
+	return s
+}
+
+func (t term) turtle() string {
+	switch {
+	case t.iri == RDFNamespace+"type":
+		return "a"
+	case t.iri != "":
+		return abbreviate(t.iri)
+	case t.literal != "" && t.datatype != "":
+		return `"` + escapeLiteral(t.literal) + `"^^` + abbreviate(t.datatype)
+	}
+	return t.nTriples()
+}
+
+// abbreviate returns the prefixed name of an IRI when its local name is safe, or the full IRI
+func abbreviate(s string) string {
+	for _, p := range prefixes {
+		if local := strings.TrimPrefix(s, p.namespace); local != s && isLocalName(local) {
+			return p.prefix + ":" + local
+		}
+	}
+	return "<" + escapeIRI(s) + ">"
+}
+
+// isLocalName reports whether s can be used as a Turtle local name as is, a conservative subset
+func isLocalName(s string) bool {
+	if s == "" || s[len(s)-1] == '.' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return s[0] != '.'
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func escapeLiteral(s string) string {
+	return literalEscaper.Replace(s)
+}
+
+// escapeIRI percent-encodes the characters forbidden in IRIs, e.g. the spaces of some Wikipedia links
+func escapeIRI(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r <= 0x20 || strings.ContainsRune("<>\"{}|^`\\", r) {
+			fmt.Fprintf(&b, "%%%02X", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
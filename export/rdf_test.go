@@ -0,0 +1,123 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go"
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+// decodeAnalysis decodes an analysis fixture
+func decodeAnalysis(t *testing.T, body string) *textrazor.Analysis {
+	analysis := &textrazor.Analysis{}
+	if err := json.Unmarshal([]byte(body), &textrazor.HTTPResponse{Response: analysis}); err != nil {
+		t.Fatal(err)
+	}
+	return analysis
+}
+
+// rdfAnalysis returns an analysis with an entity, a topic and two relations
+func rdfAnalysis(t *testing.T) *textrazor.Analysis {
+	a := decodeAnalysis(t, textrazortest.AnalysisRelations)
+	a.Topics = decodeAnalysis(t, textrazortest.AnalysisTopics).Topics[:1]
+	a.Entities = decodeAnalysis(t, textrazortest.AnalysisEntities).Entities[:1]
+	a.Entities[0].Types = a.Entities[0].Types[2:]
+	return a
+}
+
+func TestWriteTurtle(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteTurtle(&b, rdfAnalysis(t), RDFOptions{Document: "https://example.com/news/1"}); err != nil {
+		t.Fatal(err)
+	}
+	expect := `
+<https://example.com/news/1> tr:language "eng" ;
+	schema:mentions wd:Q245343 ;
+	tr:topic _:topic0 ;
+	tr:triple _:triple0 ,
+		_:triple1 .
+
+wd:Q245343 schema:name "Barclays"@en ;
+	a dbo:Company ,
+		dbo:Bank ;
+	owl:sameAs fb:m.01yx7f ;
+	schema:sameAs <http://en.wikipedia.org/wiki/Barclays> .
+
+_:topic0 tr:label "Banking" ;
+	tr:score "0.9487"^^xsd:double ;
+	owl:sameAs wd:Q22687 ;
+	schema:sameAs <http://en.wikipedia.org/Category:Banking> .
+
+_:triple0 tr:subject "Barclays" ;
+	tr:predicate "misled" ;
+	tr:object "shareholders and the public" ;
+	tr:confidence "1"^^xsd:double .
+
+_:triple1 tr:subject "a BBC Panorama investigation" ;
+	tr:predicate "has found" ;
+	tr:confidence "0.5"^^xsd:double .
+`
+	out := b.String()
+	if !strings.HasPrefix(out, "@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .\n") {
+		t.Error("expect the prefixes first, got", out)
+	}
+	if !strings.HasSuffix(out, expect) {
+		t.Errorf("expect %s, got %s", expect, out)
+	}
+}
+
+func TestWriteNTriples(t *testing.T) {
+	a := rdfAnalysis(t)
+	a.Entities = append(a.Entities,
+		textrazor.Entity{CustomEntityID: "DEV1", MatchedText: "Ken \"K\" Thompson"},
+		textrazor.Entity{MatchedText: "la Défense", WikiLink: "http://fr.wikipedia.org/wiki/La Défense"},
+	)
+	var b bytes.Buffer
+	if err := WriteNTriples(&b, a, RDFOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	for _, line := range lines {
+		if !strings.HasSuffix(line, " .") || strings.HasPrefix(line, "@") {
+			t.Error("expect a statement per line, got", line)
+		}
+	}
+
+	var tests = []string{
+		`_:document <http://schema.org/mentions> <http://www.wikidata.org/entity/Q245343> .`,
+		`<http://www.wikidata.org/entity/Q245343> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://dbpedia.org/ontology/Bank> .`,
+		`_:topic0 <https://github.com/bengentil/textrazor-go/export#score> "0.9487"^^<http://www.w3.org/2001/XMLSchema#double> .`,
+		`_:document <http://schema.org/mentions> _:custom_DEV1 .`,
+		`_:custom_DEV1 <http://schema.org/name> "Ken \"K\" Thompson" .`,
+		`_:custom_DEV1 <https://github.com/bengentil/textrazor-go/export#customEntityId> "DEV1" .`,
+		`_:entity_la_20D_e9fense <http://schema.org/sameAs> <http://fr.wikipedia.org/wiki/La%20Défense> .`,
+	}
+	for _, expect := range tests {
+		found := false
+		for _, line := range lines {
+			found = found || line == expect
+		}
+		if !found {
+			t.Error("expect the statement", expect)
+		}
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	var tests = []struct {
+		iri, expect string
+	}{
+		{WikidataEntity + "Q42", "wd:Q42"},
+		{FreebaseEntity + "m.0l7_8", "fb:m.0l7_8"},
+		{DBpediaOntology + "Work.", "<http://dbpedia.org/ontology/Work.>"},
+		{"http://en.wikipedia.org/wiki/Panorama_(TV_programme)", "<http://en.wikipedia.org/wiki/Panorama_(TV_programme)>"},
+	}
+	for _, tt := range tests {
+		if got := abbreviate(tt.iri); got != tt.expect {
+			t.Errorf("expect %s, got %s", tt.expect, got)
+		}
+	}
+}
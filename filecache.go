@@ -0,0 +1,84 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileCache is a Cache storing each response in a JSON file of a directory, named after the hash of the request,
+// so analyses are replayed offline across runs, e.g. while developing the processing of the analyses:
+//
+//	cache, err := textrazor.NewFileCache(".textrazor-cache")
+//	client := textrazor.NewClient(apiKey, textrazor.WithCache(cache))
+//
+// The files are never removed, delete the directory to clear the cache.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a FileCache in dir, created when missing
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("cache directory creation failed: %v", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// Dir returns the directory of the cache
+func (f *FileCache) Dir() string {
+	return f.dir
+}
+
+// path returns the file of a key
+func (f *FileCache) path(key string) (string, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("invalid cache key %q", key)
+	}
+	return filepath.Join(f.dir, key+".json"), nil
+}
+
+// Get implements Cache
+func (f *FileCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+// Set implements Cache, the file is replaced atomically so concurrent readers never see a partial response
+func (f *FileCache) Set(ctx context.Context, key string, value []byte) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Len returns the number of cached responses
+func (f *FileCache) Len() (int, error) {
+	files, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	return len(files), err
+}
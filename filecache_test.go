@@ -0,0 +1,84 @@
+package textrazor
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			File cache tests
+
+func TestFileCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithCache(cache))
+
+	params := Params{"extractors": {"entities"}}
+	for i := 0; i < 2; i++ {
+		if _, err := client.AnalyzeText(testText, params); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(transport.Requests()); n != 1 {
+		t.Error("expect a single request, got", n)
+	}
+	if n, err := cache.Len(); n != 1 || err != nil {
+		t.Error("expect a single cached response, got", n, err)
+	}
+
+	// replayed by another process, without network
+	replay, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusServiceUnavailable})
+	client = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, offline, WithCache(replay))
+	a, err := client.AnalyzeText(testText, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Entities) == 0 || len(offline.Requests()) != 0 {
+		t.Errorf("expect the analysis replayed from %s, got %d entities and %d requests", dir, len(a.Entities), len(offline.Requests()))
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Error("expect no temporary file left, got", files)
+	}
+}
+
+func TestFileCacheKeys(t *testing.T) {
+	cache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, ok, err := cache.Get(ctx, "missing"); ok || err != nil {
+		t.Error("expect a missing key, got", ok, err)
+	}
+	if err := cache.Set(ctx, "k", []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, err := cache.Get(ctx, "k"); !ok || err != nil || string(v) != "{}" {
+		t.Error("expect the stored value, got", string(v), ok, err)
+	}
+
+	for _, key := range []string{"", "../escape", `a\b`, ".hidden"} {
+		if err := cache.Set(ctx, key, nil); err == nil {
+			t.Errorf("expect an invalid key error for %q", key)
+		}
+		if _, _, err := cache.Get(ctx, key); err == nil {
+			t.Errorf("expect an invalid key error for %q", key)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package textrazor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fingerprintVersion changes when the computation of fingerprints changes
+const fingerprintVersion = "1"
+
+// fingerprintIgnored are the parameters describing the document rather than the options
+var fingerprintIgnored = map[string]bool{"text": true, "url": true}
+
+// Fingerprint returns a stable fingerprint of the effective options of an analysis of params by the client:
+// the parameters but the text or url, completed with the default params, the entities limit and the library version.
+// The order of the parameters and of their values doesn't matter.
+//
+// Every analysis holds the fingerprint of its options, so stored analyses can be grouped by configuration.
+func (c *Client) Fingerprint(params Params) string {
+	return c.fingerprint(c.withDefaultParams(params))
+}
+
+// fingerprint returns the fingerprint of params completed with the default params
+func (c *Client) fingerprint(params Params) string {
+	var b strings.Builder
+	b.WriteString("fingerprint=" + fingerprintVersion + "\n")
+	b.WriteString("library=" + Version() + "\n")
+	b.WriteString("maxEntities=" + strconv.Itoa(c.maxEntities) + "\n")
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if !fingerprintIgnored[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		values := append([]string(nil), params[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			b.WriteString(strconv.Quote(k) + "=" + strconv.Quote(v) + "\n")
+		}
+	}
+	h := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(h[:8])
+}
@@ -0,0 +1,74 @@
+package textrazor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Fingerprint tests
+
+func TestFingerprint(t *testing.T) {
+	client := NewClient(testAPIKey)
+	base := client.Fingerprint(Params{"extractors": {"entities", "topics"}, "classifiers": {"textrazor_iab"}, "text": {testText}})
+	if len(base) != 16 {
+		t.Error("expect a 16 characters fingerprint, got", base)
+	}
+
+	var tests = []struct {
+		name   string
+		client *Client
+		params Params
+		same   bool
+	}{
+		{"other order and text", client, Params{"url": {testURL}, "classifiers": {"textrazor_iab"}, "extractors": {"topics", "entities"}}, true},
+		{"other extractors", client, Params{"extractors": {"entities"}, "classifiers": {"textrazor_iab"}}, false},
+		{"other classifier", client, Params{"extractors": {"entities", "topics"}, "classifiers": {"textrazor_newscodes"}}, false},
+		{"cleanup mode", client, Params{"extractors": {"entities", "topics"}, "classifiers": {"textrazor_iab"}, "cleanup.mode": {"raw"}}, false},
+		{"default params", NewClient(testAPIKey, WithDefaultParams(Params{"classifiers": {"textrazor_iab"}})), Params{"extractors": {"entities", "topics"}}, true},
+		{"entities limit", NewClient(testAPIKey, WithMaxEntities(10)), Params{"extractors": {"entities", "topics"}, "classifiers": {"textrazor_iab"}}, false},
+		{"value and key collision", client, Params{"extractors": {"entities", "topics\n\"classifiers\"=\"textrazor_iab\""}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.client.Fingerprint(tt.params); (got == base) != tt.same {
+			t.Errorf("%s: expect the same fingerprint %v, got %s and %s", tt.name, tt.same, base, got)
+		}
+	}
+}
+
+func TestAnalysisFingerprint(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithCache(NewMemoryCache()))
+	params := Params{"extractors": {"entities"}}
+	expect := client.Fingerprint(params)
+
+	for _, name := range []string{"analysis", "cached analysis"} {
+		a, err := client.AnalyzeText(testText, copyParams(params))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Fingerprint != expect || a.Clone().Fingerprint != expect {
+			t.Errorf("%s: expect the fingerprint %s, got %s", name, expect, a.Fingerprint)
+		}
+	}
+
+	var tests = []struct {
+		parts  []string
+		expect string
+	}{
+		{[]string{"a", "a"}, "a"},
+		{[]string{"a", "b"}, ""},
+		{[]string{"a", "b", "a"}, ""},
+	}
+	for _, tt := range tests {
+		var parts []*Analysis
+		for _, f := range tt.parts {
+			parts = append(parts, &Analysis{Fingerprint: f})
+		}
+		if got := MergeAnalyses(parts...).Fingerprint; got != tt.expect {
+			t.Errorf("expect %v merged into %q, got %q", tt.parts, tt.expect, got)
+		}
+	}
+}
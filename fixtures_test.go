@@ -0,0 +1,235 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			textrazortest fixtures decoding tests
+
+var analysisFixturesTests = []struct {
+	name  string
+	check func(*testing.T, *Analysis)
+	fixme string
+}{
+	{"AnalysisEntities", func(t *testing.T, a *Analysis) {
+		if len(a.Entities) != 4 || a.Entities[1].EntityID != "BBC" || a.Entities[1].WikidataID != "Q9531" {
+			t.Error("expect 4 entities with BBC (Q9531) at index 1, got", a.Entities)
+		}
+	}, ""},
+	{"AnalysisCustomEntities", func(t *testing.T, a *Analysis) {
+		if len(a.Entities) != 1 || a.Entities[0].CustomEntityID != "BARC" {
+			t.Fatal("expect 1 custom entity BARC, got", a.Entities)
+		}
+		if d := a.Entities[0].Data; d.Get("ticker") != "BARC" || len(d["exchange"]) != 2 {
+			t.Error("expect the BARC ticker on 2 exchanges, got", d)
+		}
+	}, ""},
+	{"AnalysisTopics", func(t *testing.T, a *Analysis) {
+		if len(a.Topics) != 4 || a.Topics[0].Label != "Banking" {
+			t.Error("expect 4 topics with Banking first, got", a.Topics)
+		}
+		if len(a.CoarseTopics) == 0 || a.CoarseTopics[0].Label != "Business" {
+			t.Error("expect coarse topics with Business first, got", a.CoarseTopics)
+		}
+	}, ""},
+	{"AnalysisCategories", func(t *testing.T, a *Analysis) {
+		if len(a.Categories) != 3 || a.Categories[0].ClassifierID != "textrazor_newscodes" {
+			t.Error("expect 3 textrazor_newscodes categories, got", a.Categories)
+		}
+	}, ""},
+	{"AnalysisEntailments", func(t *testing.T, a *Analysis) {
+		if len(a.Entailments) != 2 {
+			t.Fatal("expect 2 entailments, got", a.Entailments)
+		}
+		if w := a.Entailments[0].EntailedWord(); w != "stockholder" {
+			t.Error("expect 'shareholders' to entail 'stockholder', got", w)
+		}
+	}, ""},
+	{"AnalysisRelations", func(t *testing.T, a *Analysis) {
+		if len(a.Relations) != 2 || len(a.Relations[0].Params) != 2 {
+			t.Error("expect 2 relations, the first one with 2 params, got", a.Relations)
+		}
+		if len(a.Properties) != 2 || a.Properties[0].WordPositions[0] != 21 {
+			t.Error("expect 2 properties, the first one on word 21, got", a.Properties)
+		}
+	}, ""},
+	{"AnalysisNounPhrases", func(t *testing.T, a *Analysis) {
+		if len(a.NounPhrases) != 4 || len(a.NounPhrases[3].WordPositions) != 4 {
+			t.Error("expect 4 noun phrases, the last one with 4 words, got", a.NounPhrases)
+		}
+	}, ""},
+	{"AnalysisWords", func(t *testing.T, a *Analysis) {
+		if len(a.Sentences) != 1 || len(a.Sentences[0].Words) != 25 || a.Sentences[0].Words[19].Token != "BBC" {
+			t.Error("expect 1 sentence of 25 words with BBC at position 19, got", a.Sentences)
+		}
+	}, ""},
+	{"AnalysisDependencyTrees", func(t *testing.T, a *Analysis) {
+		w := a.Sentences[0].Words[0]
+		if w.ParentPosition != 1 || w.RelationToParent != "nsubj" {
+			t.Error("expect Barclays to be the nsubj of word 1, got", w.ParentPosition, w.RelationToParent)
+		}
+	}, ""},
+	{"AnalysisSenses", func(t *testing.T, a *Analysis) {
+		if len(a.Sentences[0].Words[14].Senses) != 2 {
+			t.Error("expect 2 senses for 'bank', got", a.Sentences[0].Words[14].Senses)
+		}
+		if s, ok := a.Sentences[0].Words[14].TopSense(); !ok || s.Sense == "" || s.Score <= 0 {
+			t.Error("expect a top sense for 'bank', got", s)
+		}
+	}, ""},
+	{"AnalysisSpelling", func(t *testing.T, a *Analysis) {
+		if len(a.Sentences[0].Words[2].SpellingSuggestions) != 1 {
+			t.Error("expect 1 spelling suggestion for 'shareholders', got", a.Sentences[0].Words[2].SpellingSuggestions)
+		}
+		if s := a.Sentences[0].Words[2].SpellingSuggestions; len(s) == 1 && (s[0].Suggestion != "shareholders" || s[0].Score <= 0) {
+			t.Error("expect 'shareholders' to be suggested, got", s)
+		}
+	}, ""},
+	{"AnalysisCustomAnnotations", func(t *testing.T, a *Analysis) {
+		if a.CustomAnnotationOutput == "" || len(a.MatchingRules) != 1 {
+			t.Error("expect custom annotation output and 1 matching rule, got", a.CustomAnnotationOutput, a.MatchingRules)
+		}
+	}, ""},
+	{"AnalysisFull", func(t *testing.T, a *Analysis) {
+		if a.RawText != textrazortest.Text || a.CleanedText != textrazortest.Text {
+			t.Error("expect raw and cleaned text to be returned, got", a.RawText, a.CleanedText)
+		}
+	}, ""},
+}
+
+func TestAnalysisFixtures(t *testing.T) {
+	if len(analysisFixturesTests) != len(textrazortest.AnalysisFixtures) {
+		t.Error("expect a test for each of the", len(textrazortest.AnalysisFixtures), "analysis fixtures, got", len(analysisFixturesTests))
+	}
+	for _, tst := range analysisFixturesTests {
+		t.Run(tst.name, func(t *testing.T) {
+			if tst.fixme != "" {
+				t.Skip("FIXME:", tst.fixme)
+			}
+			body, ok := textrazortest.AnalysisFixtures[tst.name]
+			if !ok {
+				t.Fatal("unknown fixture", tst.name)
+			}
+			client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, body))
+			analysis, err := client.AnalyzeText(textrazortest.Text, Params{"extractors": {"entities"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkHTTPResponse(t, analysis.HTTPResponse)
+			tst.check(t, analysis)
+		})
+	}
+}
+
+func TestAnalysisFixturesRoundTrip(t *testing.T) {
+	for name, body := range textrazortest.AnalysisFixtures {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, body), WithResolvedReferences())
+		analysis, err := client.AnalyzeText(textrazortest.Text, Params{"extractors": {"entities"}})
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		b, err := json.Marshal(analysis)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+
+		var decoded Analysis
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(name, err)
+		}
+		decoded.ResolveReferences()
+		decoded.HTTPResponse = analysis.HTTPResponse
+		if !reflect.DeepEqual(&decoded, analysis) {
+			t.Errorf("%s: expect the analysis to survive a JSON round trip, got\n%s", name, b)
+		}
+	}
+}
+
+func TestManagementFixtures(t *testing.T) {
+	client := func(body string) *Client {
+		return NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, body))
+	}
+
+	account, err := client(textrazortest.Account).GetAccount()
+	if err != nil {
+		t.Error(err)
+	} else if account.Plan != "FREE" || account.PlanDailyIncludedRequests != 500 {
+		t.Error("expect a FREE plan with 500 daily requests, got", account)
+	}
+
+	resp, err := client(textrazortest.Dictionaries).GetDictionaries()
+	if err != nil {
+		t.Error(err)
+	} else if len(resp.Dictionaries) != 2 {
+		t.Error("expect 2 dictionaries, got", resp.Dictionaries)
+	}
+
+	dict, err := client(textrazortest.Dictionary).GetDictionary("test_ents")
+	if err != nil {
+		t.Error(err)
+	} else if dict.ID != "test_ents" || !dict.CaseInsensitive {
+		t.Error("expect the case insensitive test_ents dictionary, got", dict)
+	}
+
+	cl, err := client(textrazortest.Categories).GetClassifierCategories("sport", 20, 0)
+	if err != nil {
+		t.Error(err)
+	} else if cl.Total != 3 || len(cl.Categories) != 3 {
+		t.Error("expect 3 categories, got", cl)
+	}
+
+	cat, err := client(textrazortest.Category).GetClassifierCategory("sport", "100")
+	if err != nil {
+		t.Error(err)
+	} else if cat.Label != "Golf" {
+		t.Error("expect the Golf category, got", cat)
+	}
+
+	if _, err := client(textrazortest.OK).DeleteClassifier("sport"); err != nil {
+		t.Error(err)
+	}
+	if _, err := client(textrazortest.Error).DeleteClassifier("sport"); err == nil {
+		t.Error("expect the Error fixture to fail")
+	}
+}
+
+func TestGenerateAnalysis(t *testing.T) {
+	o := textrazortest.GenerateOptions{Seed: 1, Sentences: 50, WordsPerSentence: 12, Entities: 80, Topics: 10}
+	text, body := textrazortest.GenerateAnalysis(o)
+	if again, body2 := textrazortest.GenerateAnalysis(o); again != text || body2 != body {
+		t.Error("expect the same analysis for the same options")
+	}
+	if other, _ := textrazortest.GenerateAnalysis(textrazortest.GenerateOptions{Seed: 2, Sentences: 50, WordsPerSentence: 12}); other == text {
+		t.Error("expect another document for another seed")
+	}
+
+	a, err := ParseAnalysis([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Sentences) != 50 || len(a.Entities) != 80 || len(a.Topics) != 10 {
+		t.Fatalf("expect 50 sentences, 80 entities and 10 topics, got %d %d %d", len(a.Sentences), len(a.Entities), len(a.Topics))
+	}
+	a.ResolveReferences()
+	for _, s := range a.Sentences {
+		if len(s.Words) != 12 {
+			t.Fatal("expect 12 words per sentence, got", len(s.Words))
+		}
+		for _, w := range s.Words {
+			if text[w.StartingPos:w.EndingPos] != w.Token {
+				t.Fatalf("expect the offsets of %q in the text, got %q", w.Token, text[w.StartingPos:w.EndingPos])
+			}
+		}
+	}
+	for _, e := range a.Entities {
+		if e.Mention(text) != e.MatchedText || len(e.Words) != 1 || e.Words[0].Token != e.MatchedText {
+			t.Fatalf("expect the offsets and tokens of %s consistent, got %q %v", e.EntityID, e.Mention(text), e.Words)
+		}
+	}
+}
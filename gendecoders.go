@@ -0,0 +1,217 @@
+//go:build ignore
+
+// gendecoders generates decoders_generated.go, the decoders without reflection of the structs given as arguments,
+// see decode.go. The structs are read from the sources of the package.
+//
+//	go run gendecoders.go Analysis Entity Sentence Word
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const output = "decoders_generated.go"
+
+// lexerReaders are the jsonLexer methods reading the builtin types
+var lexerReaders = map[string]string{"string": "string", "bool": "bool", "int": "int", "float64": "float"}
+
+// sliceElems are the statements decoding the elements of the slices of the decoders, by element type,
+// a decodeTypeSlice function is generated for each of them
+var sliceElems = map[string]string{}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		log.Fatal("usage: go run gendecoders.go Type...")
+	}
+	types := os.Args[1:]
+
+	structs, basics, err := parseTypes()
+	if err != nil {
+		log.Fatal(err)
+	}
+	generated := map[string]bool{}
+	for _, name := range types {
+		if structs[name] == nil {
+			log.Fatalf("struct %s not found", name)
+		}
+		generated[name] = true
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gendecoders.go; DO NOT EDIT.\n\npackage textrazor\n")
+	for _, name := range types {
+		if err := writeDecoder(&b, name, structs[name], generated, basics); err != nil {
+			log.Fatal(err)
+		}
+	}
+	writeSliceDecoders(&b)
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("generated code is invalid: %v\n%s", err, b.Bytes())
+	}
+	if err := os.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseTypes returns the structs declared in the sources of the package by name, and the types defined
+// as a builtin type read by jsonLexer, e.g. "type RelationType string", without their own UnmarshalJSON method
+func parseTypes() (map[string]*ast.StructType, map[string]string, error) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		return nil, nil, err
+	}
+	structs := map[string]*ast.StructType{}
+	basics := map[string]string{}
+	unmarshalers := map[string]bool{}
+	fset := token.NewFileSet()
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") || f == output || f == "gendecoders.go" {
+			continue
+		}
+		file, err := parser.ParseFile(fset, f, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				switch t := n.Type.(type) {
+				case *ast.StructType:
+					structs[n.Name.Name] = t
+				case *ast.Ident:
+					if _, ok := lexerReaders[t.Name]; ok && n.Assign == 0 {
+						basics[n.Name.Name] = t.Name
+					}
+				}
+			case *ast.FuncDecl:
+				if n.Recv != nil && n.Name.Name == "UnmarshalJSON" {
+					recv := n.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					if id, ok := recv.(*ast.Ident); ok {
+						unmarshalers[id.Name] = true
+					}
+				}
+			}
+			return true
+		})
+	}
+	for name := range unmarshalers {
+		delete(basics, name)
+	}
+	return structs, basics, nil
+}
+
+// writeDecoder writes the decoder of a struct, unmarshalName, and the JSON names of its fields
+func writeDecoder(b *bytes.Buffer, name string, s *ast.StructType, generated map[string]bool, basics map[string]string) error {
+	var names []string
+	var cases bytes.Buffer
+	extra := false
+	for _, f := range s.Fields.List {
+		if len(f.Names) != 1 {
+			return fmt.Errorf("%s: embedded and grouped fields aren't supported", name)
+		}
+		field := f.Names[0].Name
+		if !ast.IsExported(field) {
+			continue
+		}
+		jsonName := field
+		if f.Tag != nil {
+			tag, _ := strconv.Unquote(f.Tag.Value)
+			if n, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ","); n == "-" {
+				// like Dictionary.Extra, Extra keeps the fields which aren't modeled
+				extra = extra || field == "Extra" && types.ExprString(f.Type) == "map[string]json.RawMessage"
+				continue
+			} else if n != "" {
+				jsonName = n
+			}
+		}
+		names = append(names, jsonName)
+		fmt.Fprintf(&cases, "case %q:\n%s\n", jsonName, fieldDecoder("&v."+field, f.Type, generated, basics))
+	}
+
+	unknown := "l.skip()"
+	fmt.Fprintf(b, "\nvar %sFields = []string{%s}\n", lowerFirst(name), quoteAll(names))
+	fmt.Fprintf(b, "\nfunc unmarshal%s(l *jsonLexer, v *%s) {\n", name, name)
+	if extra {
+		fmt.Fprintf(b, "v.Extra = nil\n")
+		unknown = "l.extra(&v.Extra, key)"
+	}
+	fmt.Fprintf(b, "l.object(func(key []byte) {\nswitch l.field(key, %sFields) {\n%sdefault:\n%s\n}\n})\n}\n", lowerFirst(name), cases.Bytes(), unknown)
+	return nil
+}
+
+// fieldDecoder returns the statement decoding the field of type t at the address p
+func fieldDecoder(p string, t ast.Expr, generated map[string]bool, basics map[string]string) string {
+	switch t := t.(type) {
+	case *ast.Ident:
+		if reader, ok := lexerReaders[t.Name]; ok {
+			return fmt.Sprintf("l.%s(%s)", reader, p)
+		}
+		if basic, ok := basics[t.Name]; ok {
+			return fmt.Sprintf("l.%s((*%s)(%s))", lexerReaders[basic], basic, p)
+		}
+		if generated[t.Name] {
+			return fmt.Sprintf("unmarshal%s(l, %s)", t.Name, p)
+		}
+	case *ast.ArrayType:
+		if elem, ok := t.Elt.(*ast.Ident); ok && t.Len == nil {
+			if reader, ok := lexerReaders[elem.Name]; ok {
+				sliceElems[elem.Name] = fmt.Sprintf("l.%s(&v[len(v)-1])", reader)
+				return fmt.Sprintf("%s(l, %s)", sliceDecoder(elem.Name), p)
+			}
+			if generated[elem.Name] {
+				sliceElems[elem.Name] = fmt.Sprintf("unmarshal%s(l, &v[len(v)-1])", elem.Name)
+				return fmt.Sprintf("%s(l, %s)", sliceDecoder(elem.Name), p)
+			}
+		}
+	}
+	return fmt.Sprintf("l.unmarshal(%s)", p)
+}
+
+// writeSliceDecoders writes the decoders of the slices of sliceElems, reusing the backing array like encoding/json
+func writeSliceDecoders(b *bytes.Buffer) {
+	elems := make([]string, 0, len(sliceElems))
+	for elem := range sliceElems {
+		elems = append(elems, elem)
+	}
+	sort.Strings(elems)
+	for _, elem := range elems {
+		fmt.Fprintf(b, "\nfunc %s(l *jsonLexer, s *[]%s) {\n", sliceDecoder(elem), elem)
+		fmt.Fprintf(b, "if l.null() {\n*s = nil\nreturn\n}\n")
+		fmt.Fprintf(b, "if l.deferred != nil && l.deferred[l.pos] {\n// decoded by another goroutine, see decodeSections\nl.skip()\nreturn\n}\n")
+		fmt.Fprintf(b, "v := (*s)[:0]\nif v == nil {\nv = []%s{}\n}\n", elem)
+		fmt.Fprintf(b, "l.array(func() {\nvar zero %s\nv = append(v, zero)\n%s\n})\n*s = v\n}\n", elem, sliceElems[elem])
+	}
+}
+
+// sliceDecoder returns the name of the decoder of the slices of elem, e.g. decodeEntitySlice
+func sliceDecoder(elem string) string {
+	return "decode" + strings.ToUpper(elem[:1]) + elem[1:] + "Slice"
+}
+
+func lowerFirst(s string) string { return strings.ToLower(s[:1]) + s[1:] }
+
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = strconv.Quote(n)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,3 @@
+module github.com/bengentil/textrazor-go
+
+go 1.21
@@ -0,0 +1,91 @@
+package textrazor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// group runs functions in goroutines and waits for them, like golang.org/x/sync/errgroup.
+// Every goroutine started by the client for a fan-out goes through a group, so none outlives its call
+// and they are counted by Snapshot.
+//
+// The zero value has no limit and doesn't cancel anything on error.
+type group struct {
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	slots  chan struct{}
+	// running goroutines counter, Snapshot.Goroutines
+	running *atomic.Int64
+
+	once sync.Once
+	err  error
+}
+
+// newGroup returns a group running at most limit functions at a time, no limit if limit <= 0,
+// and a context canceled by the first error or when Wait returns
+func newGroup(ctx context.Context, limit int, running *atomic.Int64) (*group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &group{cancel: cancel, running: running}
+	if limit > 0 {
+		g.slots = make(chan struct{}, limit)
+	}
+	return g, ctx
+}
+
+// Go runs fn in a goroutine, waiting for a free slot when the group is limited
+func (g *group) Go(fn func() error) {
+	if g.slots != nil {
+		g.slots <- struct{}{}
+	}
+	g.start(fn)
+}
+
+// TryGo is like Go without waiting: fn isn't run and TryGo returns false when the group is limited and full
+func (g *group) TryGo(fn func() error) bool {
+	if g.slots != nil {
+		select {
+		case g.slots <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(fn)
+	return true
+}
+
+// start runs fn in a goroutine holding a slot
+func (g *group) start(fn func() error) {
+	g.wg.Add(1)
+	if g.running != nil {
+		g.running.Add(1)
+	}
+	go func() {
+		defer func() {
+			if g.running != nil {
+				g.running.Add(-1)
+			}
+			if g.slots != nil {
+				<-g.slots
+			}
+			g.wg.Done()
+		}()
+		if err := fn(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel(err)
+				}
+			})
+		}
+	}()
+}
+
+// Wait waits for the functions started with Go and returns the first error
+func (g *group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	return g.err
+}
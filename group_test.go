@@ -0,0 +1,121 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			Goroutine group tests
+
+// checkLeaks fails the test if the goroutines started after it was called don't stop soon
+func checkLeaks(t *testing.T) func() {
+	before := runtime.NumGoroutine()
+	return func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Errorf("expect %d goroutines, got %d", before, runtime.NumGoroutine())
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	defer checkLeaks(t)()
+	var running atomic.Int64
+	var current, max atomic.Int32
+	g, _ := newGroup(context.Background(), 3, &running)
+	for i := 0; i < 20; i++ {
+		g.Go(func() error {
+			n := current.Add(1)
+			for m := max.Load(); n > m && !max.CompareAndSwap(m, n); m = max.Load() {
+			}
+			time.Sleep(time.Millisecond)
+			current.Add(-1)
+			return nil
+		})
+		if n := running.Load(); n > 3 {
+			t.Error("expect at most 3 running goroutines, got", n)
+		}
+	}
+	if err := g.Wait(); err != nil {
+		t.Error(err)
+	}
+	if max.Load() != 3 || running.Load() != 0 {
+		t.Error("expect 3 concurrent functions and no goroutine left, got", max.Load(), running.Load())
+	}
+}
+
+func TestGroupError(t *testing.T) {
+	defer checkLeaks(t)()
+	failure := errors.New("failure")
+	g, ctx := newGroup(context.Background(), 0, nil)
+	g.Go(func() error { return failure })
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err := g.Wait(); err != failure {
+		t.Error("expect the first error, got", err)
+	}
+	if context.Cause(ctx) != failure {
+		t.Error("expect the context to be canceled by the error, got", context.Cause(ctx))
+	}
+
+	var zero group
+	zero.Go(func() error { return failure })
+	if err := zero.Wait(); err != failure {
+		t.Error("expect the zero group to return the error, got", err)
+	}
+}
+
+func TestFanOutLeaks(t *testing.T) {
+	defer checkLeaks(t)()
+	transport := &concurrencyTransport{}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Millisecond)
+	defer cancel()
+	texts := make([]string, 20)
+	for i := range texts {
+		texts[i] = testText
+	}
+	if _, err := client.AnalyzeManyContext(ctx, texts, Params{"extractors": {"entities"}}, CallConcurrency(2)); err == nil {
+		t.Error("expect the canceled analyses to fail")
+	}
+	if n := client.Snapshot().Goroutines; n != 0 {
+		t.Error("expect no goroutine left after AnalyzeMany, got", n)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 25*time.Millisecond)
+	defer cancel()
+	if _, err := client.UploadDictionaryEntriesContext(ctx, dictID, bulkEntries(50), BulkOptions{BatchSize: 2, Concurrency: 3}); err == nil {
+		t.Error("expect the canceled upload to fail")
+	}
+	if n := client.Snapshot().Goroutines; n != 0 {
+		t.Error("expect no goroutine left after UploadDictionaryEntries, got", n)
+	}
+}
+
+func TestCanaryGoroutines(t *testing.T) {
+	defer checkLeaks(t)()
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &concurrencyTransport{})
+	canary := NewCanary(client, 100, nil, nil)
+	if _, err := canary.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := client.Snapshot().Goroutines; n != 1 {
+		t.Error("expect the mirrored analysis to be counted, got", n)
+	}
+	canary.Wait()
+	if n := client.Snapshot().Goroutines; n != 0 {
+		t.Error("expect no goroutine left, got", n)
+	}
+}
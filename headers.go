@@ -0,0 +1,34 @@
+package textrazor
+
+import "net/http"
+
+// WithHeaders adds headers to every request of the client, e.g. the headers required by a proxy.
+// They replace the headers of the same name set by the client, except the API key.
+//
+// headers can be added to a single call with CallHeaders
+func WithHeaders(headers http.Header) Option {
+	return func(c *Client) { c.headers = headers.Clone() }
+}
+
+// CallHeaders adds headers to the requests of a single call,
+// they replace the headers of the same name set with WithHeaders
+func CallHeaders(headers http.Header) CallOption {
+	return func(o *callOptions) { o.headers = headers.Clone() }
+}
+
+// mergeHeaders returns a copy of headers with the values of the client and call headers
+func (c *Client) mergeHeaders(headers http.Header, o *callOptions) http.Header {
+	if len(c.headers) == 0 && len(o.headers) == 0 {
+		return headers
+	}
+	merged := headers.Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	for _, extra := range []http.Header{c.headers, o.headers} {
+		for k, v := range extra {
+			merged[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,56 @@
+package textrazor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Headers tests
+
+func TestHeaders(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithHeaders(http.Header{"x-traffic-tag": {"batch"}, "X-Env": {"prod"}}))
+
+	if _, err := client.GetAccountContext(context.Background(), CallHeaders(http.Header{"X-Traffic-Tag": {"interactive"}, apiKeyHeader: {"forged"}})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		request int
+		header  string
+		expect  string
+	}{
+		{0, "X-Traffic-Tag", "interactive"},
+		{0, "X-Env", "prod"},
+		{0, apiKeyHeader, testAPIKey},
+		{1, "X-Traffic-Tag", "batch"},
+		{1, "X-Env", "prod"},
+		{1, apiKeyHeader, testAPIKey},
+	}
+	requests := transport.Requests()
+	for _, tt := range tests {
+		if got := requests[tt.request].Header.Get(tt.header); got != tt.expect {
+			t.Errorf("request %d: expect %s: %s, got %s", tt.request, tt.header, tt.expect, got)
+		}
+	}
+}
+
+func TestHeadersKeepContentType(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	if _, err := client.AnalyzeTextContext(context.Background(), testText, Params{"extractors": {"entities"}}, CallHeaders(http.Header{"X-Request-Id": {"42"}})); err != nil {
+		t.Fatal(err)
+	}
+	h := transport.Requests()[0].Header
+	if h.Get("Content-Type") != contentTypeURL || h.Get("X-Request-Id") != "42" {
+		t.Error("expect the call headers to be added to the default headers, got", h)
+	}
+}
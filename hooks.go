@@ -0,0 +1,29 @@
+package textrazor
+
+import "net/http"
+
+// OnRequest adds a hook called with every HTTP request attempt before it is sent, including the retries,
+// e.g. to add headers or record the payloads. Hooks are called in the order they are added,
+// the request holds the API key and its body is read with GetBody.
+func OnRequest(hook func(*http.Request)) Option {
+	return func(c *Client) { c.requestHooks = append(c.requestHooks, hook) }
+}
+
+// OnResponse adds a hook called with every HTTP response received once decoded, including the errors
+// and the rate limited attempts, e.g. to record the payloads or the headers. Hooks are called in the order
+// they are added. The body of the response is read for them, it is discarded after unless WithResponseBody keeps it.
+func OnResponse(hook func(*HTTPResponse)) Option {
+	return func(c *Client) { c.responseHooks = append(c.responseHooks, hook) }
+}
+
+func (c *Client) runRequestHooks(req *http.Request) {
+	for _, hook := range c.requestHooks {
+		hook(req)
+	}
+}
+
+func (c *Client) runResponseHooks(r *HTTPResponse) {
+	for _, hook := range c.responseHooks {
+		hook(r)
+	}
+}
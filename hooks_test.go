@@ -0,0 +1,65 @@
+package textrazor
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Request and response hooks tests
+
+func TestHooks(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	var calls []string
+	var payloads []string
+	var responses []*HTTPResponse
+	var bodies []string
+	transport := textrazortest.NewSequenceTransport(rateLimited, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithRateLimitRetries(1, time.Second), WithResponseBody(false),
+		OnRequest(func(req *http.Request) {
+			calls = append(calls, "request 1")
+			req.Header.Set("X-Request-Id", "42")
+		}),
+		OnRequest(func(req *http.Request) {
+			calls = append(calls, "request 2")
+			body, _ := req.GetBody()
+			b, _ := io.ReadAll(body)
+			payloads = append(payloads, string(b))
+		}),
+		OnResponse(func(r *HTTPResponse) {
+			calls = append(calls, "response")
+			responses = append(responses, r)
+			bodies = append(bodies, string(r.Body))
+		}))
+
+	a, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := []string{"request 1", "request 2", "response", "request 1", "request 2", "response"}; !reflect.DeepEqual(calls, expect) {
+		t.Errorf("expect the hooks called in order for each attempt %v, got %v", expect, calls)
+	}
+	for i, req := range transport.Requests() {
+		if req.Header.Get("X-Request-Id") != "42" {
+			t.Errorf("expect the header added to the attempt %d, got %v", i, req.Header)
+		}
+	}
+	if len(payloads) != 2 || payloads[0] == "" || payloads[0] != payloads[1] {
+		t.Error("expect the payload of both attempts, got", payloads)
+	}
+	if len(responses) != 2 || responses[0].Status != http.StatusTooManyRequests || responses[1].Status != http.StatusOK || bodies[1] != textrazortest.AnalysisEntities {
+		t.Fatal("expect the rate limited and the successful responses with their body, got", responses)
+	}
+	if a.HTTPResponse.Body != nil {
+		t.Error("expect the body discarded once the hooks are called, got", string(a.HTTPResponse.Body))
+	}
+}
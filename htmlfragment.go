@@ -0,0 +1,347 @@
+package textrazor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CleanupModeParam is the analysis parameter defining how the API cleans the text before analyzing it
+const CleanupModeParam = "cleanup.mode"
+
+// Cleanup modes of the API
+const (
+	// CleanupRaw analyzes the text as is
+	CleanupRaw = "raw"
+	// CleanupStripTags removes the HTML tags, keeping all the text
+	CleanupStripTags = "stripTags"
+	// CleanupHTML removes the HTML tags and the boilerplate of a page, e.g. its navigation
+	CleanupHTML = "cleanHTML"
+)
+
+// parameters returning the cleaned text and the raw content of the analysis
+const (
+	returnCleanedParam = "cleanup.returnCleaned"
+	returnRawParam     = "cleanup.returnRaw"
+)
+
+// Link is a link found in an HTML fragment
+type Link struct {
+	// Href is the href attribute, URL the absolute URL it resolves to
+	Href string
+	URL  string
+	// Text is the text of the link, without its tags
+	Text string
+	// External reports whether the link points to another host than the base URL
+	External bool
+	// StartingPos and EndingPos are the offsets of the text of the link in the cleaned text, in code points,
+	// and Entities the entities mentioned in the text of the link. They are set with CallLinkMentions,
+	// the offsets are -1 when the text isn't found, e.g. the link was removed with the boilerplate by CleanupHTML.
+	StartingPos int
+	EndingPos   int
+	Entities    []*Entity
+}
+
+// CallLinkMentions locates the links returned by AnalyzeHTML and AnalyzeURLLinks in the cleaned text
+// and sets the entities mentioned in their text, e.g. to build a graph of the entities linking to pages
+func CallLinkMentions() CallOption {
+	return func(o *callOptions) { o.linkMentions = true }
+}
+
+// HTMLAnalysis is the analysis of an HTML fragment
+type HTMLAnalysis struct {
+	*Analysis
+	// BaseURL is the URL the links are resolved against, the base URL of AnalyzeHTML or of a <base> tag of the fragment
+	BaseURL string
+	// Links are the links of the fragment, in order, the links which aren't valid URLs are skipped
+	Links []Link
+}
+
+// AnalyzeHTML analyzes an HTML fragment, e.g. the body of a post, and returns its links resolved against baseURL,
+// the URL of the page it comes from. The tags are stripped by the API unless CleanupModeParam is set in params.
+func (c *Client) AnalyzeHTML(fragment, baseURL string, params Params) (*HTMLAnalysis, error) {
+	return c.AnalyzeHTMLContext(context.Background(), fragment, baseURL, params)
+}
+
+// AnalyzeHTMLContext is like AnalyzeHTML with a context
+func (c *Client) AnalyzeHTMLContext(ctx context.Context, fragment, baseURL string, params Params, opts ...CallOption) (*HTMLAnalysis, error) {
+	base, links, err := ExtractLinks(fragment, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	params = copyParams(params)
+	if params.Get(CleanupModeParam) == "" {
+		params.Set(CleanupModeParam, CleanupStripTags)
+	}
+	mentions := newCallOptions(opts).linkMentions
+	if mentions {
+		params.Set(returnCleanedParam, "true")
+	}
+	a, err := c.AnalyzeTextContext(ctx, fragment, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if mentions {
+		locateLinks(a, links)
+	}
+	return &HTMLAnalysis{Analysis: a, BaseURL: base, Links: links}, nil
+}
+
+// AnalyzeURLLinks is like AnalyzeURL and returns the links of the page, resolved against its URL.
+// The raw content of the page is returned by the API to extract them, it is kept in RawText.
+func (c *Client) AnalyzeURLLinks(urlStr string, params Params) (*HTMLAnalysis, error) {
+	return c.AnalyzeURLLinksContext(context.Background(), urlStr, params)
+}
+
+// AnalyzeURLLinksContext is like AnalyzeURLLinks with a context
+func (c *Client) AnalyzeURLLinksContext(ctx context.Context, urlStr string, params Params, opts ...CallOption) (*HTMLAnalysis, error) {
+	params = copyParams(params)
+	params.Set(returnRawParam, "true")
+	mentions := newCallOptions(opts).linkMentions
+	if mentions {
+		params.Set(returnCleanedParam, "true")
+	}
+	a, err := c.AnalyzeURLContext(ctx, urlStr, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	base, links, err := ExtractLinks(a.RawText, urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if mentions {
+		locateLinks(a, links)
+	}
+	return &HTMLAnalysis{Analysis: a, BaseURL: base, Links: links}, nil
+}
+
+// locateLinks sets the offsets of the links in the cleaned text of the analysis, searched in order
+// ignoring the spaces, and the entities overlapping them
+func locateLinks(a *Analysis, links []Link) {
+	text, pos := collapseSpaces(a.CleanedText)
+	from := 0
+	for i := range links {
+		l := &links[i]
+		l.StartingPos, l.EndingPos = -1, -1
+		if l.Text == "" {
+			continue
+		}
+		n := strings.Index(text[from:], l.Text)
+		if n < 0 {
+			continue
+		}
+		start := utf8.RuneCountInString(text[:from+n])
+		end := start + utf8.RuneCountInString(l.Text)
+		from += n + len(l.Text)
+		l.StartingPos, l.EndingPos = pos[start], pos[end-1]+1
+		for j := range a.Entities {
+			if e := &a.Entities[j]; e.StartingPos < l.EndingPos && e.EndingPos > l.StartingPos {
+				l.Entities = append(l.Entities, e)
+			}
+		}
+	}
+}
+
+// collapseSpaces returns s with its spaces collapsed like the text of the links,
+// and the offset in s of each code point of the result
+func collapseSpaces(s string) (string, []int) {
+	var b strings.Builder
+	var pos []int
+	space := false
+	n := 0
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+		} else {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+				pos = append(pos, n-1)
+			}
+			space = false
+			b.WriteRune(r)
+			pos = append(pos, n)
+		}
+		n++
+	}
+	return b.String(), pos
+}
+
+// ExtractLinks returns the links of an HTML fragment resolved against baseURL, which may be empty when the links
+// are absolute, and the base URL used: a <base href> of the fragment overrides baseURL, like in a browser.
+func ExtractLinks(fragment, baseURL string) (string, []Link, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid base URL: %v", err)
+	}
+
+	var links []Link
+	baseTag := false
+	for _, t := range scanTags(fragment) {
+		href, ok := t.attrs["href"]
+		if !ok {
+			continue
+		}
+		u, err := url.Parse(strings.TrimSpace(href))
+		if err != nil {
+			continue
+		}
+		// without base URL, relative links are kept as is
+		if *base != (url.URL{}) {
+			u = base.ResolveReference(u)
+		}
+		switch t.name {
+		case "base":
+			// only the first <base> counts
+			if !baseTag {
+				base, baseTag = u, true
+			}
+		case "a":
+			links = append(links, Link{Href: href, URL: u.String(), Text: t.text, External: u.Host != "" && !strings.EqualFold(u.Host, base.Host)})
+		}
+	}
+	return base.String(), links, nil
+}
+
+// htmlTag is an opening tag of a fragment, with the text up to its closing tag for <a>
+type htmlTag struct {
+	name  string
+	attrs map[string]string
+	text  string
+}
+
+// scanTags returns the <a> and <base> tags of an HTML fragment. It's a minimal scanner,
+// the standard library has no HTML parser, skipping comments, scripts and styles.
+func scanTags(s string) []htmlTag {
+	var tags []htmlTag
+	// unlike strings.ToLower, only ASCII letters are lowered, so the indexes of lower and s match
+	lower := []byte(s)
+	for i, c := range lower {
+		if 'A' <= c && c <= 'Z' {
+			lower[i] = c + 'a' - 'A'
+		}
+	}
+	for i := 0; i < len(s); {
+		lt := strings.IndexByte(s[i:], '<')
+		if lt < 0 {
+			break
+		}
+		i += lt
+		switch {
+		case bytes.HasPrefix(lower[i:], []byte("<!--")):
+			i = skipPast(lower, i, "-->")
+			continue
+		case bytes.HasPrefix(lower[i:], []byte("<script")):
+			i = skipPast(lower, i, "</script")
+			continue
+		case bytes.HasPrefix(lower[i:], []byte("<style")):
+			i = skipPast(lower, i, "</style")
+			continue
+		}
+		name, attrs, end := parseTag(s, i+1)
+		i = end
+		switch name {
+		case "base":
+			tags = append(tags, htmlTag{name: name, attrs: attrs})
+		case "a":
+			closing := bytes.Index(lower[i:], []byte("</a"))
+			if closing < 0 {
+				closing = len(s) - i
+			}
+			tags = append(tags, htmlTag{name: name, attrs: attrs, text: innerText(s[i : i+closing])})
+		}
+	}
+	return tags
+}
+
+// skipPast returns the index following the first occurrence of marker after i, or the end of s
+func skipPast(s []byte, i int, marker string) int {
+	if n := bytes.Index(s[i:], []byte(marker)); n >= 0 {
+		return i + n + len(marker)
+	}
+	return len(s)
+}
+
+// parseTag parses the tag starting at i, after its '<', and returns its lowercased name, its attributes,
+// and the index following its '>'
+func parseTag(s string, i int) (string, map[string]string, int) {
+	start := i
+	for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+		i++
+	}
+	name := strings.ToLower(s[start:i])
+	attrs := map[string]string{}
+	for i < len(s) && s[i] != '>' {
+		if isTagSpace(s[i]) || s[i] == '/' {
+			i++
+			continue
+		}
+		start = i
+		for i < len(s) && !isTagSpace(s[i]) && s[i] != '=' && s[i] != '>' {
+			i++
+		}
+		key := strings.ToLower(s[start:i])
+		for i < len(s) && isTagSpace(s[i]) {
+			i++
+		}
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isTagSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					end = len(s) - i - 1
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+				if i > len(s) {
+					i = len(s)
+				}
+			} else {
+				start = i
+				for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		if _, ok := attrs[key]; !ok && key != "" {
+			attrs[key] = html.UnescapeString(value)
+		}
+	}
+	if i < len(s) {
+		i++
+	}
+	return name, attrs, i
+}
+
+// innerText returns the text of an HTML fragment without its tags, with collapsed spaces
+func innerText(s string) string {
+	var b strings.Builder
+	for {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:lt])
+		gt := strings.IndexByte(s[lt:], '>')
+		if gt < 0 {
+			break
+		}
+		s = s[lt+gt+1:]
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(b.String())), " ")
+}
+
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
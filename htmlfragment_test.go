@@ -0,0 +1,160 @@
+package textrazor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			HTML fragments tests
+
+func TestExtractLinks(t *testing.T) {
+	var tests = []struct {
+		fragment string
+		base     string
+		expectB  string
+		expect   []Link
+	}{
+		{`<p>Read <a href="/news/1?a=1&amp;b=2">the <b>first</b>
+			story</a> and <A HREF='https://example.org/x' class=ext>more</A>.</p>`, "https://example.com/blog/post",
+			"https://example.com/blog/post", []Link{
+				{Href: "/news/1?a=1&b=2", URL: "https://example.com/news/1?a=1&b=2", Text: "the first story"},
+				{Href: "https://example.org/x", URL: "https://example.org/x", Text: "more", External: true},
+			}},
+		{`<a href=next.html>next</a><a name="anchor">no href</a><a href="#top">top</a>`, "https://example.com/dir/page.html",
+			"https://example.com/dir/page.html", []Link{
+				{Href: "next.html", URL: "https://example.com/dir/next.html", Text: "next"},
+				{Href: "#top", URL: "https://example.com/dir/page.html#top", Text: "top"},
+			}},
+		{`<base href="https://cdn.example.net/a/"><base href="/ignored/"><a href="b">b</a>`, "https://example.com/",
+			"https://cdn.example.net/a/", []Link{{Href: "b", URL: "https://cdn.example.net/a/b", Text: "b"}}},
+		{`<!-- <a href="/commented">x</a> --><script>var s = '<a href="/script">';</script><a href="/kept">İstanbul</a>`, "http://example.com",
+			"http://example.com", []Link{{Href: "/kept", URL: "http://example.com/kept", Text: "İstanbul"}}},
+		{`<a href="http://[::1">bad</a><a href="mailto:me@example.com">mail</a><a href="x`, "",
+			"", []Link{{Href: "mailto:me@example.com", URL: "mailto:me@example.com", Text: "mail"}, {Href: "x", URL: "x"}}},
+	}
+	for _, tt := range tests {
+		base, links, err := ExtractLinks(tt.fragment, tt.base)
+		if err != nil || base != tt.expectB || !reflect.DeepEqual(links, tt.expect) {
+			t.Errorf("%s: expect %s %+v, got %s %+v %v", tt.fragment, tt.expectB, tt.expect, base, links, err)
+		}
+	}
+
+	if _, _, err := ExtractLinks("", "http://[::1"); err == nil {
+		t.Error("expect an invalid base URL error")
+	}
+}
+
+func TestAnalyzeHTML(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	params := Params{"extractors": {"entities"}}
+	fragment := `<p>Barclays <a href="/about">misled</a> shareholders</p>`
+	a, err := client.AnalyzeHTML(fragment, "https://example.com/news/", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Entities) == 0 || a.BaseURL != "https://example.com/news/" || len(a.Links) != 1 || a.Links[0].URL != "https://example.com/about" {
+		t.Errorf("unexpected analysis %+v", a)
+	}
+	if params.Get("text") != "" || params.Get(CleanupModeParam) != "" {
+		t.Error("expect the params of the caller unchanged, got", params)
+	}
+
+	req := transport.Requests()[0]
+	body, _ := req.GetBody()
+	b, _ := io.ReadAll(body)
+	form, _ := url.ParseQuery(string(b))
+	if form.Get("text") != fragment || form.Get(CleanupModeParam) != CleanupStripTags {
+		t.Error("expect the fragment with the tags stripped by the API, got", form)
+	}
+
+	params.Set(CleanupModeParam, CleanupHTML)
+	if _, err := client.AnalyzeHTML(fragment, "", params); err != nil {
+		t.Fatal(err)
+	}
+	body, _ = transport.Requests()[1].GetBody()
+	b, _ = io.ReadAll(body)
+	if form, _ = url.ParseQuery(string(b)); form.Get(CleanupModeParam) != CleanupHTML {
+		t.Error("expect the cleanup mode of the params, got", form)
+	}
+}
+
+func TestLinkMentions(t *testing.T) {
+	// the cleaned text returned by the API
+	body := strings.Replace(textrazortest.AnalysisEntities, `"language"`, `"cleanedText": `+strconv.Quote(textrazortest.Text)+`, "language"`, 1)
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: body})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	fragment := `<nav><a href="/">Home</a></nav><p><a href="/banks/barclays">Barclays</a> misled shareholders and the public about
+		one of the biggest investments in the bank's history, a <a href="https://www.bbc.co.uk/panorama">BBC
+		<b>Panorama</b></a> investigation has <a href="/found">found</a>.</p>`
+	a, err := client.AnalyzeHTMLContext(context.Background(), fragment, "https://example.com/", Params{"extractors": {"entities"}}, CallLinkMentions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		start, end int
+		entities   []string
+	}{
+		{-1, -1, nil},
+		{0, 8, []string{"Barclays"}},
+		{106, 118, []string{"BBC", "Panorama"}},
+		{137, 142, nil},
+	}
+	if len(a.Links) != len(tests) {
+		t.Fatal("unexpected links", a.Links)
+	}
+	for i, tt := range tests {
+		l := a.Links[i]
+		var entities []string
+		for _, e := range l.Entities {
+			entities = append(entities, e.MatchedText)
+		}
+		if l.StartingPos != tt.start || l.EndingPos != tt.end || !reflect.DeepEqual(entities, tt.entities) {
+			t.Errorf("%s: expect %d-%d %v, got %d-%d %v", l.Text, tt.start, tt.end, tt.entities, l.StartingPos, l.EndingPos, entities)
+		}
+	}
+
+	reqBody, _ := transport.Requests()[0].GetBody()
+	b, _ := io.ReadAll(reqBody)
+	if form, _ := url.ParseQuery(string(b)); form.Get("cleanup.returnCleaned") != "true" {
+		t.Error("expect the cleaned text to be requested, got", form)
+	}
+}
+
+func TestAnalyzeURLLinks(t *testing.T) {
+	page := `<html><head><title>Panorama</title></head><body><a href="about">About</a><a href="https://bbc.co.uk/">BBC</a></body></html>`
+	body := strings.Replace(textrazortest.AnalysisEntities, `"language"`, `"rawText": `+strconv.Quote(page)+`, "language"`, 1)
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: body})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	a, err := client.AnalyzeURLLinks("https://example.com/news/story.html", Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []Link{
+		{Href: "about", URL: "https://example.com/news/about", Text: "About"},
+		{Href: "https://bbc.co.uk/", URL: "https://bbc.co.uk/", Text: "BBC", External: true},
+	}
+	if !reflect.DeepEqual(a.Links, expect) || len(a.Entities) == 0 {
+		t.Errorf("expect %+v, got %+v", expect, a.Links)
+	}
+
+	reqBody, _ := transport.Requests()[0].GetBody()
+	b, _ := io.ReadAll(reqBody)
+	form, _ := url.ParseQuery(string(b))
+	if form.Get("url") != "https://example.com/news/story.html" || form.Get("cleanup.returnRaw") != "true" || form.Get("cleanup.returnCleaned") != "" {
+		t.Error("expect the raw page to be requested, got", form)
+	}
+}
@@ -0,0 +1,116 @@
+package textrazor
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultDataColumn names the data columns of a CSV import without header nor DataColumns
+const DefaultDataColumn = "data"
+
+// CSVImportOptions configures ImportDictionaryEntriesCSV, zero values use the defaults
+type CSVImportOptions struct {
+	BulkOptions
+	// Comma is the field delimiter, ',' by default, '\t' for TSV
+	Comma rune
+	// Header skips the first row, naming the data columns
+	Header bool
+	// DataColumns names the data columns, the columns after id and text.
+	// The names of the header are used when it's empty, or DefaultDataColumn.
+	DataColumns []string
+}
+
+// ImportDictionaryEntriesCSV adds the entries read from CSV rows to a dictionary, the rows are:
+//
+//	id,text[,data...]
+//
+// empty data cells are skipped, and the values of data columns of the same name are added to a list. Rows are uploaded in batches while they are read, like UploadDictionaryEntries:
+// a failed batch doesn't stop the import, and an invalid row stops it after the batches already read.
+func (c *Client) ImportDictionaryEntriesCSV(dictID string, r io.Reader, o CSVImportOptions) (*BulkProgress, error) {
+	return c.ImportDictionaryEntriesCSVContext(context.Background(), dictID, r, o)
+}
+
+// ImportDictionaryEntriesCSVContext is like ImportDictionaryEntriesCSV with a context
+func (c *Client) ImportDictionaryEntriesCSVContext(ctx context.Context, dictID string, r io.Reader, o CSVImportOptions, opts ...CallOption) (*BulkProgress, error) {
+	cr := csv.NewReader(r)
+	if o.Comma != 0 {
+		cr.Comma = o.Comma
+	}
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	columns := o.DataColumns
+	if o.Header {
+		header, err := cr.Read()
+		if err == io.EOF {
+			return &BulkProgress{}, nil
+		}
+		if err != nil {
+			return &BulkProgress{}, fmt.Errorf("dictionary entries import failed: %w", err)
+		}
+		if len(columns) == 0 && len(header) > 2 {
+			columns = append([]string(nil), header[2:]...)
+		}
+	}
+
+	size := o.batchSize()
+	offset, batch := 0, 0
+	// the entries read before an invalid row are uploaded, the error is returned by the next call
+	var pending error
+	return c.uploadBatches(ctx, dictID, o.BulkOptions, BulkProgress{}, func() (*BatchError, error) {
+		if pending != nil {
+			return nil, pending
+		}
+		b := &BatchError{Batch: batch, Offset: offset}
+		for len(b.Entries) < size && pending == nil {
+			row, err := cr.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				pending = fmt.Errorf("dictionary entries import failed: %w", err)
+				break
+			}
+			e, err := csvEntry(row, columns)
+			if err != nil {
+				line, _ := cr.FieldPos(0)
+				pending = fmt.Errorf("dictionary entries import failed: line %d: %v", line, err)
+				break
+			}
+			b.Entries = append(b.Entries, e)
+		}
+		if len(b.Entries) == 0 {
+			return nil, pending
+		}
+		batch++
+		offset += len(b.Entries)
+		return b, nil
+	}, opts...)
+}
+
+// csvEntry returns the dictionary entry of a CSV row
+func csvEntry(row, columns []string) (DictionaryEntry, error) {
+	if len(row) < 2 || strings.TrimSpace(row[0]) == "" || strings.TrimSpace(row[1]) == "" {
+		return DictionaryEntry{}, fmt.Errorf("expect an id and a text, got %q", row)
+	}
+	e := DictionaryEntry{ID: strings.TrimSpace(row[0]), Text: row[1]}
+	for i, v := range row[2:] {
+		if v == "" {
+			continue
+		}
+		name := DefaultDataColumn
+		if i < len(columns) {
+			name = columns[i]
+		} else if len(columns) > 0 {
+			return DictionaryEntry{}, fmt.Errorf("unnamed data column %d", i+3)
+		}
+		if e.Data == nil {
+			e.Data = EntryData{}
+		}
+		e.Data.Add(name, v)
+	}
+	return e, nil
+}
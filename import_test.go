@@ -0,0 +1,94 @@
+package textrazor
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			CSV import tests
+
+func TestImportDictionaryEntriesCSV(t *testing.T) {
+	var tests = []struct {
+		name   string
+		csv    string
+		o      CSVImportOptions
+		expect []DictionaryEntry
+	}{
+		{"no data", "DEV1,Ken Thompson\nDEV2,Rob Pike\n", CSVImportOptions{},
+			[]DictionaryEntry{{ID: "DEV1", Text: "Ken Thompson"}, {ID: "DEV2", Text: "Rob Pike"}}},
+		{"default data column", "DEV1,Ken Thompson,unix\n", CSVImportOptions{},
+			[]DictionaryEntry{{ID: "DEV1", Text: "Ken Thompson", Data: EntryData{"data": {"unix"}}}}},
+		{"header", "id,text,lang,company\nDEV1,Ken Thompson,,Bell Labs\nDEV2,Rob Pike,go,Google\n", CSVImportOptions{Header: true},
+			[]DictionaryEntry{
+				{ID: "DEV1", Text: "Ken Thompson", Data: EntryData{"company": {"Bell Labs"}}},
+				{ID: "DEV2", Text: "Rob Pike", Data: EntryData{"lang": {"go"}, "company": {"Google"}}},
+			}},
+		{"list", "id,text,lang,lang\nDEV2,Rob Pike,c,go\n", CSVImportOptions{Header: true},
+			[]DictionaryEntry{{ID: "DEV2", Text: "Rob Pike", Data: EntryData{"lang": {"c", "go"}}}}},
+		{"tsv", "DEV1\tThompson, Ken\tc\n", CSVImportOptions{Comma: '\t', DataColumns: []string{"lang"}},
+			[]DictionaryEntry{{ID: "DEV1", Text: "Thompson, Ken", Data: EntryData{"lang": {"c"}}}}},
+		{"empty", "", CSVImportOptions{Header: true}, nil},
+	}
+
+	for _, tt := range tests {
+		server := newDictionaryServer()
+		progress, err := server.client().ImportDictionaryEntriesCSV(dictID, strings.NewReader(tt.csv), tt.o)
+		if err != nil {
+			t.Error(tt.name, err)
+		}
+		if got := server.sorted(); !reflect.DeepEqual(got, tt.expect) || progress.Uploaded != len(tt.expect) {
+			t.Errorf("%s: expect %+v, got %+v %+v", tt.name, tt.expect, got, progress)
+		}
+		server.Close()
+	}
+}
+
+func TestImportDictionaryEntriesCSVBatches(t *testing.T) {
+	server := newDictionaryServer()
+	defer server.Close()
+
+	var b strings.Builder
+	for _, e := range bulkEntries(25) {
+		b.WriteString(e.ID + "," + e.Text + "\n")
+	}
+	progress, err := server.client().ImportDictionaryEntriesCSV(dictID, strings.NewReader(b.String()), CSVImportOptions{BulkOptions: BulkOptions{BatchSize: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *progress != (BulkProgress{Batches: 3, Done: 3, Entries: 25, Uploaded: 25}) || server.writes != 3 || len(server.sorted()) != 25 {
+		t.Errorf("expect 25 entries uploaded in 3 batches, got %+v", progress)
+	}
+}
+
+func TestImportDictionaryEntriesCSVErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		csv      string
+		o        CSVImportOptions
+		expect   string
+		uploaded int
+	}{
+		{"missing text", "DEV1,Ken Thompson\nDEV2\n", CSVImportOptions{BulkOptions: BulkOptions{BatchSize: 1}}, "line 2: expect an id and a text", 1},
+		{"bad row in batch", "DEV1,Ken Thompson\nDEV2,Rob Pike\nDEV3\nDEV4,Dennis Ritchie\n", CSVImportOptions{}, "line 3: expect an id and a text", 2},
+		{"unnamed column", "DEV1,Ken Thompson,c,unix\n", CSVImportOptions{DataColumns: []string{"lang"}}, "line 1: unnamed data column 4", 0},
+		{"bad quote", "DEV1,Ken \"Thompson\"\n", CSVImportOptions{}, "bare \" in non-quoted-field", 0},
+	}
+	for _, tt := range tests {
+		server := newDictionaryServer()
+		progress, err := server.client().ImportDictionaryEntriesCSV(dictID, strings.NewReader(tt.csv), tt.o)
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%s: expect %s, got %v", tt.name, tt.expect, err)
+		}
+		var bulkErr *BulkError
+		if errors.As(err, &bulkErr) {
+			t.Error(tt.name, "expect the batches read before the error to be uploaded, got", bulkErr)
+		}
+		if n := len(server.sorted()); n != tt.uploaded || progress.Uploaded != tt.uploaded {
+			t.Errorf("%s: expect the %d entries read before the error to be uploaded, got %d %+v", tt.name, tt.uploaded, n, progress)
+		}
+		server.Close()
+	}
+}
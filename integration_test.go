@@ -0,0 +1,99 @@
+//go:build integration
+// +build integration
+
+package textrazor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//***************************************************************
+// 			Integration tests against the live API
+//
+// go test -tags=integration
+//
+// requires TEXTRAZOR_API_KEY, each run uses a couple of daily requests
+//
+// set TEXTRAZOR_RECORD=1 to save the responses in testdata/recorded,
+// they are then checked by the contract tests
+
+const (
+	integrationAPIKeyEnv = "TEXTRAZOR_API_KEY"
+	integrationRecordEnv = "TEXTRAZOR_RECORD"
+)
+
+func integrationClient(t *testing.T) *Client {
+	apiKey := os.Getenv(integrationAPIKeyEnv)
+	if apiKey == "" {
+		t.Skip(integrationAPIKeyEnv, "is not set, skipping integration test")
+	}
+	return NewClient(apiKey)
+}
+
+// record saves the response body for the contract tests, name must start with a kind of recordedKinds
+func record(t *testing.T, name string, r *HTTPResponse) {
+	if os.Getenv(integrationRecordEnv) == "" {
+		return
+	}
+	dir := filepath.Join("testdata", "recorded")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, name+".json"), r.Body, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIntegrationGetAccount(t *testing.T) {
+	client := integrationClient(t)
+	account, err := client.GetAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHTTPResponse(t, account.HTTPResponse)
+	record(t, "account_live", account.HTTPResponse)
+	if account.Plan == "" {
+		t.Error("expect 'plan' field to be set, got an empty string")
+	}
+	if account.ConcurrentRequestLimit == 0 {
+		t.Error("expect 'concurrentRequestLimit' field to be set, got 0")
+	}
+	if account.PlanDailyIncludedRequests == 0 {
+		t.Error("expect 'planDailyRequestsIncluded' field to be set, got 0")
+	}
+}
+
+func TestIntegrationAnalyzeText(t *testing.T) {
+	client := integrationClient(t)
+	params := Params{"extractors": {"entities", "topics", "words", "phrases", "relations"}}
+	analysis, err := client.AnalyzeText(testText, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHTTPResponse(t, analysis.HTTPResponse)
+	record(t, "analysis_live", analysis.HTTPResponse)
+
+	if len(analysis.Entities) == 0 {
+		t.Fatal("expect entities in response, got none")
+	}
+	for _, e := range analysis.Entities {
+		if e.EntityID == "" || e.MatchedText == "" || len(e.MatchingTokens) == 0 {
+			t.Error("expect entityId, matchedText and matchingTokens to be set, got", e)
+		}
+	}
+	if len(analysis.Topics) == 0 || analysis.Topics[0].Label == "" {
+		t.Error("expect labeled topics in response, got", analysis.Topics)
+	}
+	if len(analysis.Sentences) == 0 || len(analysis.Sentences[0].Words) == 0 {
+		t.Fatal("expect sentences with words in response, got", analysis.Sentences)
+	}
+	if w := analysis.Sentences[0].Words[0]; w.Token == "" || w.EndingPos == 0 {
+		t.Error("expect token and offsets to be set on words, got", w)
+	}
+	if len(analysis.NounPhrases) == 0 {
+		t.Error("expect noun phrases in response, got none")
+	}
+}
@@ -0,0 +1,112 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The interfaces below are the extension points of a Client, integrations with third-party systems
+// implement them in their own module, so the client only depends on the standard library.
+
+// KeyProvider returns the API key of each request, e.g. from a secret manager rotating keys
+type KeyProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// StaticKey is a KeyProvider always returning the same key, the key passed to NewClient
+type StaticKey string
+
+// APIKey implements KeyProvider
+func (k StaticKey) APIKey(ctx context.Context) (string, error) { return string(k), nil }
+
+// WithKeyProvider gets the API key of each request from p, instead of the key passed to NewClient
+func WithKeyProvider(p KeyProvider) Option {
+	return func(c *Client) {
+		c.keys = p
+	}
+}
+
+// Cache stores the raw JSON responses of analyses, see WithCache
+//
+// Get returns false when the key is missing. It is used concurrently.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// RequestMetrics describes an HTTP request attempt sent to the API
+type RequestMetrics struct {
+	Method string
+	Path   string
+	// Status is 0 when no response was received
+	Status   int
+	Duration time.Duration
+	// Attempt is 0 for the first attempt, and the number of the retry after
+	Attempt int
+	Err     error
+	// Tags are the tags of the context of the request, see WithTags
+	Tags Tags
+}
+
+// MetricsSink receives the metrics of the requests sent to the API, it is used concurrently
+type MetricsSink interface {
+	ObserveRequest(m RequestMetrics)
+}
+
+// WithMetrics sends the metrics of each request attempt to m
+func WithMetrics(m MetricsSink) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// Logger logs the events the client recovers from, like retries or cache failures.
+// *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger logs the events the client recovers from to l
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// logf logs to the logger of the client, if any, with the tags of ctx
+func (c *Client) logf(ctx context.Context, format string, v ...interface{}) {
+	if c.logger == nil {
+		return
+	}
+	if tags := TagsFromContext(ctx); len(tags) > 0 {
+		format += " [" + strings.ReplaceAll(tags.String(), "%", "%%") + "]"
+	}
+	c.logger.Printf("textrazor: "+format, v...)
+}
+
+// observe sends the metrics of a request attempt to the metrics sink of the client, if any
+func (c *Client) observe(ctx context.Context, method, path string, attempt int, start time.Time, r *HTTPResponse, err error) {
+	if c.metrics == nil {
+		return
+	}
+	m := RequestMetrics{Method: method, Path: path, Duration: time.Since(start), Attempt: attempt, Err: err, Tags: TagsFromContext(ctx)}
+	if r != nil {
+		m.Status = r.Status
+	} else if apiErr := (*APIError)(nil); errors.As(err, &apiErr) {
+		m.Status = apiErr.StatusCode
+	}
+	c.metrics.ObserveRequest(m)
+}
+
+// setAPIKey sets the API key header of a request
+func (c *Client) setAPIKey(ctx context.Context, h http.Header) error {
+	key, err := c.keys.APIKey(ctx)
+	if err != nil {
+		return err
+	}
+	h.Set(apiKeyHeader, key)
+	return nil
+}
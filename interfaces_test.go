@@ -0,0 +1,91 @@
+package textrazor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Extension interfaces tests
+
+type rotatingKeys struct {
+	keys []string
+	err  error
+}
+
+func (r *rotatingKeys) APIKey(ctx context.Context) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	k := r.keys[0]
+	r.keys = append(r.keys[1:], k)
+	return k, nil
+}
+
+type recordingMetrics struct {
+	mu      sync.Mutex
+	metrics []RequestMetrics
+}
+
+func (r *recordingMetrics) ObserveRequest(m RequestMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+func TestKeyProvider(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithKeyProvider(&rotatingKeys{keys: []string{"k1", "k2"}}))
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetAccount(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	requests := transport.Requests()
+	if requests[0].Header.Get(apiKeyHeader) != "k1" || requests[1].Header.Get(apiKeyHeader) != "k2" {
+		t.Error("expect a key per request, got", requests[0].Header, requests[1].Header)
+	}
+
+	errNoKey := errors.New("vault sealed")
+	client = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, 200, "", true), WithKeyProvider(&rotatingKeys{err: errNoKey}))
+	if _, err := client.GetAccount(); !errors.Is(err, errNoKey) {
+		t.Error("expect the key provider error, got", err)
+	}
+}
+
+func TestMetricsAndLogger(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	var logs bytes.Buffer
+	metrics := &recordingMetrics{}
+	transport := textrazortest.NewSequenceTransport(rateLimited, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithRateLimitRetries(1, time.Second), WithMetrics(metrics), WithLogger(log.New(&logs, "", 0)))
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(metrics.metrics) != 2 {
+		t.Fatal("expect the metrics of 2 attempts, got", metrics.metrics)
+	}
+	first, second := metrics.metrics[0], metrics.metrics[1]
+	if first.Status != http.StatusTooManyRequests || first.Err == nil || first.Attempt != 0 || first.Method != http.MethodGet || first.Path != "/account/" {
+		t.Errorf("unexpected first attempt %+v", first)
+	}
+	if second.Status != http.StatusOK || second.Err != nil || second.Attempt != 1 {
+		t.Errorf("unexpected second attempt %+v", second)
+	}
+	if !strings.Contains(logs.String(), "textrazor: GET /account/ rate limited, retry 1 in 1ms") {
+		t.Error("expect the retry to be logged, got", logs.String())
+	}
+}
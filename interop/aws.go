@@ -0,0 +1,75 @@
+package interop
+
+import (
+	"unicode/utf8"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// AWS Comprehend specific entity types
+const (
+	AWSCommercialItem = "COMMERCIAL_ITEM"
+	AWSTitle          = "TITLE"
+)
+
+var awsTypes = map[string]string{
+	"Work":                 AWSTitle,
+	"Device":               AWSCommercialItem,
+	"Food":                 AWSCommercialItem,
+	"MeanOfTransportation": AWSCommercialItem,
+}
+
+// ComprehendEntities is the response of the AWS Comprehend DetectEntities action
+type ComprehendEntities struct {
+	Entities []ComprehendEntity `json:"Entities"`
+}
+
+// ComprehendEntity https://docs.aws.amazon.com/comprehend/latest/APIReference/API_Entity.html
+type ComprehendEntity struct {
+	BeginOffset int     `json:"BeginOffset"`
+	EndOffset   int     `json:"EndOffset"`
+	Score       float32 `json:"Score"`
+	Text        string  `json:"Text"`
+	Type        string  `json:"Type"`
+}
+
+// ComprehendClasses is the response of the AWS Comprehend ClassifyDocument action
+type ComprehendClasses struct {
+	Classes []ComprehendClass `json:"Classes"`
+}
+
+// ComprehendClass https://docs.aws.amazon.com/comprehend/latest/APIReference/API_DocumentClass.html
+type ComprehendClass struct {
+	Name  string  `json:"Name"`
+	Score float32 `json:"Score"`
+}
+
+// ToComprehendEntities converts the entities of an analysis, one per mention.
+//
+// The confidence score of TextRazor isn't bounded, it is mapped to a score between 0 and 1 with c/(1+c).
+func ToComprehendEntities(a *textrazor.Analysis) ComprehendEntities {
+	r := ComprehendEntities{Entities: []ComprehendEntity{}}
+	for _, e := range a.Entities {
+		end := e.EndingPos
+		if end == 0 {
+			end = e.StartingPos + utf8.RuneCountInString(e.MatchedText)
+		}
+		r.Entities = append(r.Entities, ComprehendEntity{
+			BeginOffset: e.StartingPos,
+			EndOffset:   end,
+			Score:       float32(e.ConfidenceScore / (1 + e.ConfidenceScore)),
+			Text:        e.MatchedText,
+			Type:        entityType(e, awsTypes),
+		})
+	}
+	return r
+}
+
+// ToComprehendClasses converts the categories of an analysis, named by their label
+func ToComprehendClasses(a *textrazor.Analysis) ComprehendClasses {
+	r := ComprehendClasses{Classes: []ComprehendClass{}}
+	for _, c := range a.Categories {
+		r.Classes = append(r.Classes, ComprehendClass{Name: c.Label, Score: float32(c.Score)})
+	}
+	return r
+}
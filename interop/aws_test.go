@@ -0,0 +1,29 @@
+package interop
+
+import (
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+func TestToComprehendEntities(t *testing.T) {
+	r := ToComprehendEntities(decodeAnalysis(t, textrazortest.AnalysisEntities))
+	if len(r.Entities) != 4 {
+		t.Fatal("expect 4 entities, got", r.Entities)
+	}
+	for _, e := range r.Entities {
+		if textrazortest.Text[e.BeginOffset:e.EndOffset] != e.Text || e.Score <= 0 || e.Score >= 1 {
+			t.Error("expect valid offsets and score, got", e)
+		}
+	}
+	if r.Entities[1].Type != TypeOrganization || r.Entities[2].Type != AWSTitle || r.Entities[3].Type != TypePerson {
+		t.Error("expect ORGANIZATION, TITLE and PERSON types, got", r.Entities)
+	}
+}
+
+func TestToComprehendClasses(t *testing.T) {
+	r := ToComprehendClasses(decodeAnalysis(t, textrazortest.AnalysisCategories))
+	if len(r.Classes) != 3 || r.Classes[0].Name != "economy, business and finance>financial and business service" || r.Classes[0].Score != 0.5713 {
+		t.Error("expect 3 classes, got", r.Classes)
+	}
+}
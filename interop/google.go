@@ -0,0 +1,101 @@
+package interop
+
+import (
+	"github.com/bengentil/textrazor-go"
+)
+
+// Google Cloud Natural Language specific entity types
+const (
+	GoogleWorkOfArt    = "WORK_OF_ART"
+	GoogleConsumerGood = "CONSUMER_GOOD"
+)
+
+var googleTypes = map[string]string{
+	"Work":                 GoogleWorkOfArt,
+	"Device":               GoogleConsumerGood,
+	"Food":                 GoogleConsumerGood,
+	"MeanOfTransportation": GoogleConsumerGood,
+}
+
+// GoogleEntities is the response of the Google Cloud Natural Language analyzeEntities method
+type GoogleEntities struct {
+	Entities []GoogleEntity `json:"entities"`
+}
+
+// GoogleEntity https://cloud.google.com/natural-language/docs/reference/rest/v1/Entity
+type GoogleEntity struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Salience float32           `json:"salience"`
+	Mentions []GoogleMention   `json:"mentions"`
+}
+
+// GoogleMention https://cloud.google.com/natural-language/docs/reference/rest/v1/Entity#EntityMention
+type GoogleMention struct {
+	Text GoogleTextSpan `json:"text"`
+	Type string         `json:"type"`
+}
+
+// GoogleTextSpan https://cloud.google.com/natural-language/docs/reference/rest/v1/TextSpan
+type GoogleTextSpan struct {
+	Content     string `json:"content"`
+	BeginOffset int    `json:"beginOffset"`
+}
+
+// GoogleCategories is the response of the Google Cloud Natural Language classifyText method
+type GoogleCategories struct {
+	Categories []GoogleCategory `json:"categories"`
+}
+
+// GoogleCategory https://cloud.google.com/natural-language/docs/reference/rest/v1/ClassificationCategory
+type GoogleCategory struct {
+	Name       string  `json:"name"`
+	Confidence float32 `json:"confidence"`
+}
+
+// ToGoogleEntities converts the entities of an analysis, the mentions of the same entity are grouped,
+// the salience is the best relevance score of the mentions
+func ToGoogleEntities(a *textrazor.Analysis) GoogleEntities {
+	r := GoogleEntities{Entities: []GoogleEntity{}}
+	index := map[string]int{}
+	for _, e := range a.Entities {
+		name := entityName(e)
+		mention := GoogleMention{Text: GoogleTextSpan{Content: e.MatchedText, BeginOffset: e.StartingPos}, Type: "PROPER"}
+		if e.EntityID == "" && e.CustomEntityID == "" {
+			mention.Type = "COMMON"
+		}
+		if i, ok := index[name]; ok {
+			ge := &r.Entities[i]
+			ge.Mentions = append(ge.Mentions, mention)
+			if float32(e.RelevanceScore) > ge.Salience {
+				ge.Salience = float32(e.RelevanceScore)
+			}
+			continue
+		}
+
+		ge := GoogleEntity{Name: name, Type: entityType(e, googleTypes), Salience: float32(e.RelevanceScore), Mentions: []GoogleMention{mention}}
+		if e.WikiLink != "" || e.FreebaseID != "" {
+			ge.Metadata = map[string]string{}
+			if e.WikiLink != "" {
+				ge.Metadata["wikipedia_url"] = e.WikiLink
+			}
+			if e.FreebaseID != "" {
+				ge.Metadata["mid"] = e.FreebaseID
+			}
+		}
+		index[name] = len(r.Entities)
+		r.Entities = append(r.Entities, ge)
+	}
+	return r
+}
+
+// ToGoogleCategories converts the categories of an analysis, labels are converted to paths,
+// e.g. "science and technology>media" becomes "/science and technology/media"
+func ToGoogleCategories(a *textrazor.Analysis) GoogleCategories {
+	r := GoogleCategories{Categories: []GoogleCategory{}}
+	for _, c := range a.Categories {
+		r.Categories = append(r.Categories, GoogleCategory{Name: categoryPath(c.Label), Confidence: float32(c.Score)})
+	}
+	return r
+}
@@ -0,0 +1,42 @@
+package interop
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bengentil/textrazor-go"
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+func TestToGoogleEntities(t *testing.T) {
+	analysis := decodeAnalysis(t, textrazortest.AnalysisEntities)
+	// a second mention of BBC
+	analysis.Entities = append(analysis.Entities, textrazor.Entity{EntityID: "BBC", EntityEnglishID: "BBC", MatchedText: "BBC", StartingPos: 150, RelevanceScore: 0.9})
+
+	r := ToGoogleEntities(analysis)
+	if len(r.Entities) != 4 {
+		t.Fatal("expect 4 entities, got", r.Entities)
+	}
+	bbc := r.Entities[1]
+	if bbc.Name != "BBC" || bbc.Type != TypeOrganization || bbc.Salience != 0.9 || len(bbc.Mentions) != 2 || bbc.Mentions[0].Text.BeginOffset != 106 {
+		t.Error("expect BBC with 2 mentions, got", bbc)
+	}
+	if bbc.Metadata["wikipedia_url"] != "http://en.wikipedia.org/wiki/BBC" || bbc.Metadata["mid"] != "/m/0ncl8zk" {
+		t.Error("expect BBC metadata, got", bbc.Metadata)
+	}
+	if r.Entities[2].Type != GoogleWorkOfArt {
+		t.Error("expect Panorama to be a work of art, got", r.Entities[2].Type)
+	}
+
+	b, err := json.Marshal(ToGoogleEntities(&textrazor.Analysis{}))
+	if err != nil || string(b) != `{"entities":[]}` {
+		t.Error("expect an empty list of entities, got", string(b), err)
+	}
+}
+
+func TestToGoogleCategories(t *testing.T) {
+	r := ToGoogleCategories(decodeAnalysis(t, textrazortest.AnalysisCategories))
+	if len(r.Categories) != 3 || r.Categories[2].Name != "/science and technology/media" || r.Categories[2].Confidence != 0.2436 {
+		t.Error("expect 3 categories, got", r.Categories)
+	}
+}
@@ -0,0 +1,65 @@
+// Package interop converts TextRazor analyses to the response shapes of other providers' NLP APIs,
+// Google Cloud Natural Language and AWS Comprehend, to compare providers or reuse downstream code
+// written for them.
+//
+// Only the fields with a TextRazor equivalent are set. Offsets are in characters (Unicode code points),
+// like the offsets of Google Cloud Natural Language with the UTF32 encoding type.
+package interop
+
+import (
+	"strings"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// Entity types shared by Google Cloud Natural Language and AWS Comprehend
+const (
+	TypePerson       = "PERSON"
+	TypeLocation     = "LOCATION"
+	TypeOrganization = "ORGANIZATION"
+	TypeEvent        = "EVENT"
+	TypeOther        = "OTHER"
+)
+
+// dbpediaTypes maps the DBpedia types of TextRazor entities to the shared entity types,
+// the first match in the entity types wins
+var dbpediaTypes = map[string]string{
+	"Person":       TypePerson,
+	"Place":        TypeLocation,
+	"Location":     TypeLocation,
+	"Organisation": TypeOrganization,
+	"Company":      TypeOrganization,
+	"Event":        TypeEvent,
+}
+
+// entityType returns the type of an entity, from the provider specific types in extra or the shared types
+func entityType(e textrazor.Entity, extra map[string]string) string {
+	for _, t := range e.Types {
+		if mapped, ok := extra[t]; ok {
+			return mapped
+		}
+		if mapped, ok := dbpediaTypes[t]; ok {
+			return mapped
+		}
+	}
+	return TypeOther
+}
+
+// entityName returns the name of an entity: its English id, its id, or its custom entity id,
+// and the matched text only when every id is empty
+func entityName(e textrazor.Entity) string {
+	switch {
+	case e.EntityEnglishID != "":
+		return e.EntityEnglishID
+	case e.EntityID != "":
+		return e.EntityID
+	case e.CustomEntityID != "":
+		return e.CustomEntityID
+	}
+	return e.MatchedText
+}
+
+// categoryPath converts a TextRazor category label, e.g. "science and technology>media", to a path "/science and technology/media"
+func categoryPath(label string) string {
+	return "/" + strings.Replace(label, ">", "/", -1)
+}
@@ -0,0 +1,39 @@
+package interop
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// decodeAnalysis decodes an analysis fixture
+func decodeAnalysis(t *testing.T, body string) *textrazor.Analysis {
+	analysis := &textrazor.Analysis{}
+	if err := json.Unmarshal([]byte(body), &textrazor.HTTPResponse{Response: analysis}); err != nil {
+		t.Fatal(err)
+	}
+	return analysis
+}
+
+func TestEntityType(t *testing.T) {
+	var tests = []struct {
+		types  []string
+		extra  map[string]string
+		expect string
+	}{
+		{[]string{"Agent", "Organisation", "Company"}, nil, TypeOrganization},
+		{[]string{"Agent", "Person"}, nil, TypePerson},
+		{[]string{"Place", "PopulatedPlace"}, nil, TypeLocation},
+		{[]string{"Work", "TelevisionShow"}, googleTypes, GoogleWorkOfArt},
+		{[]string{"Work", "TelevisionShow"}, awsTypes, AWSTitle},
+		{[]string{"Work", "TelevisionShow"}, nil, TypeOther},
+		{nil, nil, TypeOther},
+	}
+
+	for _, tt := range tests {
+		if got := entityType(textrazor.Entity{Types: tt.types}, tt.extra); got != tt.expect {
+			t.Error(tt.types, "expect", tt.expect, "got", got)
+		}
+	}
+}
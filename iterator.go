@@ -0,0 +1,103 @@
+package textrazor
+
+import "context"
+
+// DefaultPageSize is the number of entries requested per page by DictionaryEntryIterator
+const DefaultPageSize = 100
+
+// DictionaryEntryIterator pages through the entries of a dictionary,
+// it is used like a bufio.Scanner:
+//
+//	it := client.DictionaryEntriesIterator("dict")
+//	for it.Next() {
+//		entry := it.Entry()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type DictionaryEntryIterator struct {
+	// PageSize is the number of entries requested per page, it can be changed before the first call to Next
+	PageSize int
+
+	ctx    context.Context
+	client *Client
+	dictID string
+	opts   []CallOption
+
+	page   []DictionaryEntry
+	index  int
+	offset int
+	total  int
+	done   bool
+	err    error
+}
+
+// DictionaryEntriesIterator returns an iterator over every entry of a dictionary
+func (c *Client) DictionaryEntriesIterator(dictID string) *DictionaryEntryIterator {
+	return c.DictionaryEntriesIteratorContext(context.Background(), dictID)
+}
+
+// DictionaryEntriesIteratorContext is like DictionaryEntriesIterator with a context used by every page request
+func (c *Client) DictionaryEntriesIteratorContext(ctx context.Context, dictID string, opts ...CallOption) *DictionaryEntryIterator {
+	return &DictionaryEntryIterator{PageSize: DefaultPageSize, ctx: ctx, client: c, dictID: dictID, opts: opts, index: -1}
+}
+
+// Next advances to the next entry, requesting the next page when needed.
+// It returns false at the end of the entries or on error.
+func (it *DictionaryEntryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	if it.index < len(it.page) {
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	size := it.PageSize
+	if size < 1 {
+		size = DefaultPageSize
+	}
+	list, err := it.client.ListDictionaryEntriesContext(it.ctx, it.dictID, ListOptions{Limit: size, Offset: it.offset}, it.opts...)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page, it.index, it.total = list.Entries, 0, list.Total
+	it.offset += len(list.Entries)
+	// an empty page ends the iteration even if the total isn't reached, the dictionary may have been modified
+	it.done = len(list.Entries) == 0 || it.offset >= list.Total
+	return len(it.page) > 0
+}
+
+// Entry returns the current entry
+func (it *DictionaryEntryIterator) Entry() DictionaryEntry {
+	return it.page[it.index]
+}
+
+// Total returns the number of entries of the dictionary reported by the last page
+func (it *DictionaryEntryIterator) Total() int {
+	return it.total
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *DictionaryEntryIterator) Err() error {
+	return it.err
+}
+
+// AllDictionaryEntries returns every entry of a dictionary, requesting as many pages as needed
+func (c *Client) AllDictionaryEntries(dictID string) ([]DictionaryEntry, error) {
+	return c.AllDictionaryEntriesContext(context.Background(), dictID)
+}
+
+// AllDictionaryEntriesContext is like AllDictionaryEntries with a context
+func (c *Client) AllDictionaryEntriesContext(ctx context.Context, dictID string, opts ...CallOption) ([]DictionaryEntry, error) {
+	var entries []DictionaryEntry
+	it := c.DictionaryEntriesIteratorContext(ctx, dictID, opts...)
+	for it.Next() {
+		entries = append(entries, it.Entry())
+	}
+	return entries, it.Err()
+}
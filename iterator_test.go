@@ -0,0 +1,97 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//***************************************************************
+// 			Dictionary entries iterator tests
+
+// pagingTransport serves the pages of a dictionary of total entries
+type pagingTransport struct {
+	total int
+
+	mu      sync.Mutex
+	offsets []int
+}
+
+func (t *pagingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Body.Close()
+	values := req.URL.Query()
+	limit, _ := strconv.Atoi(values.Get("limit"))
+	offset, _ := strconv.Atoi(values.Get("offset"))
+
+	t.mu.Lock()
+	t.offsets = append(t.offsets, offset)
+	t.mu.Unlock()
+
+	list := DictionaryEntryList{Offset: offset, Limit: limit, Total: t.total, Entries: []DictionaryEntry{}}
+	for i := offset; i < offset+limit && i < t.total; i++ {
+		list.Entries = append(list.Entries, DictionaryEntry{ID: fmt.Sprint("DEV", i), Text: fmt.Sprint("entry ", i)})
+	}
+	b, _ := json.Marshal(struct {
+		Ok       bool                `json:"ok"`
+		Response DictionaryEntryList `json:"response"`
+	}{true, list})
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(string(b))), Request: req}, nil
+}
+
+func TestDictionaryEntriesIterator(t *testing.T) {
+	var tests = []struct {
+		total, pageSize int
+		expectOffsets   []int
+	}{
+		{0, 10, []int{0}},
+		{5, 10, []int{0}},
+		{10, 10, []int{0}},
+		{25, 10, []int{0, 10, 20}},
+		{250, 0, []int{0, 100, 200}},
+	}
+
+	for _, tt := range tests {
+		transport := &pagingTransport{total: tt.total}
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+		it := client.DictionaryEntriesIterator(dictID)
+		it.PageSize = tt.pageSize
+
+		n := 0
+		for it.Next() {
+			if e := it.Entry(); e.ID != fmt.Sprint("DEV", n) {
+				t.Error("expect entry", n, "got", e.ID)
+			}
+			n++
+		}
+		if it.Err() != nil {
+			t.Error(it.Err())
+		}
+		if n != tt.total || it.Total() != tt.total {
+			t.Error("expect", tt.total, "entries, got", n, it.Total())
+		}
+		if fmt.Sprint(transport.offsets) != fmt.Sprint(tt.expectOffsets) {
+			t.Error("expect requested offsets", tt.expectOffsets, "got", transport.offsets)
+		}
+	}
+}
+
+func TestAllDictionaryEntries(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &pagingTransport{total: 150})
+	entries, err := client.AllDictionaryEntries(dictID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 150 || entries[149].ID != "DEV149" {
+		t.Error("expect 150 entries, got", len(entries))
+	}
+
+	client = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, http.StatusInternalServerError, `{"ok":false}`, false))
+	if _, err := client.AllDictionaryEntries(dictID); err == nil {
+		t.Error("expect an error")
+	}
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// checkpoint records the IDs of the completed documents of a job in a file, one per line.
+// New IDs are appended, so a crash loses at most the IDs not flushed yet.
+type checkpoint struct {
+	path    string
+	done    map[string]bool
+	pending []string
+}
+
+// loadCheckpoint reads the IDs recorded in the file at path, which doesn't have to exist.
+// A last line without newline, cut by a crash, is ignored.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	c := &checkpoint{path: path, done: map[string]bool{}}
+	if path == "" {
+		return c, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint reading failed: %v", err)
+	}
+	lines := strings.Split(string(b), "\n")
+	// the last item follows the last newline, it's empty unless the line was cut
+	for _, id := range lines[:len(lines)-1] {
+		if id != "" {
+			c.done[id] = true
+		}
+	}
+	if cut := lines[len(lines)-1]; cut != "" {
+		// the next IDs would be appended to the cut line
+		if err := os.Truncate(path, int64(len(b)-len(cut))); err != nil {
+			return nil, fmt.Errorf("checkpoint repair failed: %v", err)
+		}
+	}
+	return c, nil
+}
+
+// add records a completed document, it's written by the next flush
+func (c *checkpoint) add(id string) {
+	if c.done[id] {
+		return
+	}
+	c.done[id] = true
+	c.pending = append(c.pending, id)
+}
+
+// flush appends the pending IDs to the file and syncs it
+func (c *checkpoint) flush() error {
+	if c.path == "" || len(c.pending) == 0 {
+		return nil
+	}
+	f, err := os.OpenFile(c.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("checkpoint writing failed: %v", err)
+	}
+	w := bufio.NewWriter(f)
+	for _, id := range c.pending {
+		w.WriteString(id)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("checkpoint writing failed: %v", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("checkpoint writing failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("checkpoint writing failed: %v", err)
+	}
+	c.pending = c.pending[:0]
+	return nil
+}
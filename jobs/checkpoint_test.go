@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//***************************************************************
+// 			Checkpoint tests
+
+func TestCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+
+	cp, err := loadCheckpoint(path)
+	if err != nil || len(cp.done) != 0 {
+		t.Fatal("expect an empty checkpoint without file, got", cp, err)
+	}
+	cp.add("a")
+	cp.add("b")
+	cp.add("a")
+	if err := cp.flush(); err != nil {
+		t.Fatal(err)
+	}
+	cp.add("c")
+	if err := cp.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "a\nb\nc\n" {
+		t.Errorf("expect the IDs appended once, got %q", b)
+	}
+
+	// a crash cut the last line
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	f.WriteString("d")
+	f.Close()
+	cp, err = loadCheckpoint(path)
+	if err != nil || len(cp.done) != 3 || cp.done["d"] {
+		t.Fatal("expect the cut line to be ignored, got", cp.done, err)
+	}
+	cp.add("e")
+	if err := cp.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "a\nb\nc\ne\n" {
+		t.Errorf("expect the cut line to be removed, got %q", b)
+	}
+
+	if _, err := loadCheckpoint(t.TempDir()); err == nil {
+		t.Error("expect an error reading a directory")
+	}
+}
@@ -0,0 +1,205 @@
+// Package jobs runs the analysis of a corpus as a resumable job: the IDs of the completed documents are checkpointed
+// to a file, so a job stopped by a crash, a cancellation or the daily quota resumes where it stopped, without
+// analyzing (and paying for) the completed documents again.
+//
+//	job := &jobs.Job{
+//		Client:     client,
+//		Source:     jobs.SliceSource(docs),
+//		Params:     textrazor.Params{"extractors": {"entities"}},
+//		Checkpoint: "corpus.checkpoint",
+//		Handle:     func(r textrazor.BatchResult) error { return store(r.Document.ID, r.Analysis) },
+//	}
+//	progress, err := job.Run(ctx)
+//
+// A document is completed once Handle returns, the analyses must be stored by Handle.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// DefaultCheckpointInterval is the time between 2 writes of the checkpoint file
+const DefaultCheckpointInterval = 10 * time.Second
+
+// Source yields the documents of a job, Next returns io.EOF after the last document.
+// The documents must have a unique ID, and the same IDs every time the job runs.
+type Source interface {
+	Next(ctx context.Context) (textrazor.Document, error)
+}
+
+// SourceFunc is a function implementing Source
+type SourceFunc func(ctx context.Context) (textrazor.Document, error)
+
+// Next implements Source
+func (f SourceFunc) Next(ctx context.Context) (textrazor.Document, error) { return f(ctx) }
+
+// SliceSource returns a Source of the documents of a slice
+func SliceSource(docs []textrazor.Document) Source {
+	i := 0
+	return SourceFunc(func(context.Context) (textrazor.Document, error) {
+		if i == len(docs) {
+			return textrazor.Document{}, io.EOF
+		}
+		i++
+		return docs[i-1], nil
+	})
+}
+
+// Job is the analysis of the documents of a source with the same params
+type Job struct {
+	Client *textrazor.Client
+	Source Source
+	Params textrazor.Params
+
+	// Checkpoint is the file recording the IDs of the completed documents, they are skipped by the next runs.
+	// Nothing is recorded if empty.
+	Checkpoint string
+	// CheckpointInterval is the time between 2 writes of the checkpoint, DefaultCheckpointInterval if 0.
+	// The checkpoint is written too when the job stops.
+	CheckpointInterval time.Duration
+	// Concurrency is the number of concurrent analyses, textrazor.DefaultManyConcurrency if 0
+	Concurrency int
+
+	// Handle is called with the analysis of each document, from a single goroutine, it is required.
+	// Its error stops the job, the document isn't completed.
+	Handle func(textrazor.BatchResult) error
+	// Progress, if set, is called after each document, from the goroutine calling Handle
+	Progress func(Progress)
+}
+
+// Progress reports the progress of a job
+type Progress struct {
+	// Completed counts the documents analyzed and handled by this run, Skipped the documents completed by previous runs
+	Completed int
+	Skipped   int
+	// Failed counts the documents whose analysis failed, they are analyzed again by the next run
+	Failed int
+}
+
+// Run analyzes the documents of the source which aren't completed yet, and returns the progress of the run.
+//
+// A failed analysis doesn't stop the job, the error then reports the failed documents. The job stops on the first
+// error of the source or of Handle, once the daily quota is exceeded, or when ctx is done: the analyses in flight
+// are canceled, the completed documents are checkpointed, and the job can be run again later to resume it.
+func (j *Job) Run(ctx context.Context) (*Progress, error) {
+	if j.Handle == nil {
+		return nil, errors.New("job without Handle, the analyses would be lost")
+	}
+	cp, err := loadCheckpoint(j.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	interval := j.CheckpointInterval
+	if interval <= 0 {
+		interval = DefaultCheckpointInterval
+	}
+	var opts []textrazor.CallOption
+	if j.Concurrency > 0 {
+		opts = append(opts, textrazor.CallConcurrency(j.Concurrency))
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	progress := &Progress{}
+	in := make(chan textrazor.Document)
+	// the feeder skips the documents completed by the previous runs, cp.done is updated by this goroutine
+	done := make(map[string]bool, len(cp.done))
+	for id := range cp.done {
+		done[id] = true
+	}
+	skipped := make(chan int, 1)
+	go func() {
+		defer close(in)
+		n, err := j.feed(ctx, done, in)
+		if err != nil {
+			cancel(err)
+		}
+		skipped <- n
+	}()
+
+	var (
+		firstFailure error
+		stopped      bool
+		lastFlush    = time.Now()
+	)
+	for r := range j.Client.AnalyzeStream(ctx, in, j.Params, opts...) {
+		switch {
+		case stopped:
+			// the job is stopping, the analyses in flight are dropped
+			continue
+		case r.Err != nil:
+			progress.Failed++
+			if firstFailure == nil {
+				firstFailure = fmt.Errorf("document '%s': %w", r.Document.ID, r.Err)
+			}
+			if errors.Is(r.Err, textrazor.ErrQuotaExceeded) {
+				stopped = true
+				cancel(r.Err)
+			}
+		default:
+			if err := j.Handle(r); err != nil {
+				stopped = true
+				cancel(fmt.Errorf("document '%s' handling failed: %w", r.Document.ID, err))
+				continue
+			}
+			cp.add(r.Document.ID)
+			progress.Completed++
+		}
+		if j.Progress != nil {
+			j.Progress(*progress)
+		}
+		if time.Since(lastFlush) >= interval {
+			if err := cp.flush(); err != nil {
+				stopped = true
+				cancel(err)
+			}
+			lastFlush = time.Now()
+		}
+	}
+	progress.Skipped = <-skipped
+
+	if err := cp.flush(); err != nil {
+		return progress, err
+	}
+	// the cause is the error stopping the job, or the error of the parent context
+	if err := context.Cause(ctx); err != nil {
+		return progress, err
+	}
+	if firstFailure != nil {
+		return progress, fmt.Errorf("%d documents failed, run the job again to retry them, first: %w", progress.Failed, firstFailure)
+	}
+	return progress, nil
+}
+
+// feed sends the documents of the source which aren't done to in, and returns the number of skipped documents
+func (j *Job) feed(ctx context.Context, done map[string]bool, in chan<- textrazor.Document) (int, error) {
+	skipped := 0
+	for {
+		d, err := j.Source.Next(ctx)
+		if err == io.EOF {
+			return skipped, nil
+		}
+		if err != nil {
+			return skipped, fmt.Errorf("document source failed: %w", err)
+		}
+		if d.ID == "" || strings.ContainsAny(d.ID, "\r\n") {
+			return skipped, fmt.Errorf("invalid document id %q, the documents need a single line id", d.ID)
+		}
+		if done[d.ID] {
+			skipped++
+			continue
+		}
+		select {
+		case in <- d:
+		case <-ctx.Done():
+			return skipped, nil
+		}
+	}
+}
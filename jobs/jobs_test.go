@@ -0,0 +1,216 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bengentil/textrazor-go"
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Job tests
+
+// analysisServer replies with an analysis, except for the texts mapped to an error status
+type analysisServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	failing  map[string]int
+	analyzed []string
+}
+
+func newAnalysisServer(failing map[string]int) *analysisServer {
+	s := &analysisServer{failing: failing}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		text := r.FormValue("text")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.analyzed = append(s.analyzed, text)
+		if status := s.failing[text]; status != 0 {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"ok": false, "error": "failed %s"}`, text)
+			return
+		}
+		w.Write([]byte(textrazortest.AnalysisEntities))
+	}))
+	return s
+}
+
+func (s *analysisServer) client() *textrazor.Client {
+	return textrazor.NewCustomClient("key", false, false, s.URL, s.URL, http.DefaultTransport)
+}
+
+func corpus(texts ...string) []textrazor.Document {
+	docs := make([]textrazor.Document, len(texts))
+	for i, text := range texts {
+		docs[i] = textrazor.Document{ID: "doc-" + text, Text: text}
+	}
+	return docs
+}
+
+// handled records the IDs of the handled documents
+type handled struct {
+	ids []string
+}
+
+func (h *handled) handle(r textrazor.BatchResult) error {
+	if r.Analysis == nil {
+		return errors.New("no analysis")
+	}
+	h.ids = append(h.ids, r.Document.ID)
+	return nil
+}
+
+func readCheckpoint(t *testing.T, path string) []string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := strings.Fields(string(b))
+	sort.Strings(ids)
+	return ids
+}
+
+func TestJobResume(t *testing.T) {
+	server := newAnalysisServer(map[string]int{"c": http.StatusBadRequest})
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	docs := corpus("a", "b", "c", "d")
+
+	var h handled
+	var reported []Progress
+	job := &Job{Client: server.client(), Source: SliceSource(docs), Params: textrazor.Params{"extractors": {"entities"}},
+		Checkpoint: path, Concurrency: 2, Handle: h.handle, Progress: func(p Progress) { reported = append(reported, p) }}
+	progress, err := job.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "1 documents failed, run the job again to retry them, first: document 'doc-c': ") {
+		t.Error("expect the failure of doc-c, got", err)
+	}
+	if *progress != (Progress{Completed: 3, Failed: 1}) || len(reported) != 4 || reported[3] != *progress {
+		t.Errorf("expect 3 completed and 1 failed documents, got %+v, reported %+v", progress, reported)
+	}
+	if ids := readCheckpoint(t, path); !reflect.DeepEqual(ids, []string{"doc-a", "doc-b", "doc-d"}) {
+		t.Error("expect the completed documents checkpointed, got", ids)
+	}
+
+	// the next run only analyzes the failed document
+	server.mu.Lock()
+	server.failing, server.analyzed = nil, nil
+	server.mu.Unlock()
+	h.ids = nil
+	job.Source = SliceSource(docs)
+	progress, err = job.Run(context.Background())
+	if err != nil || *progress != (Progress{Completed: 1, Skipped: 3}) {
+		t.Errorf("expect 1 completed and 3 skipped documents, got %+v %v", progress, err)
+	}
+	if !reflect.DeepEqual(server.analyzed, []string{"c"}) || !reflect.DeepEqual(h.ids, []string{"doc-c"}) {
+		t.Error("expect only doc-c to be analyzed again, got", server.analyzed, h.ids)
+	}
+	if ids := readCheckpoint(t, path); len(ids) != 4 {
+		t.Error("expect every document checkpointed, got", ids)
+	}
+}
+
+func TestJobStop(t *testing.T) {
+	server := newAnalysisServer(map[string]int{"c": http.StatusPaymentRequired})
+	defer server.Close()
+	docs := corpus("a", "b", "c", "d", "e")
+
+	var tests = []struct {
+		name    string
+		handle  func(textrazor.BatchResult) error
+		err     error
+		expect  Progress
+		handled []string
+	}{
+		{"quota", nil, textrazor.ErrQuotaExceeded, Progress{Completed: 2, Failed: 1}, []string{"doc-a", "doc-b"}},
+		{"handle", func(r textrazor.BatchResult) error {
+			if r.Document.ID == "doc-b" {
+				return errors.New("storage unavailable")
+			}
+			return nil
+		}, nil, Progress{Completed: 1}, []string{"doc-a"}},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "checkpoint")
+		var h handled
+		handle := func(r textrazor.BatchResult) error {
+			if tt.handle != nil {
+				if err := tt.handle(r); err != nil {
+					return err
+				}
+			}
+			return h.handle(r)
+		}
+		job := &Job{Client: server.client(), Source: SliceSource(docs), Params: textrazor.Params{"extractors": {"entities"}},
+			Checkpoint: path, Concurrency: 1, Handle: handle}
+		progress, err := job.Run(context.Background())
+		if err == nil || tt.err != nil && !errors.Is(err, tt.err) {
+			t.Errorf("%s: expect the job to stop with %v, got %v", tt.name, tt.err, err)
+		}
+		if progress.Completed != tt.expect.Completed || progress.Failed != tt.expect.Failed {
+			t.Errorf("%s: expect %+v, got %+v", tt.name, tt.expect, progress)
+		}
+		if !reflect.DeepEqual(h.ids, tt.handled) || !reflect.DeepEqual(readCheckpoint(t, path), tt.handled) {
+			t.Errorf("%s: expect %v handled and checkpointed, got %v %v", tt.name, tt.handled, h.ids, readCheckpoint(t, path))
+		}
+	}
+}
+
+func TestJobSource(t *testing.T) {
+	server := newAnalysisServer(nil)
+	defer server.Close()
+
+	var tests = []struct {
+		docs []textrazor.Document
+		err  string
+	}{
+		{[]textrazor.Document{{Text: "a"}}, `invalid document id ""`},
+		{[]textrazor.Document{{ID: "a\nb", Text: "a"}}, `invalid document id "a\nb"`},
+	}
+	for _, tt := range tests {
+		job := &Job{Client: server.client(), Source: SliceSource(tt.docs), Params: textrazor.Params{"extractors": {"entities"}},
+			Handle: func(textrazor.BatchResult) error { return nil }}
+		if _, err := job.Run(context.Background()); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("expect the error %q, got %v", tt.err, err)
+		}
+	}
+
+	failing := SourceFunc(func(context.Context) (textrazor.Document, error) {
+		return textrazor.Document{}, errors.New("disk error")
+	})
+	job := &Job{Client: server.client(), Source: failing, Handle: func(textrazor.BatchResult) error { return nil }}
+	if _, err := job.Run(context.Background()); err == nil || err.Error() != "document source failed: disk error" {
+		t.Error("expect the source error, got", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	job = &Job{Client: server.client(), Source: SliceSource(corpus("a")), Handle: func(textrazor.BatchResult) error { return nil }}
+	if _, err := job.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Error("expect the context error, got", err)
+	}
+}
+
+func TestJobWithoutHandle(t *testing.T) {
+	server := newAnalysisServer(nil)
+	defer server.Close()
+
+	job := &Job{Client: server.client(), Source: SliceSource(corpus("a")), Params: textrazor.Params{"extractors": {"entities"}}}
+	if _, err := job.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "job without Handle") {
+		t.Error("expect the missing Handle to be reported, got", err)
+	}
+	if len(server.analyzed) != 0 {
+		t.Error("expect no analysis without Handle, got", server.analyzed)
+	}
+}
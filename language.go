@@ -0,0 +1,57 @@
+package textrazor
+
+// languageNames maps the ISO 639-2 codes of the languages supported by the API to their English name,
+// with both the bibliographic (B) and terminologic (T) codes when they differ
+var languageNames = map[string]string{
+	"ara": "Arabic",
+	"bul": "Bulgarian",
+	"cat": "Catalan",
+	"chi": "Chinese", "zho": "Chinese",
+	"cze": "Czech", "ces": "Czech",
+	"dan": "Danish",
+	"dut": "Dutch", "nld": "Dutch",
+	"eng": "English",
+	"est": "Estonian",
+	"fin": "Finnish",
+	"fre": "French", "fra": "French",
+	"ger": "German", "deu": "German",
+	"gre": "Greek", "ell": "Greek",
+	"heb": "Hebrew",
+	"hin": "Hindi",
+	"hun": "Hungarian",
+	"ind": "Indonesian",
+	"ita": "Italian",
+	"jpn": "Japanese",
+	"kor": "Korean",
+	"lav": "Latvian",
+	"lit": "Lithuanian",
+	"may": "Malay", "msa": "Malay",
+	"nor": "Norwegian",
+	"per": "Persian", "fas": "Persian",
+	"pol": "Polish",
+	"por": "Portuguese",
+	"rum": "Romanian", "ron": "Romanian",
+	"rus": "Russian",
+	"slo": "Slovak", "slk": "Slovak",
+	"slv": "Slovenian",
+	"spa": "Spanish",
+	"swe": "Swedish",
+	"tha": "Thai",
+	"tur": "Turkish",
+	"ukr": "Ukrainian",
+	"vie": "Vietnamese",
+}
+
+// LanguageName returns the English name of a language from its ISO 639-2 code, e.g. "eng",
+// or the code itself when it is unknown
+func LanguageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// LanguageName returns the English name of the language of the analysis, see LanguageName
+func (a *Analysis) LanguageName() string {
+	return LanguageName(a.Language)
+}
@@ -0,0 +1,45 @@
+package textrazor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Language tests
+
+func TestLanguageName(t *testing.T) {
+	var tests = []struct {
+		code   string
+		expect string
+	}{
+		{"eng", "English"},
+		{"fre", "French"},
+		{"fra", "French"},
+		{"ger", "German"},
+		{"deu", "German"},
+		{"xyz", "xyz"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := LanguageName(tt.code); got != tt.expect {
+			t.Error(tt.code, "expect", tt.expect, "got", got)
+		}
+	}
+}
+
+func TestAnalysisLanguage(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities))
+	a, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Language != "eng" || !a.LanguageIsReliable || a.LanguageName() != "English" {
+		t.Error("expect a reliable English analysis, got", a.Language, a.LanguageIsReliable, a.LanguageName())
+	}
+	if v := a.View(); v.Language() != "eng" || !v.LanguageIsReliable() {
+		t.Error("expect the view to expose the language, got", v.Language(), v.LanguageIsReliable())
+	}
+}
@@ -0,0 +1,65 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+)
+
+// LanguageOverrideParam is the analysis parameter forcing the language of the text, an ISO 639-2 code
+const LanguageOverrideParam = "languageOverride"
+
+// LanguageMismatchPolicy defines what an analysis does when the API reliably detects a language
+// other than the languageOverride parameter, as the extraction is then done with the wrong language
+type LanguageMismatchPolicy int
+
+// Valid LanguageMismatchPolicy values
+const (
+	// LanguageMismatchIgnore returns the analysis, the default
+	LanguageMismatchIgnore LanguageMismatchPolicy = iota
+	// LanguageMismatchWarn returns the analysis and logs the mismatch, see WithLogger
+	LanguageMismatchWarn
+	// LanguageMismatchFail returns a *LanguageMismatchError holding the analysis
+	LanguageMismatchFail
+	// LanguageMismatchRetry analyzes the text again without the languageOverride parameter,
+	// which uses another request of the daily quota
+	LanguageMismatchRetry
+)
+
+// WithLanguageMismatchPolicy sets what analyses do when the detected language isn't the languageOverride parameter
+func WithLanguageMismatchPolicy(p LanguageMismatchPolicy) Option {
+	return func(c *Client) {
+		c.languagePolicy = p
+	}
+}
+
+// LanguageMismatchError is returned by analyses with the LanguageMismatchFail policy
+type LanguageMismatchError struct {
+	// Override is the languageOverride parameter, Detected the language reported by the API
+	Override string
+	Detected string
+	Analysis *Analysis
+}
+
+func (e *LanguageMismatchError) Error() string {
+	return fmt.Sprintf("language override '%s' but '%s' detected", e.Override, e.Detected)
+}
+
+// checkLanguage applies the language mismatch policy of the client to an analysis
+func (c *Client) checkLanguage(ctx context.Context, timer *callTimer, params Params, a *Analysis, opts ...CallOption) (*Analysis, error) {
+	override := params.Get(LanguageOverrideParam)
+	if c.languagePolicy == LanguageMismatchIgnore || override == "" || !a.LanguageIsReliable || a.Language == "" || a.Language == override {
+		return a, nil
+	}
+
+	switch c.languagePolicy {
+	case LanguageMismatchWarn:
+		c.logf(ctx, "language override '%s' but '%s' detected", override, a.Language)
+	case LanguageMismatchFail:
+		return nil, &LanguageMismatchError{Override: override, Detected: a.Language, Analysis: a}
+	case LanguageMismatchRetry:
+		p := copyParams(params)
+		p.Del(LanguageOverrideParam)
+		return c.analyze(ctx, timer, p, opts...)
+	}
+	return a, nil
+}
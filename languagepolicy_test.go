@@ -0,0 +1,79 @@
+package textrazor
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Language mismatch policy tests
+
+func TestLanguageMismatchPolicy(t *testing.T) {
+	var tests = []struct {
+		name      string
+		policy    LanguageMismatchPolicy
+		override  string
+		requests  int
+		expectErr bool
+		expectLog bool
+	}{
+		{"ignore", LanguageMismatchIgnore, "fre", 1, false, false},
+		{"warn", LanguageMismatchWarn, "fre", 1, false, true},
+		{"fail", LanguageMismatchFail, "fre", 1, true, false},
+		{"retry", LanguageMismatchRetry, "fre", 2, false, false},
+		{"same language", LanguageMismatchFail, "eng", 1, false, false},
+		{"no override", LanguageMismatchFail, "", 1, false, false},
+	}
+
+	for _, tt := range tests {
+		var logs bytes.Buffer
+		transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+			WithLanguageMismatchPolicy(tt.policy), WithLogger(log.New(&logs, "", 0)))
+
+		params := Params{"extractors": {"entities"}}
+		if tt.override != "" {
+			params.Set(LanguageOverrideParam, tt.override)
+		}
+		a, err := client.AnalyzeText(testText, params)
+
+		var mismatch *LanguageMismatchError
+		if tt.expectErr {
+			if !errors.As(err, &mismatch) || mismatch.Override != "fre" || mismatch.Detected != "eng" || mismatch.Analysis == nil {
+				t.Error(tt.name, "expect a LanguageMismatchError, got", err)
+			}
+		} else if err != nil || a.Language != "eng" {
+			t.Error(tt.name, "expect an English analysis, got", err)
+		}
+		if n := len(transport.Requests()); n != tt.requests {
+			t.Error(tt.name, "expect", tt.requests, "requests, got", n)
+		}
+		if got := logs.Len() > 0; got != tt.expectLog {
+			t.Error(tt.name, "unexpected logs:", logs.String())
+		}
+	}
+}
+
+func TestLanguageMismatchRetryParams(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithLanguageMismatchPolicy(LanguageMismatchRetry), WithDefaultParams(Params{LanguageOverrideParam: {"ger"}}))
+	if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := transport.Requests()
+	if len(requests) != 2 {
+		t.Fatal("expect a retry, got", len(requests), "requests")
+	}
+	for i, expect := range []string{"ger", ""} {
+		if got := sentForm(t, transport, i).Get(LanguageOverrideParam); got != expect {
+			t.Errorf("request %d: expect languageOverride '%s', got '%s'", i, expect, got)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package textrazor
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListOptions defines the page requested from a paginated endpoint
+type ListOptions struct {
+	// Limit is the maximum number of items returned, 0 means DefaultPageSize
+	Limit int
+	// Offset is the index of the first item returned
+	Offset int
+}
+
+// Validate returns an error if the limit or the offset is negative
+func (o ListOptions) Validate() error {
+	if o.Limit < 0 || o.Offset < 0 {
+		return fmt.Errorf("invalid list options: negative limit %d or offset %d", o.Limit, o.Offset)
+	}
+	return nil
+}
+
+// Encode returns the query string of the options, with the default limit if not set
+func (o ListOptions) Encode() string {
+	limit := o.Limit
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	return url.Values{"limit": {strconv.Itoa(limit)}, "offset": {strconv.Itoa(o.Offset)}}.Encode()
+}
@@ -0,0 +1,49 @@
+package textrazor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			ListOptions tests
+
+func TestListOptions(t *testing.T) {
+	var tests = []struct {
+		options     ListOptions
+		expectQuery string
+		shouldFail  bool
+	}{
+		{ListOptions{}, "limit=100&offset=0", false},
+		{ListOptions{Limit: 20, Offset: 40}, "limit=20&offset=40", false},
+		{ListOptions{Limit: 1000}, "limit=1000&offset=0", false},
+		{ListOptions{Limit: -1}, "", true},
+		{ListOptions{Offset: -20}, "", true},
+	}
+
+	for _, tt := range tests {
+		transport := textrazortest.NewSequenceTransport(
+			textrazortest.Reply{Status: http.StatusOK, Body: `{"ok":true,"response":{"entries":[]}}`},
+		)
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+		_, err := client.ListDictionaryEntries(dictID, tt.options)
+		_, err2 := client.ListClassifierCategories("cls", tt.options)
+		if (err != nil) != tt.shouldFail || (err2 != nil) != tt.shouldFail {
+			t.Error(tt.options, "expect failure", tt.shouldFail, "got", err, err2)
+		}
+		if tt.shouldFail {
+			if len(transport.Requests()) != 0 {
+				t.Error(tt.options, "expect no request")
+			}
+			continue
+		}
+		for _, req := range transport.Requests() {
+			if req.URL.RawQuery != tt.expectQuery || req.ContentLength != 0 {
+				t.Error(tt.options, "expect query", tt.expectQuery, "without body, got", req.URL.RawQuery, req.ContentLength)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultManyConcurrency is the number of concurrent analyses of AnalyzeMany
+const DefaultManyConcurrency = 4
+
+// CallConcurrency sets the number of concurrent analyses of AnalyzeMany,
+// the concurrency limit of the client, if any, still applies
+func CallConcurrency(n int) CallOption {
+	return func(o *callOptions) {
+		o.concurrency = n
+	}
+}
+
+// AnalyzeManyError is returned by AnalyzeMany when some analyses failed
+type AnalyzeManyError struct {
+	// Errors maps the index of the failed texts to their error
+	Errors map[int]error
+}
+
+func (e *AnalyzeManyError) Error() string {
+	indexes := e.indexes()
+	msgs := make([]string, len(indexes))
+	for i, index := range indexes {
+		msgs[i] = fmt.Sprintf("text %d: %v", index, e.Errors[index])
+	}
+	return fmt.Sprintf("%d analyses failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed analyses, so errors.Is and errors.As match any of them
+func (e *AnalyzeManyError) Unwrap() []error {
+	var errs []error
+	for _, i := range e.indexes() {
+		errs = append(errs, e.Errors[i])
+	}
+	return errs
+}
+
+func (e *AnalyzeManyError) indexes() []int {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// AnalyzeMany analyzes texts concurrently with the same params, and returns their analyses in the order of texts.
+//
+// A failed analysis doesn't stop the others: its analysis is nil, and the error is an *AnalyzeManyError.
+func (c *Client) AnalyzeMany(texts []string, params Params) ([]*Analysis, error) {
+	return c.AnalyzeManyContext(context.Background(), texts, params)
+}
+
+// AnalyzeManyContext is like AnalyzeMany with a context, see CallConcurrency
+func (c *Client) AnalyzeManyContext(ctx context.Context, texts []string, params Params, opts ...CallOption) ([]*Analysis, error) {
+	analyses, errs := c.analyzeAll(ctx, len(texts), func(i int) (*Analysis, error) {
+		return c.AnalyzeTextContext(ctx, texts[i], copyParams(params), opts...)
+	}, opts...)
+
+	failed := map[int]error{}
+	for i, err := range errs {
+		if err != nil {
+			failed[i] = err
+		}
+	}
+	if len(failed) > 0 {
+		return analyses, &AnalyzeManyError{Errors: failed}
+	}
+	return analyses, nil
+}
+
+// analyzeAll runs n analyses on a pool of CallConcurrency workers, and returns their analyses and errors by index.
+// An error doesn't stop the other analyses, the analyses not started when ctx is done fail with its error.
+func (c *Client) analyzeAll(ctx context.Context, n int, analyze func(i int) (*Analysis, error), opts ...CallOption) ([]*Analysis, []error) {
+	workers := newCallOptions(opts).concurrency
+	if workers <= 0 {
+		workers = DefaultManyConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+
+	var (
+		analyses = make([]*Analysis, n)
+		errs     = make([]error, n)
+	)
+	g, _ := newGroup(ctx, workers, &c.stats.goroutines)
+	for i := 0; i < n; i++ {
+		i := i
+		g.Go(func() error {
+			if errs[i] = ctx.Err(); errs[i] == nil {
+				analyses[i], errs[i] = analyze(i)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+	return analyses, errs
+}
@@ -0,0 +1,87 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+//***************************************************************
+// 			AnalyzeMany tests
+
+// lockedTransport serializes the requests sent to a transport which isn't safe for concurrent use
+type lockedTransport struct {
+	mu sync.Mutex
+	rt http.RoundTripper
+}
+
+func (t *lockedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rt.RoundTrip(req)
+}
+
+func TestAnalyzeMany(t *testing.T) {
+	transport := &classifyingTransport{categories: map[string][]ScoredCategory{
+		"football": {{CategoryID: "1", Label: "football"}},
+		"rugby":    {{CategoryID: "2", Label: "rugby"}},
+		"tennis":   {{CategoryID: "3", Label: "tennis"}},
+	}}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &lockedTransport{rt: transport})
+
+	texts := []string{"rugby", "cinema", "football", "tennis", "football"}
+	analyses, err := client.AnalyzeManyContext(context.Background(), texts, Params{"extractors": {"entities"}}, CallConcurrency(2))
+
+	var manyErr *AnalyzeManyError
+	if !errors.As(err, &manyErr) || len(manyErr.Errors) != 1 || manyErr.Errors[1] == nil {
+		t.Fatal("expect the analysis of cinema to fail, got", err)
+	}
+	if !strings.HasPrefix(err.Error(), "1 analyses failed: text 1: ") {
+		t.Error("unexpected error message", err)
+	}
+	for i, text := range texts {
+		if i == 1 {
+			if analyses[i] != nil {
+				t.Error("expect no analysis for a failed text, got", analyses[i])
+			}
+			continue
+		}
+		if analyses[i] == nil || len(analyses[i].Categories) != 1 || analyses[i].Categories[0].Label != text {
+			t.Error("expect the analysis of", text, "at index", i, "got", analyses[i])
+		}
+	}
+}
+
+func TestAnalyzeManyConcurrency(t *testing.T) {
+	transport := &concurrencyTransport{}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	texts := make([]string, 12)
+	for i := range texts {
+		texts[i] = testText
+	}
+	analyses, err := client.AnalyzeManyContext(context.Background(), texts, Params{"extractors": {"entities"}}, CallConcurrency(3))
+	if err != nil || len(analyses) != len(texts) {
+		t.Fatal(err)
+	}
+	if max := atomic.LoadInt32(&transport.max); max != 3 {
+		t.Error("expect 3 concurrent analyses, got", max)
+	}
+}
+
+func TestAnalyzeManyCanceled(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, 200, "", true))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	analyses, err := client.AnalyzeManyContext(ctx, []string{"a", "b"}, Params{"extractors": {"entities"}})
+	if !errors.Is(err, context.Canceled) || len(analyses) != 2 {
+		t.Error("expect every analysis to fail with the context, got", err)
+	}
+	if analyses, err := client.AnalyzeMany(nil, Params{"extractors": {"entities"}}); err != nil || len(analyses) != 0 {
+		t.Error("expect no analyses, got", analyses, err)
+	}
+}
@@ -0,0 +1,38 @@
+package textrazor
+
+// Mention returns the text matched by the entity in raw, the text of the analysis (CleanedText when it is returned),
+// using its offsets, e.g. to highlight the entity. It returns "" if the offsets are out of raw.
+//
+// The offsets count unicode code points, not bytes.
+func (e *Entity) Mention(raw string) string {
+	s, _ := codePointSlice(raw, e.StartingPos, e.EndingPos)
+	return s
+}
+
+// codePointSlice returns the substring of s between the start and end code points
+func codePointSlice(s string, start, end int) (string, bool) {
+	if start < 0 || end < start {
+		return "", false
+	}
+	from, to, n := -1, -1, 0
+	for i := range s {
+		if n == start {
+			from = i
+		}
+		if n == end {
+			to = i
+			break
+		}
+		n++
+	}
+	if to < 0 {
+		if n != end {
+			return "", false
+		}
+		to = len(s)
+	}
+	if from < 0 {
+		from = to
+	}
+	return s[from:to], true
+}
@@ -0,0 +1,48 @@
+package textrazor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Mention tests
+
+func TestEntityMention(t *testing.T) {
+	var tests = []struct {
+		raw        string
+		start, end int
+		expect     string
+	}{
+		{"Barclays misled shareholders", 0, 8, "Barclays"},
+		{"Barclays misled shareholders", 16, 28, "shareholders"},
+		{"Société Générale a été condamnée", 8, 16, "Générale"},
+		{"東京の銀行", 0, 2, "東京"},
+		{"東京の銀行", 3, 5, "銀行"},
+		{"Barclays", 8, 8, ""},
+		{"Barclays", 4, 12, ""},
+		{"Barclays", -1, 3, ""},
+		{"Barclays", 5, 3, ""},
+	}
+	for _, tt := range tests {
+		e := Entity{StartingPos: tt.start, EndingPos: tt.end}
+		if got := e.Mention(tt.raw); got != tt.expect {
+			t.Errorf("%s [%d:%d]: expect '%s', got '%s'", tt.raw, tt.start, tt.end, tt.expect, got)
+		}
+	}
+}
+
+func TestEntityMentionFixture(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities))
+	a, err := client.AnalyzeText(textrazortest.Text, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range a.Entities {
+		if got := e.Mention(textrazortest.Text); got != e.MatchedText {
+			t.Errorf("expect the mention of %s to be '%s', got '%s'", e.EntityID, e.MatchedText, got)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package textrazor
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MergeSeparator is the text assumed between the parts merged by MergeAnalyses
+const MergeSeparator = "\n"
+
+// MergeAnalyses merges the analyses of consecutive parts of a document, e.g. a title and a body,
+// into the analysis of the parts joined with MergeSeparator. Nil parts are skipped.
+//
+// Each part keeps its own namespace of positions, shifted to follow the previous parts:
+//
+// * word positions are shifted by the number of words of the previous parts
+//
+// * character offsets are shifted by the length of the previous parts and separators,
+// the length of a part is the number of characters of its CleanedText, its RawText,
+// or the ending offset of its last word when no text was returned
+//
+// * entity ids are renumbered in order, as the ids of the other annotations and the sentence positions
+//
+// Topics and coarse topics with the same label, and categories with the same classifier and id, are merged keeping the best score.
+// Matching rules are deduplicated, texts and custom annotation outputs are joined.
+// The language is the language of the first part, the fingerprint is kept if the parts have the same.
+// The merged analysis has no HTTPResponse.
+func MergeAnalyses(parts ...*Analysis) *Analysis {
+	merged := &Analysis{}
+	topics, coarseTopics := map[string]int{}, map[string]int{}
+	categories := map[[2]string]int{}
+	rules := map[string]bool{}
+	var cleaned, raw, annotations []string
+	wordShift, offsetShift, entityID := 0, 0, 0
+	resolveRefs := false
+	fingerprints := 0
+
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		p := part.clone()
+		resolveRefs = resolveRefs || p.resolveRefs
+		shift := func(positions []int) {
+			for i := range positions {
+				positions[i] += wordShift
+			}
+		}
+
+		for _, e := range p.Entailments {
+			shift(e.WordPositions)
+			merged.Entailments = append(merged.Entailments, e)
+		}
+		for _, e := range p.Entities {
+			shift(e.MatchingTokens)
+			e.StartingPos += offsetShift
+			e.EndingPos += offsetShift
+			e.ID = entityID
+			entityID++
+			merged.Entities = append(merged.Entities, e)
+		}
+		for _, np := range p.NounPhrases {
+			shift(np.WordPositions)
+			merged.NounPhrases = append(merged.NounPhrases, np)
+		}
+		for _, pr := range p.Properties {
+			shift(pr.WordPositions)
+			shift(pr.PropertyPositions)
+			merged.Properties = append(merged.Properties, pr)
+		}
+		for _, r := range p.Relations {
+			shift(r.WordPositions)
+			for _, param := range r.Params {
+				shift(param.WordPositions)
+			}
+			merged.Relations = append(merged.Relations, r)
+		}
+
+		words, length := 0, 0
+		for _, s := range p.Sentences {
+			for i := range s.Words {
+				w := &s.Words[i]
+				if w.EndingPos > length {
+					length = w.EndingPos
+				}
+				w.Position += wordShift
+				// the root of a dependency tree has no parent
+				if w.RelationToParent != "" {
+					w.ParentPosition += wordShift
+				}
+				w.StartingPos += offsetShift
+				w.EndingPos += offsetShift
+				words++
+			}
+			merged.Sentences = append(merged.Sentences, s)
+		}
+
+		merged.Topics = mergeTopics(merged.Topics, topics, p.Topics)
+		merged.CoarseTopics = mergeTopics(merged.CoarseTopics, coarseTopics, p.CoarseTopics)
+		for _, c := range p.Categories {
+			key := [2]string{c.ClassifierID, c.CategoryID}
+			if i, ok := categories[key]; ok {
+				if c.Score > merged.Categories[i].Score {
+					merged.Categories[i] = c
+				}
+				continue
+			}
+			categories[key] = len(merged.Categories)
+			merged.Categories = append(merged.Categories, c)
+		}
+		for _, r := range p.MatchingRules {
+			if !rules[r] {
+				rules[r] = true
+				merged.MatchingRules = append(merged.MatchingRules, r)
+			}
+		}
+
+		// the fingerprint is kept if every part has the same
+		switch {
+		case fingerprints == 0:
+			merged.Fingerprint = p.Fingerprint
+		case p.Fingerprint != merged.Fingerprint:
+			merged.Fingerprint = ""
+		}
+		fingerprints++
+
+		if merged.Language == "" {
+			merged.Language, merged.LanguageIsReliable = p.Language, p.LanguageIsReliable
+		}
+		cleaned = append(cleaned, p.CleanedText)
+		raw = append(raw, p.RawText)
+		if p.CustomAnnotationOutput != "" {
+			annotations = append(annotations, p.CustomAnnotationOutput)
+		}
+
+		switch {
+		case p.CleanedText != "":
+			length = utf8.RuneCountInString(p.CleanedText)
+		case p.RawText != "":
+			length = utf8.RuneCountInString(p.RawText)
+		}
+		wordShift += words
+		offsetShift += length + utf8.RuneCountInString(MergeSeparator)
+	}
+
+	renumber(len(merged.Sentences), func(i int) *int { return &merged.Sentences[i].Position })
+	renumber(len(merged.Entailments), func(i int) *int { return &merged.Entailments[i].ID })
+	renumber(len(merged.Topics), func(i int) *int { return &merged.Topics[i].ID })
+	renumber(len(merged.CoarseTopics), func(i int) *int { return &merged.CoarseTopics[i].ID })
+	renumber(len(merged.Categories), func(i int) *int { return &merged.Categories[i].ID })
+	renumber(len(merged.NounPhrases), func(i int) *int { return &merged.NounPhrases[i].ID })
+	renumber(len(merged.Properties), func(i int) *int { return &merged.Properties[i].ID })
+	renumber(len(merged.Relations), func(i int) *int { return &merged.Relations[i].ID })
+
+	merged.CleanedText = joinTexts(cleaned)
+	merged.RawText = joinTexts(raw)
+	merged.CustomAnnotationOutput = strings.Join(annotations, "\n")
+	if resolveRefs {
+		merged.ResolveReferences()
+	}
+	return merged
+}
+
+// renumber sets the ids of n annotations to their index
+func renumber(n int, id func(i int) *int) {
+	for i := 0; i < n; i++ {
+		*id(i) = i
+	}
+}
+
+// joinTexts joins the texts of every part, or returns an empty string if one of them is missing
+func joinTexts(texts []string) string {
+	for _, t := range texts {
+		if t == "" {
+			return ""
+		}
+	}
+	return strings.Join(texts, MergeSeparator)
+}
+
+// mergeTopics appends topics to merged, keeping the best score of the topics with the same label,
+// index maps the labels to their index in merged
+func mergeTopics(merged []Topic, index map[string]int, topics []Topic) []Topic {
+	for _, t := range topics {
+		if i, ok := index[t.Label]; ok {
+			if t.Score > merged[i].Score {
+				merged[i] = t
+			}
+			continue
+		}
+		index[t.Label] = len(merged)
+		merged = append(merged, t)
+	}
+	return merged
+}
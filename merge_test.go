@@ -0,0 +1,70 @@
+package textrazor
+
+import (
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			MergeAnalyses tests
+
+func TestMergeAnalyses(t *testing.T) {
+	title := &Analysis{
+		CleanedText: "Barclays",
+		Entities:    []Entity{{ID: 0, EntityID: "Barclays", MatchingTokens: []int{0}}},
+		Topics:      []Topic{{Label: "Banking", Score: 0.5}},
+		Categories:  []ScoredCategory{{ClassifierID: "textrazor_newscodes", CategoryID: "04006000", Score: 0.9}},
+		Sentences:   []Sentence{{Words: []Word{{Position: 0, StartingPos: 0, EndingPos: 8, Token: "Barclays"}}}},
+	}
+	body := decodeAnalysis(t, textrazortest.AnalysisRelations)
+	body.CleanedText = textrazortest.Text
+	body.Entities = []Entity{{ID: 0, EntityID: "BBC", MatchingTokens: []int{19}}}
+	body.Topics = []Topic{{Label: "Banking", Score: 0.9}, {Label: "BBC", Score: 0.4}}
+	body.CoarseTopics = []Topic{{Label: "Business", Score: 0.8}}
+	body.Categories = []ScoredCategory{{ClassifierID: "textrazor_newscodes", CategoryID: "04006000", Score: 0.3}}
+	body.ResolveReferences()
+
+	merged := MergeAnalyses(title, nil, body)
+
+	if merged.CleanedText != "Barclays"+MergeSeparator+textrazortest.Text || merged.RawText != "" {
+		t.Error("expect joined cleaned texts only, got", merged.CleanedText, merged.RawText)
+	}
+	if len(merged.Entities) != 2 || merged.Entities[1].ID != 1 || merged.Entities[1].MatchingTokens[0] != 20 {
+		t.Fatal("expect BBC to be renumbered 1 at position 20, got", merged.Entities)
+	}
+	if w := merged.Entities[1].Words; len(w) != 1 || w[0].Token != "BBC" || w[0].StartingPos != 106+9 {
+		t.Error("expect BBC references to be resolved with shifted offsets, got", w)
+	}
+	if len(merged.Topics) != 2 || merged.Topics[0].Score != 0.9 {
+		t.Error("expect Banking topic to keep the best score, got", merged.Topics)
+	}
+	if merged.Topics[1].ID != 1 || merged.Sentences[1].Position != 1 || merged.Relations[1].ID != 1 {
+		t.Error("expect the ids and sentence positions to be renumbered, got", merged.Topics, merged.Relations)
+	}
+	if len(merged.CoarseTopics) != 1 || merged.CoarseTopics[0].Score != 0.8 {
+		t.Error("expect the coarse topics to be merged, got", merged.CoarseTopics)
+	}
+	if len(merged.Categories) != 1 || merged.Categories[0].Score != 0.9 {
+		t.Error("expect the category to keep the best score, got", merged.Categories)
+	}
+	if r := merged.Relations[0]; r.WordPositions[0] != 2 || r.Params[0].WordPositions[0] != 1 {
+		t.Error("expect shifted relation positions, got", r)
+	}
+
+	words := 0
+	for _, s := range merged.Sentences {
+		for _, w := range s.Words {
+			if w.Position != words {
+				t.Error("expect consecutive word positions, got", w.Position, "for", w.Token)
+			}
+			words++
+		}
+	}
+	if root := merged.Sentences[1].Words[23]; root.Token != "found" || root.ParentPosition != 0 {
+		t.Error("expect the root not to have a parent, got", root)
+	}
+	if title.Entities[0].ID != 0 || body.Relations[0].WordPositions[0] != 1 {
+		t.Error("expect the parts not to be modified")
+	}
+}
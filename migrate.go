@@ -0,0 +1,169 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// StoredResult is an analysis kept in a ResultStore
+type StoredResult struct {
+	ID string
+	// Params of the analysis, including the text or url of the document
+	Params Params
+	// Fingerprint of the analysis, see Analysis.Fingerprint
+	Fingerprint string
+}
+
+// ResultStore is a store of analyses, e.g. a table of analyzed documents, migrated by MigrateResults
+type ResultStore interface {
+	// Scan calls fn for each stored analysis, and stops with the error of fn
+	Scan(ctx context.Context, fn func(StoredResult) error) error
+	// Save replaces the analysis of a document, it is called concurrently
+	Save(ctx context.Context, ID string, a *Analysis) error
+}
+
+// DefaultMigrationConcurrency is the number of concurrent analyses of MigrateResults
+const DefaultMigrationConcurrency = 4
+
+// MigrationOptions configures MigrateResults, zero values use the defaults
+type MigrationOptions struct {
+	// Concurrency is the maximum number of documents analyzed at the same time
+	Concurrency int
+	// Budget is the maximum number of documents analyzed by the migration, the remaining budget
+	// of the QuotaGuard of the client when 0, unlimited without QuotaGuard
+	Budget int
+	// Progress, if set, is called after each document, from a single goroutine at a time
+	Progress func(MigrationProgress)
+}
+
+// MigrationProgress reports the progress of a migration
+type MigrationProgress struct {
+	// Scanned counts the stored analyses, Stale the ones with another fingerprint
+	Scanned int
+	Stale   int
+	// Done counts the analyzed documents, including the Failed ones
+	Done   int
+	Failed int
+	// Deferred counts the stale documents left for a later migration once the budget is spent
+	Deferred int
+}
+
+// MigrationError is returned by MigrateResults when some documents failed, the others are migrated
+type MigrationError struct {
+	// Errors maps the ID of the failed documents to their error
+	Errors map[string]error
+}
+
+func (e *MigrationError) Error() string {
+	ids := e.ids()
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("%s: %v", id, e.Errors[id])
+	}
+	return fmt.Sprintf("%d documents failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed documents, so errors.Is and errors.As match any of them
+func (e *MigrationError) Unwrap() []error {
+	var errs []error
+	for _, id := range e.ids() {
+		errs = append(errs, e.Errors[id])
+	}
+	return errs
+}
+
+func (e *MigrationError) ids() []string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// MigrateResults analyzes again the stored analyses whose fingerprint differs from the fingerprint of their params
+// with the current client, e.g. after a change of default params or of the library version, and saves the new analyses.
+//
+// A failed document doesn't stop the others, the error is then a *MigrationError. Once the budget is spent,
+// the remaining stale documents are Deferred, running the migration again continues with them.
+func (c *Client) MigrateResults(store ResultStore, o MigrationOptions) (*MigrationProgress, error) {
+	return c.MigrateResultsContext(context.Background(), store, o)
+}
+
+// MigrateResultsContext is like MigrateResults with a context, the documents are analyzed with ForceRefresh
+func (c *Client) MigrateResultsContext(ctx context.Context, store ResultStore, o MigrationOptions, opts ...CallOption) (*MigrationProgress, error) {
+	workers := o.Concurrency
+	if workers <= 0 {
+		workers = DefaultMigrationConcurrency
+	}
+	// a QuotaGuard with nothing left defers every stale document
+	budget, limited := o.Budget, o.Budget > 0
+	if !limited && c.quota != nil {
+		budget, limited = c.quota.Remaining(), true
+	}
+	opts = append(opts[:len(opts):len(opts)], ForceRefresh())
+
+	var (
+		mu       sync.Mutex
+		progress MigrationProgress
+		failed   = map[string]error{}
+	)
+	g, _ := newGroup(ctx, workers, &c.stats.goroutines)
+	err := store.Scan(ctx, func(r StoredResult) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		stale := c.Fingerprint(r.Params) != r.Fingerprint
+
+		mu.Lock()
+		progress.Scanned++
+		if stale {
+			progress.Stale++
+		}
+		deferred := stale && limited && progress.Stale > budget
+		if deferred {
+			progress.Deferred++
+		}
+		mu.Unlock()
+		if !stale || deferred {
+			return nil
+		}
+
+		// the scan waits for a free worker
+		g.Go(func() error {
+			a, err := c.AnalyzeContext(ctx, copyParams(r.Params), opts...)
+			if err == nil {
+				err = store.Save(ctx, r.ID, a)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			progress.Done++
+			if err != nil {
+				progress.Failed++
+				failed[r.ID] = err
+			}
+			if o.Progress != nil {
+				o.Progress(progress)
+			}
+			return nil
+		})
+		return nil
+	})
+	g.Wait()
+
+	if err != nil {
+		err = fmt.Errorf("scan failed: %w", err)
+	}
+	if len(failed) > 0 {
+		migrationErr := &MigrationError{Errors: failed}
+		if err != nil {
+			return &progress, fmt.Errorf("%w, %w", err, migrationErr)
+		}
+		return &progress, migrationErr
+	}
+	return &progress, err
+}
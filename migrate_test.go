@@ -0,0 +1,131 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Migration tests
+
+type memoryStore struct {
+	mu      sync.Mutex
+	results map[string]StoredResult
+	saved   []string
+	failing string
+}
+
+func newMemoryStore(results ...StoredResult) *memoryStore {
+	s := &memoryStore{results: map[string]StoredResult{}}
+	for _, r := range results {
+		s.results[r.ID] = r
+	}
+	return s
+}
+
+func (s *memoryStore) Scan(ctx context.Context, fn func(StoredResult) error) error {
+	s.mu.Lock()
+	var results []StoredResult
+	for _, r := range s.results {
+		results = append(results, r)
+	}
+	s.mu.Unlock()
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+	for _, r := range results {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, ID string, a *Analysis) error {
+	if ID == s.failing {
+		return errors.New("store down")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.results[ID]
+	r.Fingerprint = a.Fingerprint
+	s.results[ID] = r
+	s.saved = append(s.saved, ID)
+	return nil
+}
+
+func TestMigrateResults(t *testing.T) {
+	defer checkLeaks(t)()
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithDefaultParams(Params{"extractors": {"entities"}}))
+	params := Params{"text": {testText}}
+	current := client.Fingerprint(params)
+	store := newMemoryStore(
+		StoredResult{"a", params, current},
+		StoredResult{"b", params, "old"},
+		StoredResult{"c", params, ""},
+		StoredResult{"d", params, current},
+		StoredResult{"e", params, "old"},
+	)
+
+	var calls []MigrationProgress
+	progress, err := client.MigrateResults(store, MigrationOptions{Budget: 2, Concurrency: 1, Progress: func(p MigrationProgress) { calls = append(calls, p) }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *progress != (MigrationProgress{Scanned: 5, Stale: 3, Done: 2, Deferred: 1}) || len(calls) != 2 {
+		t.Errorf("unexpected progress %+v, %d reports", progress, len(calls))
+	}
+	if len(store.saved) != 2 || store.saved[0] != "b" || store.saved[1] != "c" || store.results["b"].Fingerprint != current {
+		t.Error("expect the first stale documents to be saved, got", store.saved)
+	}
+
+	progress, err = client.MigrateResults(store, MigrationOptions{})
+	if err != nil || *progress != (MigrationProgress{Scanned: 5, Stale: 1, Done: 1}) {
+		t.Errorf("expect the deferred document to be migrated, got %+v %v", progress, err)
+	}
+	if n := len(transport.Requests()); n != 3 {
+		t.Error("expect 3 analyses, got", n)
+	}
+}
+
+func TestMigrateResultsErrors(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(
+		textrazortest.Reply{Status: http.StatusRequestEntityTooLarge, Body: `{"ok": false, "error": "Request too large"}`},
+		textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities},
+	)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithQuotaGuard(NewQuotaGuard(3, QuotaReject)))
+	params := Params{"text": {testText}, "extractors": {"entities"}}
+	store := newMemoryStore(StoredResult{"a", params, ""}, StoredResult{"b", params, ""}, StoredResult{"c", params, ""}, StoredResult{"d", params, ""})
+	store.failing = "b"
+
+	progress, err := client.MigrateResultsContext(context.Background(), store, MigrationOptions{Concurrency: 1})
+	var migrationErr *MigrationError
+	if !errors.As(err, &migrationErr) || len(migrationErr.Errors) != 2 || migrationErr.Errors["a"] == nil || migrationErr.Errors["b"] == nil {
+		t.Fatal("expect the first two documents to fail, got", err)
+	}
+	if !errors.Is(err, ErrRequestTooLarge) {
+		t.Error("expect the analysis error to be matched, got", err)
+	}
+	if *progress != (MigrationProgress{Scanned: 4, Stale: 4, Done: 3, Failed: 2, Deferred: 1}) || len(store.saved) != 1 {
+		t.Errorf("expect the quota to defer the last document, got %+v", progress)
+	}
+
+	progress, err = client.MigrateResults(store, MigrationOptions{})
+	if err != nil || *progress != (MigrationProgress{Scanned: 4, Stale: 3, Deferred: 3}) {
+		t.Errorf("expect the exhausted quota to defer every document, got %+v %v", progress, err)
+	}
+	if n := len(transport.Requests()); n != 3 {
+		t.Error("expect no analysis once the quota is exhausted, got", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.MigrateResultsContext(ctx, store, MigrationOptions{}); !errors.Is(err, context.Canceled) {
+		t.Error("expect the scan to stop with the context, got", err)
+	}
+}
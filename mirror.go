@@ -0,0 +1,186 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DictionaryMirror is a local copy of the entries of a dictionary, searchable without requesting the API,
+// e.g. to offer entry search in an admin UI. It's safe for concurrent use.
+type DictionaryMirror struct {
+	DictID string
+
+	mu sync.RWMutex
+	// entries are sorted by lowercased text, then by id, lower holds their lowercased text
+	entries []DictionaryEntry
+	lower   []string
+}
+
+// NewDictionaryMirror returns a mirror of the entries of a dictionary, e.g. read from an exported CSV
+func NewDictionaryMirror(dictID string, entries []DictionaryEntry) *DictionaryMirror {
+	m := &DictionaryMirror{DictID: dictID}
+	m.Set(entries)
+	return m
+}
+
+// MirrorDictionary returns a mirror of every entry of a dictionary
+func (c *Client) MirrorDictionary(dictID string) (*DictionaryMirror, error) {
+	return c.MirrorDictionaryContext(context.Background(), dictID)
+}
+
+// MirrorDictionaryContext is like MirrorDictionary with a context
+func (c *Client) MirrorDictionaryContext(ctx context.Context, dictID string, opts ...CallOption) (*DictionaryMirror, error) {
+	m := &DictionaryMirror{DictID: dictID}
+	if err := m.Refresh(ctx, c, opts...); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Refresh replaces the entries of the mirror with the current entries of the dictionary
+func (m *DictionaryMirror) Refresh(ctx context.Context, c *Client, opts ...CallOption) error {
+	entries, err := c.AllDictionaryEntriesContext(ctx, m.DictID, opts...)
+	if err != nil {
+		return fmt.Errorf("dictionary entries listing failed: %w", err)
+	}
+	m.Set(entries)
+	return nil
+}
+
+// Set replaces the entries of the mirror
+func (m *DictionaryMirror) Set(entries []DictionaryEntry) {
+	sorted := make([]DictionaryEntry, len(entries))
+	for i, e := range entries {
+		e.HTTPResponse = nil
+		e.Data = e.Data.clone()
+		sorted[i] = e
+	}
+	lower := make([]string, len(sorted))
+	for i, e := range sorted {
+		lower[i] = strings.ToLower(e.Text)
+	}
+	sort.Sort(mirrorOrder{sorted, lower})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries, m.lower = sorted, lower
+}
+
+type mirrorOrder struct {
+	entries []DictionaryEntry
+	lower   []string
+}
+
+func (o mirrorOrder) Len() int { return len(o.entries) }
+func (o mirrorOrder) Less(i, j int) bool {
+	if o.lower[i] != o.lower[j] {
+		return o.lower[i] < o.lower[j]
+	}
+	return o.entries[i].ID < o.entries[j].ID
+}
+func (o mirrorOrder) Swap(i, j int) {
+	o.entries[i], o.entries[j] = o.entries[j], o.entries[i]
+	o.lower[i], o.lower[j] = o.lower[j], o.lower[i]
+}
+
+// Len returns the number of entries of the mirror
+func (m *DictionaryMirror) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.entries)
+}
+
+// Entries returns every entry of the mirror, sorted by text ignoring case
+func (m *DictionaryMirror) Entries() []DictionaryEntry {
+	return m.Filter(func(DictionaryEntry) bool { return true })
+}
+
+// Entry returns the entry with an id
+func (m *DictionaryMirror) Entry(id string) (DictionaryEntry, bool) {
+	found := m.Filter(func(e DictionaryEntry) bool { return e.ID == id })
+	if len(found) == 0 {
+		return DictionaryEntry{}, false
+	}
+	return found[0], true
+}
+
+// WithPrefix returns the entries whose text starts with prefix, ignoring case, sorted by text
+func (m *DictionaryMirror) WithPrefix(prefix string) []DictionaryEntry {
+	prefix = strings.ToLower(prefix)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// the lowercased texts are sorted, so the matches are contiguous
+	start := sort.SearchStrings(m.lower, prefix)
+	end := start
+	for end < len(m.lower) && strings.HasPrefix(m.lower[end], prefix) {
+		end++
+	}
+	return cloneEntries(m.entries[start:end])
+}
+
+// Containing returns the entries whose text contains s, ignoring case, sorted by text
+func (m *DictionaryMirror) Containing(s string) []DictionaryEntry {
+	s = strings.ToLower(s)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found []DictionaryEntry
+	for i, text := range m.lower {
+		if strings.Contains(text, s) {
+			found = append(found, cloneEntry(m.entries[i]))
+		}
+	}
+	return found
+}
+
+// WithData returns the entries having value among the values of a data key, sorted by text.
+// An empty value matches every entry having the key.
+func (m *DictionaryMirror) WithData(key, value string) []DictionaryEntry {
+	return m.Filter(func(e DictionaryEntry) bool {
+		values, ok := e.Data[key]
+		if !ok || value == "" {
+			return ok
+		}
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Filter returns the entries for which keep returns true, sorted by text.
+// keep is called with the lock held, it mustn't modify the mirror.
+func (m *DictionaryMirror) Filter(keep func(DictionaryEntry) bool) []DictionaryEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found []DictionaryEntry
+	for _, e := range m.entries {
+		if keep(e) {
+			found = append(found, cloneEntry(e))
+		}
+	}
+	return found
+}
+
+// cloneEntry copies the data of an entry, so callers can't modify the mirror
+func cloneEntry(e DictionaryEntry) DictionaryEntry {
+	e.Data = e.Data.clone()
+	return e
+}
+
+func cloneEntries(entries []DictionaryEntry) []DictionaryEntry {
+	if len(entries) == 0 {
+		return nil
+	}
+	c := make([]DictionaryEntry, len(entries))
+	for i, e := range entries {
+		c[i] = cloneEntry(e)
+	}
+	return c
+}
@@ -0,0 +1,96 @@
+package textrazor
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+//***************************************************************
+// 			DictionaryMirror tests
+
+func mirrorIDs(entries []DictionaryEntry) []string {
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestDictionaryMirrorSearch(t *testing.T) {
+	m := NewDictionaryMirror("products", []DictionaryEntry{
+		{ID: "4", Text: "apple pie", Data: EntryData{"type": {"dessert"}}},
+		{ID: "1", Text: "Apple", Data: EntryData{"type": {"fruit", "brand"}}},
+		{ID: "3", Text: "Pineapple", Data: EntryData{"type": {"fruit"}}},
+		{ID: "2", Text: "apple"},
+		{ID: "5", Text: "Banana", Data: EntryData{"origin": {"Ecuador"}}},
+	})
+
+	var tests = []struct {
+		name   string
+		got    []DictionaryEntry
+		expect []string
+	}{
+		{"prefix", m.WithPrefix("APP"), []string{"1", "2", "4"}},
+		{"empty prefix", m.WithPrefix(""), []string{"1", "2", "4", "5", "3"}},
+		{"unknown prefix", m.WithPrefix("cherry"), nil},
+		{"containing", m.Containing("aPPle"), []string{"1", "2", "4", "3"}},
+		{"containing inside", m.Containing("nan"), []string{"5"}},
+		{"data value", m.WithData("type", "fruit"), []string{"1", "3"}},
+		{"data second value", m.WithData("type", "brand"), []string{"1"}},
+		{"data key", m.WithData("origin", ""), []string{"5"}},
+		{"unknown data", m.WithData("type", "vegetable"), nil},
+		{"filter", m.Filter(func(e DictionaryEntry) bool { return len(e.Data) == 0 }), []string{"2"}},
+	}
+	for _, tt := range tests {
+		if ids := mirrorIDs(tt.got); !reflect.DeepEqual(ids, tt.expect) {
+			t.Errorf("%s: expect the entries %v, got %v", tt.name, tt.expect, ids)
+		}
+	}
+
+	if e, ok := m.Entry("3"); !ok || e.Text != "Pineapple" {
+		t.Errorf("expect the entry 3, got %+v %v", e, ok)
+	}
+	if _, ok := m.Entry("6"); ok {
+		t.Error("expect no entry 6")
+	}
+
+	// the results are copies
+	m.WithData("type", "fruit")[0].Data.Set("type", "vegetable")
+	if e, _ := m.Entry("1"); e.Data.Get("type") != "fruit" {
+		t.Errorf("expect the mirror unchanged, got %v", e.Data)
+	}
+}
+
+func TestMirrorDictionary(t *testing.T) {
+	s := newDictionaryServer(
+		DictionaryEntry{ID: "b", Text: "Berlin", Data: EntryData{"country": {"DE"}}},
+		DictionaryEntry{ID: "p", Text: "Paris", Data: EntryData{"country": {"FR"}}},
+	)
+	defer s.Close()
+	c := s.client()
+
+	m, err := c.MirrorDictionary("cities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 2 || m.DictID != "cities" {
+		t.Fatalf("expect 2 entries of cities, got %d of %s", m.Len(), m.DictID)
+	}
+	if ids := mirrorIDs(m.WithData("country", "FR")); !reflect.DeepEqual(ids, []string{"p"}) {
+		t.Errorf("expect the entry p, got %v", ids)
+	}
+
+	if _, err := c.AddDictionaryEntries("cities", []DictionaryEntry{{ID: "l", Text: "Lyon", Data: EntryData{"country": {"FR"}}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Refresh(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if ids := mirrorIDs(m.WithData("country", "FR")); !reflect.DeepEqual(ids, []string{"l", "p"}) {
+		t.Errorf("expect the entries l and p after refresh, got %v", ids)
+	}
+	if e := m.Entries(); len(e) != 3 || e[0].HTTPResponse != nil {
+		t.Errorf("expect 3 entries without response, got %+v", e)
+	}
+}
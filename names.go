@@ -0,0 +1,42 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// The documentation and the API don't always agree on field names.
+// jsonAliases lists, for each type, the alternative names accepted when decoding,
+// mapped to the name used in the struct tags.
+//
+// Case variants (entityID, EntityId...) don't need an alias, encoding/json matches names case-insensitively.
+var jsonAliases = map[reflect.Type]map[string]string{
+	// the doc says planDailyIncludedRequests but the API responds with planDailyRequestsIncluded
+	reflect.TypeOf(Account{}): {"planDailyIncludedRequests": "planDailyRequestsIncluded"},
+}
+
+// canonicalNames renames the aliased fields of a JSON object to the names used in the struct tags of t,
+// a field already present under its canonical name is kept
+func canonicalNames(b []byte, t reflect.Type) ([]byte, error) {
+	aliases := jsonAliases[t]
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil || fields == nil {
+		return b, err
+	}
+	renamed := false
+	for alias, name := range aliases {
+		v, ok := fields[alias]
+		if !ok {
+			continue
+		}
+		if _, exists := fields[name]; !exists {
+			fields[name] = v
+		}
+		delete(fields, alias)
+		renamed = true
+	}
+	if !renamed {
+		return b, nil
+	}
+	return json.Marshal(fields)
+}
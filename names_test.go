@@ -0,0 +1,46 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+//***************************************************************
+// 			Field aliases tests
+
+func TestAccountAliases(t *testing.T) {
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"plan":"FREE","planDailyRequestsIncluded":500}`, 500},
+		{`{"plan":"FREE","planDailyIncludedRequests":"500"}`, 500},
+		{`{"plan":"FREE","planDailyIncludedRequests":100,"planDailyRequestsIncluded":500}`, 500},
+		{`{"plan":"FREE","PlanDailyRequestsIncluded":500}`, 500},
+	}
+	for _, tst := range tests {
+		var a Account
+		if err := json.Unmarshal([]byte(tst.body), &a); err != nil {
+			t.Error(tst.body, err)
+			continue
+		}
+		if a.Plan != "FREE" || a.PlanDailyIncludedRequests != tst.want {
+			t.Error(tst.body, "expect a FREE plan with", tst.want, "daily requests, got", a)
+		}
+	}
+
+	findings, err := schemaFindings([]byte(`{"ok":true,"response":{"planDailyIncludedRequests":500}}`), &Account{})
+	if err != nil || len(findings) != 0 {
+		t.Error("expect aliases to be modeled, got", findings, err)
+	}
+}
+
+func TestEntityCaseVariants(t *testing.T) {
+	var e Entity
+	if err := json.Unmarshal([]byte(`{"entityID":"BBC","EntityEnglishId":"BBC","wikidataID":"Q9531"}`), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.EntityID != "BBC" || e.EntityEnglishID != "BBC" || e.WikidataID != "Q9531" {
+		t.Error("expect case variants to be decoded, got", e)
+	}
+}
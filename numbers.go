@@ -0,0 +1,165 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// The API has not always been consistent in the JSON representation of numbers,
+// scores and ids have been returned as integers, floats or strings.
+// The types below tolerate those variations, they are used by the UnmarshalJSON methods
+// of the structs holding such fields, to decode them without changing their exported type.
+
+// jsonScalar returns the textual value of a JSON number or string, and false for null or an empty string
+func jsonScalar(b []byte) (string, bool, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return "", false, nil
+	}
+	s := string(b)
+	if b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return "", false, err
+		}
+	}
+	return s, s != "", nil
+}
+
+// flexFloat64 decodes a float64 from a JSON number or a numeric string
+type flexFloat64 float64
+
+func (f *flexFloat64) UnmarshalJSON(b []byte) error {
+	s, ok, err := jsonScalar(b)
+	if err != nil || !ok {
+		return err
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s: %v", b, err)
+	}
+	*f = flexFloat64(v)
+	return nil
+}
+
+// flexInt decodes an int from a JSON number, including integral floats like 12.0, or a numeric string
+type flexInt int
+
+func (i *flexInt) UnmarshalJSON(b []byte) error {
+	s, ok, err := jsonScalar(b)
+	if err != nil || !ok {
+		return err
+	}
+	if v, err := strconv.Atoi(s); err == nil {
+		*i = flexInt(v)
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v != math.Trunc(v) {
+		return fmt.Errorf("invalid integer %s", b)
+	}
+	*i = flexInt(v)
+	return nil
+}
+
+// flexString decodes a string from a JSON string or number
+type flexString string
+
+func (s *flexString) UnmarshalJSON(b []byte) error {
+	v, _, err := jsonScalar(b)
+	*s = flexString(v)
+	return err
+}
+
+// UnmarshalJSON decodes a ScoredCategory, tolerating a numeric categoryId, or an id or a score encoded as a string
+func (c *ScoredCategory) UnmarshalJSON(b []byte) error {
+	type scoredCategory ScoredCategory
+	aux := struct {
+		*scoredCategory
+		ID         flexInt     `json:"id"`
+		CategoryID flexString  `json:"categoryId"`
+		Score      flexFloat64 `json:"score"`
+	}{scoredCategory: (*scoredCategory)(c)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	c.ID = int(aux.ID)
+	c.CategoryID = string(aux.CategoryID)
+	c.Score = float64(aux.Score)
+	return nil
+}
+
+// UnmarshalJSON decodes a Category, tolerating a numeric categoryId
+func (c *Category) UnmarshalJSON(b []byte) error {
+	type category Category
+	aux := struct {
+		*category
+		CategoryID flexString `json:"categoryId"`
+	}{category: (*category)(c)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	c.CategoryID = string(aux.CategoryID)
+	return nil
+}
+
+// UnmarshalJSON decodes an Account, tolerating counters encoded as strings or floats
+// and the field names of the documentation
+func (a *Account) UnmarshalJSON(b []byte) error {
+	b, err := canonicalNames(b, reflect.TypeOf(*a))
+	if err != nil {
+		return err
+	}
+	type account Account
+	aux := struct {
+		*account
+		ConcurrentRequestLimit    flexInt `json:"concurrentRequestLimit"`
+		ConcurrentRequestsUsed    flexInt `json:"concurrentRequestsUsed"`
+		PlanDailyIncludedRequests flexInt `json:"planDailyRequestsIncluded"`
+		RequestsUsedToday         flexInt `json:"requestsUsedToday"`
+	}{account: (*account)(a)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	a.ConcurrentRequestLimit = int(aux.ConcurrentRequestLimit)
+	a.ConcurrentRequestsUsed = int(aux.ConcurrentRequestsUsed)
+	a.PlanDailyIncludedRequests = int(aux.PlanDailyIncludedRequests)
+	a.RequestsUsedToday = int(aux.RequestsUsedToday)
+	return nil
+}
+
+// Numbers returns the numbers of the body of the response as sent by the API, by path, e.g.
+// "response.entities[0].relevanceScore", for callers who need their exact decimal value rather than the float64 of the structs.
+// It fails when the body was discarded, see WithResponseBody.
+func (r *HTTPResponse) Numbers() (map[string]json.Number, error) {
+	if r.Body == nil {
+		return nil, fmt.Errorf("the response body isn't kept")
+	}
+	dec := json.NewDecoder(bytes.NewReader(r.Body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("http response body parsing failed: %v", err)
+	}
+	numbers := map[string]json.Number{}
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		switch value := v.(type) {
+		case map[string]interface{}:
+			for k, fv := range value {
+				walk(joinPath(path, k), fv)
+			}
+		case []interface{}:
+			for i, ev := range value {
+				walk(path+"["+strconv.Itoa(i)+"]", ev)
+			}
+		case json.Number:
+			numbers[path] = value
+		}
+	}
+	walk("", v)
+	return numbers, nil
+}
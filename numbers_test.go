@@ -0,0 +1,99 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Numeric type drift tests
+
+func TestFlexNumbers(t *testing.T) {
+	var e Entity
+	if err := json.Unmarshal([]byte(`{"id":"3","entityId":"BBC","confidenceScore":"1.5","relevanceScore":1}`), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.ID != 3 || e.EntityID != "BBC" || e.ConfidenceScore != 1.5 || e.RelevanceScore != 1 {
+		t.Error("expect entity 3 BBC with scores 1.5 and 1, got", e)
+	}
+
+	var c ScoredCategory
+	if err := json.Unmarshal([]byte(`{"categoryId":4006000,"label":"finance","score":null}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.CategoryID != "4006000" || c.Label != "finance" || c.Score != 0 {
+		t.Error("expect category 4006000 with a 0 score, got", c)
+	}
+
+	var a Account
+	if err := json.Unmarshal([]byte(`{"plan":"FREE","concurrentRequestLimit":2.0,"requestsUsedToday":"17","planDailyRequestsIncluded":500}`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if a.Plan != "FREE" || a.ConcurrentRequestLimit != 2 || a.RequestsUsedToday != 17 || a.PlanDailyIncludedRequests != 500 {
+		t.Error("expect a FREE account with a limit of 2 and 17/500 requests, got", a)
+	}
+
+	var topics []Topic
+	if err := json.Unmarshal([]byte(`[{"label":"Banking","score":"0.95"},{"label":"BBC","score":0.5}]`), &topics); err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != 2 || topics[0].Score != 0.95 || topics[1].Label != "BBC" {
+		t.Error("expect 2 topics with Banking scored 0.95, got", topics)
+	}
+
+	invalid := []string{`{"id":1.5}`, `{"id":"one"}`, `{"confidenceScore":"high"}`, `{"confidenceScore":true}`}
+	for _, s := range invalid {
+		if err := json.Unmarshal([]byte(s), &e); err == nil {
+			t.Error("expect", s, "to fail")
+		}
+	}
+}
+
+func TestScorePrecision(t *testing.T) {
+	var e Entailment
+	if err := json.Unmarshal([]byte(`{"score":0.123456789012345,"priorScore":"1e-9","contextScore":0.1}`), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Score != 0.123456789012345 || e.PriorScore != 1e-9 || e.ContextScore != 0.1 {
+		t.Error("expect the scores without loss of precision, got", e)
+	}
+}
+
+func TestHTTPResponseNumbers(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint,
+		textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisFull))
+	a, err := client.AnalyzeText("text", Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	numbers, err := a.HTTPResponse.Numbers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		path   string
+		expect json.Number
+	}{
+		{"response.entities[0].relevanceScore", "0.7246"},
+		{"response.entities[1].relevanceScore", "0.4451"},
+		{"response.entities[0].startingPos", "0"},
+	}
+	for _, tt := range tests {
+		if n := numbers[tt.path]; n != tt.expect {
+			t.Errorf("%s: expect %s, got %q", tt.path, tt.expect, n)
+		}
+	}
+	if f, _ := numbers["response.entities[0].relevanceScore"].Float64(); f != a.Entities[0].RelevanceScore {
+		t.Errorf("expect the score of the entity, got %v and %v", f, a.Entities[0].RelevanceScore)
+	}
+	if _, ok := numbers["response.language"]; ok {
+		t.Error("expect only numbers")
+	}
+
+	if _, err := (&HTTPResponse{}).Numbers(); err == nil {
+		t.Error("expect an error without body")
+	}
+}
@@ -0,0 +1,119 @@
+// Package output prints the results of the textrazor command in the format chosen with its -format flag:
+// aligned tables, colored on terminals, JSON or CSV.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/bengentil/textrazor-go"
+)
+
+// Format is an output format, it implements flag.Value
+type Format string
+
+// Valid Format values
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	CSV   Format = "csv"
+)
+
+// Formats are the valid formats
+var Formats = []Format{Table, JSON, CSV}
+
+func (f *Format) String() string { return string(*f) }
+
+// Set implements flag.Value
+func (f *Format) Set(s string) error {
+	for _, valid := range Formats {
+		if Format(s) == valid {
+			*f = valid
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q, expect table, json or csv", s)
+}
+
+// Printer prints results to W in Format
+type Printer struct {
+	W      io.Writer
+	Format Format
+	// Color colors the tables with ANSI escape codes
+	Color bool
+}
+
+// New returns a Printer of w, colored when w is a terminal and the NO_COLOR environment variable isn't set
+func New(w io.Writer, f Format) *Printer {
+	return &Printer{W: w, Format: f, Color: IsTerminal(w) && os.Getenv("NO_COLOR") == ""}
+}
+
+// IsTerminal reports whether w is a terminal, i.e. a character device other than a dumb terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Print prints a result: v encoded in JSON, or the header and the rows as a table or CSV
+func (p *Printer) Print(v interface{}, header []string, rows [][]string) error {
+	switch p.Format {
+	case JSON:
+		enc := json.NewEncoder(p.W)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case CSV:
+		cw := csv.NewWriter(p.W)
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	default:
+		return textrazor.PrintOptions{Color: p.Color}.Table(p.W, "", header, len(rows), func(i int) []string { return rows[i] })
+	}
+}
+
+// AnalysisHeader is the header of the CSV of an analysis, a row per entity, topic, coarse topic and category
+var AnalysisHeader = []string{"kind", "id", "text", "score", "startingPos", "endingPos"}
+
+// Analysis prints an analysis: the summary of textrazor.Fprint, its JSON encoding, or a CSV row per annotation, see AnalysisHeader
+func (p *Printer) Analysis(a *textrazor.Analysis) error {
+	switch p.Format {
+	case JSON, CSV:
+		return p.Print(a, AnalysisHeader, analysisRows(a))
+	default:
+		return textrazor.PrintOptions{Color: p.Color}.Fprint(p.W, a)
+	}
+}
+
+// analysisRows returns the CSV rows of an analysis
+func analysisRows(a *textrazor.Analysis) [][]string {
+	var rows [][]string
+	for _, e := range a.Entities {
+		id := e.EntityID
+		if id == "" {
+			id = e.CustomEntityID
+		}
+		rows = append(rows, []string{"entity", id, e.MatchedText, score(e.RelevanceScore), strconv.Itoa(e.StartingPos), strconv.Itoa(e.EndingPos)})
+	}
+	for _, t := range a.Topics {
+		rows = append(rows, []string{"topic", t.WikidataID, t.Label, score(t.Score), "", ""})
+	}
+	for _, t := range a.CoarseTopics {
+		rows = append(rows, []string{"coarseTopic", t.WikidataID, t.Label, score(t.Score), "", ""})
+	}
+	for _, c := range a.Categories {
+		rows = append(rows, []string{"category", c.ClassifierID + "/" + c.CategoryID, c.Label, score(c.Score), "", ""})
+	}
+	return rows
+}
+
+func score(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go"
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Output tests
+
+func decodeAnalysis(t *testing.T, body string) *textrazor.Analysis {
+	var r struct{ Response *textrazor.Analysis }
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatal(err)
+	}
+	return r.Response
+}
+
+func TestFormatFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	f := Table
+	fs.Var(&f, "format", "")
+	if err := fs.Parse([]string{"-format", "csv"}); err != nil || f != CSV {
+		t.Error("expect the csv format, got", f, err)
+	}
+	if err := fs.Parse([]string{"-format", "yaml"}); err == nil || f != CSV {
+		t.Error("expect an unknown format to be rejected, got", f, err)
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	header, rows := []string{"ID", "LABEL"}, [][]string{{"1", "Sport"}, {"20", "Politics\tand news"}}
+	var tests = []struct {
+		p      Printer
+		expect string
+	}{
+		{Printer{Format: Table}, "ID  LABEL\n1   Sport\n20  Politics and news\n"},
+		{Printer{Format: Table, Color: true}, "\x1b[2mID  LABEL\x1b[0m\n1   Sport\n20  Politics and news\n"},
+		{Printer{Format: CSV}, "ID,LABEL\n1,Sport\n20,Politics\tand news\n"},
+		{Printer{Format: JSON}, "{\n  \"id\": 1\n}\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		tt.p.W = &b
+		if err := tt.p.Print(map[string]int{"id": 1}, header, rows); err != nil || b.String() != tt.expect {
+			t.Errorf("%s: expect %q, got %q %v", tt.p.Format, tt.expect, b.String(), err)
+		}
+	}
+}
+
+func TestPrinterAnalysis(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisFull)
+	var tests = []struct {
+		p      Printer
+		expect string
+	}{
+		{Printer{Format: Table}, "Language: eng (reliable)\n"},
+		{Printer{Format: Table, Color: true}, "\x1b[1mEntities:\x1b[0m\n\x1b[2mID  ENTITY"},
+		{Printer{Format: CSV}, "entity,BBC,BBC,0.4451,106,109\n"},
+		{Printer{Format: CSV}, "topic,Q22687,Banking,0.9487,,\n"},
+		{Printer{Format: CSV}, `category,textrazor_newscodes/04006000,"economy, business and finance>financial and business service",0.5713`},
+		{Printer{Format: JSON}, "\"cleanedText\": "},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		tt.p.W = &b
+		if err := tt.p.Analysis(a); err != nil || !strings.Contains(b.String(), tt.expect) {
+			t.Errorf("%s: expect %q, got\n%s %v", tt.p.Format, tt.expect, b.String(), err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if p := New(&bytes.Buffer{}, JSON); p.Color || p.Format != JSON {
+		t.Errorf("expect a buffer not to be colored, got %+v", p)
+	}
+	f, err := os.CreateTemp(t.TempDir(), "output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if IsTerminal(f) {
+		t.Error("expect a regular file not to be a terminal")
+	}
+}
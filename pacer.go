@@ -0,0 +1,75 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Workload is a number of requests to spread evenly over a time window,
+// e.g. 10000 analyses over 8 hours, see NewPacer
+type Workload struct {
+	Requests int
+	Window   time.Duration
+
+	// DailyQuota and QuotaShare cap the requests sent per day to a share of the daily quota,
+	// e.g. 0.7 of the PlanDailyIncludedRequests of the account. No cap when DailyQuota is 0,
+	// the whole quota when QuotaShare is 0.
+	DailyQuota int
+	QuotaShare float64
+}
+
+// Pacer is a RateLimiter sending the requests of a Workload at a steady rate, so the workload finishes
+// at the end of its window instead of consuming the daily quota at once. Use it with WithRateLimiter.
+//
+// Retries are paced too, they delay the end of the workload.
+type Pacer struct {
+	Workload
+	perSecond float64
+	limiter   RateLimiter
+}
+
+// NewPacer returns a Pacer spreading the requests of w over its window.
+// It returns an error when the rate exceeds the share of the daily quota.
+func NewPacer(w Workload) (*Pacer, error) {
+	if w.Requests <= 0 || w.Window <= 0 {
+		return nil, errors.New("workload must have requests and a window")
+	}
+	if w.QuotaShare < 0 || w.QuotaShare > 1 {
+		return nil, fmt.Errorf("invalid quota share %v, expect a value between 0 and 1", w.QuotaShare)
+	}
+	p := &Pacer{Workload: w, perSecond: float64(w.Requests) / w.Window.Seconds()}
+
+	if w.DailyQuota > 0 {
+		share := w.QuotaShare
+		if share == 0 {
+			share = 1
+		}
+		day := w.Window
+		if day > 24*time.Hour {
+			day = 24 * time.Hour
+		}
+		if perDay, budget := p.perSecond*day.Seconds(), float64(w.DailyQuota)*share; perDay > budget {
+			return nil, fmt.Errorf("%d requests over %v send %.0f requests per day, more than %.0f%% of the daily quota of %d",
+				w.Requests, w.Window, perDay, share*100, w.DailyQuota)
+		}
+	}
+	p.limiter = NewRateLimiter(p.perSecond, 1)
+	return p, nil
+}
+
+// PerMinute returns the number of requests sent per minute
+func (p *Pacer) PerMinute() float64 {
+	return p.perSecond * 60
+}
+
+// Interval returns the time between 2 requests
+func (p *Pacer) Interval() time.Duration {
+	return time.Duration(float64(time.Second) / p.perSecond)
+}
+
+// Wait implements RateLimiter
+func (p *Pacer) Wait(ctx context.Context) error {
+	return p.limiter.Wait(ctx)
+}
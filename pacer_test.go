@@ -0,0 +1,72 @@
+package textrazor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			Pacer tests
+
+func TestNewPacer(t *testing.T) {
+	var tests = []struct {
+		w         Workload
+		perMinute float64
+		err       string
+	}{
+		{Workload{Requests: 10000, Window: 8 * time.Hour, DailyQuota: 20000, QuotaShare: 0.7}, 10000.0 / 480, ""},
+		{Workload{Requests: 600, Window: time.Hour}, 10, ""},
+		// over several days, the requests of a day are capped
+		{Workload{Requests: 3000, Window: 72 * time.Hour, DailyQuota: 1000}, 3000.0 / 4320, ""},
+		{Workload{Requests: 15000, Window: 8 * time.Hour, DailyQuota: 20000, QuotaShare: 0.7}, 0,
+			"15000 requests over 8h0m0s send 15000 requests per day, more than 70% of the daily quota of 20000"},
+		{Workload{Requests: 3000, Window: 48 * time.Hour, DailyQuota: 1000}, 0, "send 1500 requests per day"},
+		{Workload{Requests: 10, Window: time.Hour, QuotaShare: 1.5}, 0, "invalid quota share 1.5"},
+		{Workload{Window: time.Hour}, 0, "workload must have requests and a window"},
+	}
+	for _, tt := range tests {
+		p, err := NewPacer(tt.w)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%+v: expect the error %q, got %v", tt.w, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %v", tt.w, err)
+			continue
+		}
+		if d := p.PerMinute() - tt.perMinute; d > 1e-9 || d < -1e-9 {
+			t.Errorf("%+v: expect %v requests per minute, got %v", tt.w, tt.perMinute, p.PerMinute())
+		}
+	}
+}
+
+func TestPacerWait(t *testing.T) {
+	p, err := NewPacer(Workload{Requests: 5, Window: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Interval() != 20*time.Millisecond {
+		t.Error("expect a request every 20ms, got", p.Interval())
+	}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := p.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first request is sent at once
+	if d := time.Since(start); d < 75*time.Millisecond || d > time.Second {
+		t.Error("expect the requests to be spread over the window, took", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Wait(ctx); err != context.Canceled {
+		t.Error("expect the context error, got", err)
+	}
+}
@@ -0,0 +1,39 @@
+package textrazor
+
+import "strings"
+
+// Text returns the text of the sentence, rebuilt from the tokens of its words separated by a space where their offsets leave a gap
+func (s *Sentence) Text() string {
+	words := make([]*Word, len(s.Words))
+	for i := range s.Words {
+		words[i] = &s.Words[i]
+	}
+	return joinTokens(words)
+}
+
+// Text returns the text of the noun phrase, taken from the text of a or rebuilt from the tokens of its words, see Span
+func (np *NounPhrase) Text(a *Analysis) string {
+	return a.WordSpan(np.WordPositions).Text
+}
+
+// Text returns the text of the words described by the property, see Span
+func (p *Property) Text(a *Analysis) string {
+	return a.WordSpan(p.WordPositions).Text
+}
+
+// PropertyText returns the text of the property words, see Span
+func (p *Property) PropertyText(a *Analysis) string {
+	return a.WordSpan(p.PropertyPositions).Text
+}
+
+// joinTokens joins the tokens of words ordered by position, separated by a space where their offsets leave a gap
+func joinTokens(words []*Word) string {
+	var b strings.Builder
+	for i, w := range words {
+		if i > 0 && w.StartingPos > words[i-1].EndingPos {
+			b.WriteString(" ")
+		}
+		b.WriteString(w.Token)
+	}
+	return b.String()
+}
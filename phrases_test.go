@@ -0,0 +1,43 @@
+package textrazor
+
+import (
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Text reconstruction tests
+
+func TestSentenceText(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisWords)
+	if text := a.Sentences[0].Text(); text != textrazortest.Text {
+		t.Errorf("expect %q, got %q", textrazortest.Text, text)
+	}
+	if text := (&Sentence{}).Text(); text != "" {
+		t.Error("expect an empty sentence to have no text, got", text)
+	}
+}
+
+func TestPhraseText(t *testing.T) {
+	phrases := decodeAnalysis(t, textrazortest.AnalysisNounPhrases)
+	relations := decodeAnalysis(t, textrazortest.AnalysisRelations)
+	relations.RawText = textrazortest.Text
+
+	var tests = []struct {
+		name   string
+		text   string
+		expect string
+	}{
+		{"noun phrase", phrases.NounPhrases[0].Text(phrases), "shareholders and the public"},
+		{"noun phrase", phrases.NounPhrases[1].Text(phrases), "the biggest investments"},
+		{"property", relations.Properties[0].Text(relations), "investigation"},
+		{"property words", relations.Properties[0].PropertyText(relations), "BBC Panorama"},
+		{"property words", relations.Properties[1].PropertyText(relations), "biggest"},
+	}
+	for _, tt := range tests {
+		if tt.text != tt.expect {
+			t.Errorf("%s: expect %q, got %q", tt.name, tt.expect, tt.text)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package textrazor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// printedTypes is the number of types of an entity printed by Fprint
+const printedTypes = 3
+
+// ANSI escape codes of the colored output
+const (
+	ansiBold  = "\x1b[1m"
+	ansiFaint = "\x1b[2m"
+	ansiCyan  = "\x1b[36m"
+	ansiReset = "\x1b[0m"
+)
+
+// PrintOptions configures the pretty printers
+type PrintOptions struct {
+	// Color highlights the titles, the table headers and the relations of the trees with ANSI escape codes, for terminals
+	Color bool
+}
+
+// Fprint writes a human readable summary of an analysis to w, e.g. while debugging: the language,
+// aligned tables of the entities, topics, coarse topics and categories, and the dependency trees of the sentences.
+// Empty sections are skipped.
+func Fprint(w io.Writer, a *Analysis) error {
+	return PrintOptions{}.Fprint(w, a)
+}
+
+// Fprint is like the Fprint function, with options
+func (o PrintOptions) Fprint(w io.Writer, a *Analysis) error {
+	bw := bufio.NewWriter(w)
+	reliable := "unreliable"
+	if a.LanguageIsReliable {
+		reliable = "reliable"
+	}
+	fmt.Fprintf(bw, "%s %s (%s)\n", o.color(ansiBold, "Language:"), a.Language, reliable)
+
+	if len(a.Entities) > 0 {
+		o.Table(bw, "Entities", []string{"ID", "ENTITY", "TEXT", "TYPES", "RELEVANCE", "CONFIDENCE", "OFFSETS"}, len(a.Entities), func(i int) []string {
+			e := &a.Entities[i]
+			id := e.EntityID
+			if id == "" {
+				id = e.CustomEntityID
+			}
+			return []string{fmt.Sprint(e.ID), id, e.MatchedText, entityTypes(e.Types), fmt.Sprintf("%.3f", e.RelevanceScore),
+				fmt.Sprintf("%.3f", e.ConfidenceScore), fmt.Sprintf("%d-%d", e.StartingPos, e.EndingPos)}
+		})
+	}
+	for _, topics := range []struct {
+		title  string
+		topics []Topic
+	}{{"Topics", a.Topics}, {"Coarse topics", a.CoarseTopics}} {
+		if len(topics.topics) > 0 {
+			o.Table(bw, topics.title, []string{"LABEL", "SCORE", "WIKIDATA"}, len(topics.topics), func(i int) []string {
+				t := &topics.topics[i]
+				return []string{t.Label, fmt.Sprintf("%.3f", t.Score), t.WikidataID}
+			})
+		}
+	}
+	if len(a.Categories) > 0 {
+		o.Table(bw, "Categories", []string{"CLASSIFIER", "ID", "LABEL", "SCORE"}, len(a.Categories), func(i int) []string {
+			c := &a.Categories[i]
+			return []string{c.ClassifierID, c.CategoryID, c.Label, fmt.Sprintf("%.3f", c.Score)}
+		})
+	}
+
+	for i := range a.Sentences {
+		if t := a.Sentences[i].DependencyTree(); t != nil {
+			fmt.Fprintf(bw, "\n%s %s\n", o.color(ansiBold, fmt.Sprintf("Sentence %d:", i+1)), a.Sentences[i].Text())
+			o.FprintTree(bw, t)
+		}
+	}
+	return bw.Flush()
+}
+
+// Fprint writes the tree to w, a word per line with its relation to its parent, e.g.
+//
+//	found
+//	├── Barclays (nsubj)
+//	└── misled (ccomp)
+//	    └── shareholders (dobj)
+func (t *DependencyTree) Fprint(w io.Writer) error {
+	return PrintOptions{}.FprintTree(w, t)
+}
+
+// FprintTree is like DependencyTree.Fprint, with options
+func (o PrintOptions) FprintTree(w io.Writer, t *DependencyTree) error {
+	bw := bufio.NewWriter(w)
+	var print func(t *DependencyTree, prefix, branch, indent string)
+	print = func(t *DependencyTree, prefix, branch, indent string) {
+		bw.WriteString(prefix + branch + t.Word.Token)
+		if t.Word.RelationToParent != "" {
+			bw.WriteString(" " + o.color(ansiCyan, "("+t.Word.RelationToParent+")"))
+		}
+		bw.WriteString("\n")
+		for i, c := range t.Children {
+			if i == len(t.Children)-1 {
+				print(c, prefix+indent, "└── ", "    ")
+			} else {
+				print(c, prefix+indent, "├── ", "│   ")
+			}
+		}
+	}
+	print(t, "", "", "")
+	return bw.Flush()
+}
+
+// Table writes a titled table of n rows with aligned columns, without title when it's empty
+func (o PrintOptions) Table(w io.Writer, title string, header []string, n int, row func(i int) []string) error {
+	if title != "" {
+		if _, err := fmt.Fprintf(w, "\n%s\n", o.color(ansiBold, title+":")); err != nil {
+			return err
+		}
+	}
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for i := 0; i < n; i++ {
+		cells := make([]string, 0, len(header))
+		for _, c := range row(i) {
+			cells = append(cells, strings.NewReplacer("\t", " ", "\n", " ").Replace(c))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	tw.Flush()
+
+	// the header is colored once aligned, escape codes would count in the width of the cells
+	line, _ := b.ReadString('\n')
+	if _, err := io.WriteString(w, o.color(ansiFaint, strings.TrimRight(line, "\n"))+"\n"); err != nil {
+		return err
+	}
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// color wraps s in an ANSI escape code when the output is colored
+func (o PrintOptions) color(code, s string) string {
+	if !o.Color {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// entityTypes returns the first types of an entity, and the number of the others
+func entityTypes(types []string) string {
+	if len(types) <= printedTypes {
+		return strings.Join(types, ", ")
+	}
+	return fmt.Sprintf("%s +%d", strings.Join(types[:printedTypes], ", "), len(types)-printedTypes)
+}
@@ -0,0 +1,69 @@
+package textrazor
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Pretty printer tests
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestFprint(t *testing.T) {
+	var b bytes.Buffer
+	if err := Fprint(&b, decodeAnalysis(t, textrazortest.AnalysisFull)); err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{
+		"Language: eng (reliable)\n",
+		"\nEntities:\nID  ENTITY                   TEXT          TYPES                            RELEVANCE  CONFIDENCE  OFFSETS\n" +
+			"0   Barclays                 Barclays      Agent, Organisation, Company +1  0.725      4.376       0-8\n",
+		"\nTopics:\nLABEL                     SCORE  WIKIDATA\nBanking                   0.949  Q22687\n",
+		"\nCoarse topics:\n",
+		"\nCategories:\nCLASSIFIER           ID        LABEL",
+		"\nSentence 1: Barclays misled shareholders",
+		"\nfound\n├── misled (ccomp)\n│   ├── Barclays (nsubj)\n",
+		"└── . (punct)\n",
+	} {
+		if !strings.Contains(b.String(), expect) {
+			t.Errorf("expect the output to contain\n%s\ngot\n%s", expect, b.String())
+		}
+	}
+
+	b.Reset()
+	if err := Fprint(&b, decodeAnalysis(t, textrazortest.AnalysisCategories)); err != nil || strings.Contains(b.String(), "Entities:") || strings.Contains(b.String(), "Sentence") {
+		t.Error("expect the empty sections to be skipped, got", b.String(), err)
+	}
+	if err := Fprint(failingWriter{}, decodeAnalysis(t, textrazortest.AnalysisFull)); err == nil {
+		t.Error("expect the write error to be returned")
+	}
+}
+
+func TestDependencyTreeFprint(t *testing.T) {
+	s := Sentence{Words: []Word{
+		{Position: 0, Token: "Barclays", RelationToParent: "nsubj", ParentPosition: 1},
+		{Position: 1, Token: "misled"},
+		{Position: 2, Token: "shareholders", RelationToParent: "dobj", ParentPosition: 1},
+		{Position: 3, Token: "badly", RelationToParent: "advmod", ParentPosition: 2},
+	}}
+	var b bytes.Buffer
+	if err := s.DependencyTree().Fprint(&b); err != nil {
+		t.Fatal(err)
+	}
+	expect := "misled\n├── Barclays (nsubj)\n└── shareholders (dobj)\n    └── badly (advmod)\n"
+	if b.String() != expect {
+		t.Errorf("expect\n%s\ngot\n%s", expect, b.String())
+	}
+
+	b.Reset()
+	if err := (PrintOptions{Color: true}).FprintTree(&b, s.DependencyTree()); err != nil || !strings.Contains(b.String(), "├── Barclays \x1b[36m(nsubj)\x1b[0m\n") {
+		t.Errorf("expect colored relations, got %q %v", b.String(), err)
+	}
+}
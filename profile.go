@@ -0,0 +1,83 @@
+package textrazor
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Environment variables read by ProfileFromEnv
+const (
+	ProfileEnv  = "TEXTRAZOR_PROFILE"
+	EndpointEnv = "TEXTRAZOR_ENDPOINT"
+)
+
+// Profile defines the endpoints of a TextRazor deployment, and how to reach them
+type Profile struct {
+	Name           string
+	Endpoint       string
+	SecureEndpoint string
+	UseCompression bool
+	UseEncryption  bool
+}
+
+// ProfileCloud is the public TextRazor API
+var ProfileCloud = Profile{
+	Name:           "cloud",
+	Endpoint:       DefaultEndpoint,
+	SecureEndpoint: DefaultSecureEndpoint,
+	UseCompression: DefaultUseCompression,
+	UseEncryption:  DefaultUseEncryption,
+}
+
+// ProfileSelfHosted returns the profile of a self-hosted TextRazor instance, e.g. "http://textrazor.internal:8080"
+//
+// requests are encrypted if endpoint is a https URL, they are not compressed as the instance is usually on a local network
+func ProfileSelfHosted(endpoint string) (Profile, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return Profile{}, fmt.Errorf("invalid self-hosted endpoint '%s'", endpoint)
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	return Profile{
+		Name:           "selfhosted",
+		Endpoint:       endpoint,
+		SecureEndpoint: endpoint,
+		UseEncryption:  u.Scheme == "https",
+	}, nil
+}
+
+// ProfileFromEnv returns the profile named by TEXTRAZOR_PROFILE, "cloud" (the default) or "selfhosted".
+// The endpoint of a self-hosted instance is read from TEXTRAZOR_ENDPOINT.
+func ProfileFromEnv() (Profile, error) {
+	switch name := strings.ToLower(os.Getenv(ProfileEnv)); name {
+	case "", ProfileCloud.Name:
+		return ProfileCloud, nil
+	case "selfhosted", "self-hosted":
+		endpoint := os.Getenv(EndpointEnv)
+		if endpoint == "" {
+			return Profile{}, fmt.Errorf("%s is required by the selfhosted profile", EndpointEnv)
+		}
+		return ProfileSelfHosted(endpoint)
+	default:
+		return Profile{}, fmt.Errorf("unknown profile '%s'", name)
+	}
+}
+
+// NewProfileClient returns a TextRazor client for the given profile, with the default transport
+func NewProfileClient(apiKey string, p Profile, opts ...Option) *Client {
+	return NewCustomClient(apiKey, p.UseCompression, p.UseEncryption, p.Endpoint, p.SecureEndpoint, DefaultTransport(p.UseCompression), opts...)
+}
+
+// WithProfile sets the endpoints, the encryption and the compression of the given profile.
+// Compression is turned on or off through the useCompression field of the client,
+// which sets the Accept-Encoding header of the requests.
+func WithProfile(p Profile) Option {
+	return func(c *Client) {
+		c.Endpoint = p.Endpoint
+		c.SecureEndpoint = p.SecureEndpoint
+		c.UseEncryption = p.UseEncryption
+		c.useCompression = p.UseCompression
+	}
+}
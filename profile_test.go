@@ -0,0 +1,59 @@
+package textrazor
+
+import (
+	"net/http"
+	"os"
+	"testing"
+)
+
+//***************************************************************
+// 			Profile tests
+
+func TestProfileFromEnv(t *testing.T) {
+	var tests = []struct {
+		profile, endpoint string
+		shouldFail        bool
+		expect            Profile
+	}{
+		{"", "", false, ProfileCloud},
+		{"CLOUD", "", false, ProfileCloud},
+		{"selfhosted", "http://textrazor.internal:8080/", false, Profile{Name: "selfhosted", Endpoint: "http://textrazor.internal:8080", SecureEndpoint: "http://textrazor.internal:8080"}},
+		{"self-hosted", "https://textrazor.internal", false, Profile{Name: "selfhosted", Endpoint: "https://textrazor.internal", SecureEndpoint: "https://textrazor.internal", UseEncryption: true}},
+		{"selfhosted", "", true, Profile{}},
+		{"selfhosted", "textrazor.internal", true, Profile{}},
+		{"cloudeu", "", true, Profile{}},
+	}
+
+	defer os.Unsetenv(ProfileEnv)
+	defer os.Unsetenv(EndpointEnv)
+	for _, tt := range tests {
+		os.Setenv(ProfileEnv, tt.profile)
+		os.Setenv(EndpointEnv, tt.endpoint)
+		p, err := ProfileFromEnv()
+		if (err != nil) != tt.shouldFail {
+			t.Error(tt.profile, tt.endpoint, "expect failure", tt.shouldFail, "got", err)
+		}
+		if p != tt.expect {
+			t.Error(tt.profile, tt.endpoint, "expect", tt.expect, "got", p)
+		}
+	}
+}
+
+func TestProfileClient(t *testing.T) {
+	p, err := ProfileSelfHosted("http://textrazor.internal:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewProfileClient(testAPIKey, p)
+	if client.endpointURL() != "http://textrazor.internal:8080" || client.useCompression {
+		t.Error("expect an uncompressed client for", p.Endpoint, "got", client.endpointURL())
+	}
+	if transport, ok := client.httpTransport.(*http.Transport); !ok || !transport.DisableCompression {
+		t.Error("expect the transport to disable compression")
+	}
+
+	client = NewClient(testAPIKey, WithProfile(p))
+	if client.endpointURL() != "http://textrazor.internal:8080" || client.UseEncryption {
+		t.Error("expect the profile endpoint, got", client.endpointURL())
+	}
+}
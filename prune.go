@@ -0,0 +1,103 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DeleteWhereOptions configures DeleteDictionaryEntriesWhere, zero values use the defaults
+type DeleteWhereOptions struct {
+	// Concurrency is the maximum number of entries deleted at the same time, DefaultBulkConcurrency by default
+	Concurrency int
+	// DryRun only returns the matching entries, without deleting them
+	DryRun bool
+}
+
+// DeleteEntriesError is returned by DeleteDictionaryEntriesWhere when some deletions failed, the others are done
+type DeleteEntriesError struct {
+	// Errors maps the id of the entries which weren't deleted to their error
+	Errors map[string]error
+}
+
+func (e *DeleteEntriesError) Error() string {
+	ids := e.ids()
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("entry '%s': %v", id, e.Errors[id])
+	}
+	return fmt.Sprintf("%d deletions failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed deletions, so errors.Is and errors.As match any of them
+func (e *DeleteEntriesError) Unwrap() []error {
+	var errs []error
+	for _, id := range e.ids() {
+		errs = append(errs, e.Errors[id])
+	}
+	return errs
+}
+
+func (e *DeleteEntriesError) ids() []string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DeleteDictionaryEntriesWhere deletes the entries of a dictionary for which match returns true,
+// e.g. to clean up stale custom entities, and returns the matching entries.
+// Every entry is listed before the first deletion, so the deletions don't shift the pages.
+//
+// A failed deletion doesn't stop the others, the error is then a *DeleteEntriesError.
+// With DryRun, the matching entries are only returned.
+func (c *Client) DeleteDictionaryEntriesWhere(ctx context.Context, dictID string, match func(DictionaryEntry) bool, o DeleteWhereOptions, opts ...CallOption) ([]DictionaryEntry, error) {
+	var matches []DictionaryEntry
+	it := c.DictionaryEntriesIteratorContext(ctx, dictID, opts...)
+	for it.Next() {
+		if e := it.Entry(); match(e) {
+			matches = append(matches, e)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("dictionary entries listing failed: %w", err)
+	}
+	if o.DryRun {
+		return matches, nil
+	}
+
+	workers := o.Concurrency
+	if workers <= 0 {
+		workers = DefaultBulkConcurrency
+	}
+	var (
+		mu     sync.Mutex
+		failed = map[string]error{}
+	)
+	g, _ := newGroup(ctx, workers, &c.stats.goroutines)
+	for _, e := range matches {
+		id := e.ID
+		g.Go(func() error {
+			err := ctx.Err()
+			if err == nil {
+				_, err = c.DeleteDictionaryEntryContext(ctx, dictID, id, opts...)
+			}
+			if err != nil {
+				mu.Lock()
+				failed[id] = err
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	if len(failed) > 0 {
+		return matches, &DeleteEntriesError{Errors: failed}
+	}
+	return matches, nil
+}
@@ -0,0 +1,57 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			DeleteDictionaryEntriesWhere tests
+
+// failingDeleteTransport fails the deletion of an entry
+type failingDeleteTransport struct{ id string }
+
+func (t failingDeleteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodDelete && strings.HasSuffix(req.URL.Path, "/"+t.id) {
+		return nil, errors.New("connection reset")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestDeleteDictionaryEntriesWhere(t *testing.T) {
+	server := newDictionaryServer(
+		DictionaryEntry{ID: "DEV1", Text: "Ken Thompson", Data: EntryData{"status": {"stale"}}},
+		DictionaryEntry{ID: "DEV2", Text: "Bjarne Stroustrup"},
+		DictionaryEntry{ID: "DEV3", Text: "Rob Pike", Data: EntryData{"status": {"stale"}}},
+		DictionaryEntry{ID: "DEV4", Text: "Robert Griesemer", Data: EntryData{"status": {"stale"}}},
+	)
+	defer server.Close()
+	client := server.client()
+	stale := func(e DictionaryEntry) bool { return e.Data.Get("status") == "stale" }
+
+	matches, err := client.DeleteDictionaryEntriesWhere(context.Background(), dictID, stale, DeleteWhereOptions{DryRun: true})
+	if err != nil || len(matches) != 3 || server.writes != 0 {
+		t.Fatalf("expect 3 matches and no deletion with a dry run, got %d %v, %d writes", len(matches), err, server.writes)
+	}
+
+	failing := NewCustomClient(testAPIKey, DefaultUseCompression, false, server.URL, server.URL, failingDeleteTransport{"DEV3"})
+	matches, err = failing.DeleteDictionaryEntriesWhere(context.Background(), dictID, stale, DeleteWhereOptions{Concurrency: 2})
+	var deleteErr *DeleteEntriesError
+	if !errors.As(err, &deleteErr) || len(deleteErr.Errors) != 1 || deleteErr.Errors["DEV3"] == nil || len(matches) != 3 {
+		t.Fatal("expect the deletion of DEV3 to fail, got", err)
+	}
+	if !strings.HasPrefix(err.Error(), "1 deletions failed: entry 'DEV3': ") {
+		t.Error("unexpected error message", err)
+	}
+	if entries := server.sorted(); len(entries) != 2 || entries[0].ID != "DEV2" || entries[1].ID != "DEV3" {
+		t.Error("expect DEV2 and DEV3 left, got", entries)
+	}
+
+	matches, err = client.DeleteDictionaryEntriesWhere(context.Background(), dictID, stale, DeleteWhereOptions{})
+	if err != nil || len(matches) != 1 || matches[0].ID != "DEV3" || len(server.sorted()) != 1 {
+		t.Error("expect DEV3 deleted, got", matches, err)
+	}
+}
@@ -0,0 +1,124 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// QuotaPolicy defines what a QuotaGuard does once the daily budget is exhausted
+type QuotaPolicy int
+
+// Valid QuotaPolicy values
+const (
+	// QuotaReject returns a QuotaExceededError without sending the request
+	QuotaReject QuotaPolicy = iota
+	// QuotaWait blocks until the budget is reset, or the context is done
+	QuotaWait
+)
+
+// QuotaGuard tracks the requests sent during the day against a daily budget,
+// to avoid overage charges from runaway batch jobs.
+//
+// The budget is reset every day at midnight in Location (UTC by default).
+// It is safe for concurrent use.
+type QuotaGuard struct {
+	Limit    int
+	Policy   QuotaPolicy
+	Location *time.Location
+
+	mu    sync.Mutex
+	used  int
+	reset time.Time
+	now   func() time.Time
+}
+
+// NewQuotaGuard returns a QuotaGuard allowing limit requests per day
+func NewQuotaGuard(limit int, policy QuotaPolicy) *QuotaGuard {
+	return &QuotaGuard{Limit: limit, Policy: policy}
+}
+
+// NewQuotaGuardFromAccount returns a QuotaGuard sized to the daily requests included in the account plan,
+// starting with the requests already used today
+func NewQuotaGuardFromAccount(a *Account, policy QuotaPolicy) *QuotaGuard {
+	g := NewQuotaGuard(a.PlanDailyIncludedRequests, policy)
+	g.used = a.RequestsUsedToday
+	return g
+}
+
+// WithQuotaGuard checks every Analyze call against the budget of g
+func WithQuotaGuard(g *QuotaGuard) Option {
+	return func(c *Client) { c.quota = g }
+}
+
+// Used returns the number of requests counted today
+func (g *QuotaGuard) Used() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.resetIfNeeded()
+	return g.used
+}
+
+// Remaining returns the number of requests left in today's budget
+func (g *QuotaGuard) Remaining() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.resetIfNeeded()
+	if g.used >= g.Limit {
+		return 0
+	}
+	return g.Limit - g.used
+}
+
+// Reserve counts a request against the budget, according to the policy
+// it returns a QuotaExceededError or waits for the reset once the budget is exhausted
+func (g *QuotaGuard) Reserve(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		g.resetIfNeeded()
+		if g.used < g.Limit {
+			g.used++
+			g.mu.Unlock()
+			return nil
+		}
+		reset := g.reset
+		g.mu.Unlock()
+
+		if g.Policy != QuotaWait {
+			return &QuotaExceededError{&APIError{ErrorMessage: fmt.Sprintf("daily budget of %d requests exhausted by the local quota guard", g.Limit)}}
+		}
+		if err := sleep(ctx, reset.Sub(g.clock())); err != nil {
+			return err
+		}
+	}
+}
+
+func (g *QuotaGuard) clock() time.Time {
+	if g.now != nil {
+		return g.now()
+	}
+	return time.Now()
+}
+
+// resetIfNeeded starts a new budget when the day changed, g.mu must be held
+func (g *QuotaGuard) resetIfNeeded() {
+	loc := g.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now := g.clock().In(loc)
+	if g.reset.IsZero() {
+		g.reset = nextMidnight(now)
+		return
+	}
+	if !now.Before(g.reset) {
+		g.used = 0
+		g.reset = nextMidnight(now)
+	}
+}
+
+func nextMidnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,64 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			QuotaGuard tests
+
+func TestQuotaGuardReject(t *testing.T) {
+	g := NewQuotaGuardFromAccount(&Account{PlanDailyIncludedRequests: 500, RequestsUsedToday: 498}, QuotaReject)
+	transport := textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithQuotaGuard(g))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.Remaining() != 0 || g.Used() != 500 {
+		t.Error("expect the budget to be exhausted, got", g.Used(), "used and", g.Remaining(), "remaining")
+	}
+
+	_, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatal("expect ErrQuotaExceeded, got", err)
+	}
+	t.Log(err)
+}
+
+func TestQuotaGuardReset(t *testing.T) {
+	now := time.Date(2017, 3, 14, 23, 59, 0, 0, time.UTC)
+	g := NewQuotaGuard(1, QuotaReject)
+	g.now = func() time.Time { return now }
+
+	if err := g.Reserve(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Reserve(context.Background()); err == nil {
+		t.Fatal("expect the budget to be exhausted")
+	}
+	now = now.Add(time.Minute)
+	if err := g.Reserve(context.Background()); err != nil {
+		t.Error("expect the budget to be reset at midnight, got", err)
+	}
+}
+
+func TestQuotaGuardWait(t *testing.T) {
+	now := time.Now()
+	g := NewQuotaGuard(0, QuotaWait)
+	g.now = func() time.Time { return now }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := g.Reserve(ctx); err != context.DeadlineExceeded {
+		t.Error("expect to wait for the reset until the context is done, got", err)
+	}
+}
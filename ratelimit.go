@@ -0,0 +1,71 @@
+package textrazor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles the requests sent by a Client, Wait blocks until a request is allowed
+//
+// it is satisfied by *rate.Limiter from golang.org/x/time/rate
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter waits for l before every HTTP request, including retries.
+// The same RateLimiter can be shared by several clients.
+func WithRateLimiter(l RateLimiter) Option {
+	return func(c *Client) { c.rateLimiter = l }
+}
+
+// NewRateLimiter returns a RateLimiter allowing perSecond requests per second on average,
+// with bursts of up to burst requests, perSecond <= 0 means no limit. It is safe for concurrent use.
+func NewRateLimiter(perSecond float64, burst int) RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{perSecond: perSecond, burst: float64(burst), tokens: float64(burst)}
+}
+
+// tokenBucket is a minimal token bucket limiter, waiting callers reserve their token upfront
+type tokenBucket struct {
+	perSecond float64
+	burst     float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// Wait implements RateLimiter
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if b.perSecond <= 0 {
+		return ctx.Err()
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.perSecond
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+	b.tokens--
+	missing := -b.tokens
+	b.mu.Unlock()
+
+	if missing <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, time.Duration(missing/b.perSecond*float64(time.Second))); err != nil {
+		// give the reserved token back
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Rate limiter tests
+
+type countingLimiter struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls++
+	return l.err
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	limiter := &countingLimiter{}
+	transport := textrazortest.NewSequenceTransport(
+		rateLimited,
+		textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account},
+	)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithRateLimiter(limiter), WithRateLimitRetries(1, 0))
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if limiter.calls != 2 {
+		t.Error("expect the limiter to be called for the request and its retry, got", limiter.calls)
+	}
+
+	limiter.err = errors.New("throttled")
+	if _, err := client.GetAccount(); err != limiter.err {
+		t.Error("expect the limiter error, got", err)
+	}
+	if len(transport.Requests()) != 2 {
+		t.Error("expect no request to be sent when the limiter fails, got", len(transport.Requests()))
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(100, 2)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	// 2 requests are allowed immediately, then one every 10ms
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Error("expect 6 requests to take at least 40ms, got", elapsed)
+	}
+
+	unlimited := NewRateLimiter(0, 1)
+	for i := 0; i < 100; i++ {
+		if err := unlimited.Wait(context.Background()); err != nil {
+			t.Fatal("expect a zero rate to be unlimited, got", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := unlimited.Wait(ctx); err != context.Canceled {
+		t.Error("expect a canceled context to be reported, got", err)
+	}
+}
@@ -0,0 +1,112 @@
+package textrazor
+
+// WithResolvedReferences resolves the word positions of every analysis into Word pointers
+// while decoding, see Analysis.ResolveReferences
+func WithResolvedReferences() Option {
+	return func(c *Client) { c.resolveRefs = true }
+}
+
+// newAnalysis returns an empty analysis decoded with the options of the client
+func (c *Client) newAnalysis() *Analysis {
+	return &Analysis{resolveRefs: c.resolveRefs, maxEntities: c.maxEntities, concurrentDecode: c.concurrentDecode}
+}
+
+// UnmarshalJSON decodes an Analysis, caps its entities and resolves its word references when requested by the client
+func (a *Analysis) UnmarshalJSON(b []byte) error {
+	if err := a.decode(b); err != nil {
+		return err
+	}
+	if a.maxEntities > 0 && len(a.Entities) > a.maxEntities {
+		a.Entities = a.capEntities(a.maxEntities)
+	}
+	if a.resolveRefs {
+		a.ResolveReferences()
+	}
+	return nil
+}
+
+// ResolveReferences sets the Words fields of entities, entailments, noun phrases, properties and relations
+// to the words of Sentences at the referenced positions, unknown positions are ignored.
+//
+// The pointers refer to the words in Sentences, it must be called again if Sentences is modified.
+func (a *Analysis) ResolveReferences() {
+	a.resolveRefs = true
+	a.resolveWith(positionResolver(a.wordsByPosition()))
+}
+
+// resolveWith sets the Words fields of the analysis with resolve
+func (a *Analysis) resolveWith(resolve func(positions []int) []*Word) {
+	resolveEntities(a.Entities, resolve)
+	resolveEntailments(a.Entailments, resolve)
+	resolveNounPhrases(a.NounPhrases, resolve)
+	resolveProperties(a.Properties, resolve)
+	resolveRelations(a.Relations, resolve)
+}
+
+// positionResolver returns the words at the given positions, unknown positions are ignored
+func positionResolver(words map[int]*Word) func(positions []int) []*Word {
+	return func(positions []int) []*Word {
+		var ws []*Word
+		for _, p := range positions {
+			if w, ok := words[p]; ok {
+				ws = append(ws, w)
+			}
+		}
+		return ws
+	}
+}
+
+// copyingResolver is like positionResolver but returns copies of the words, a single copy by position
+func copyingResolver(words map[int]*Word) func(positions []int) []*Word {
+	copies := map[int]*Word{}
+	return func(positions []int) []*Word {
+		var ws []*Word
+		for _, p := range positions {
+			c, ok := copies[p]
+			if !ok {
+				w, found := words[p]
+				if !found {
+					continue
+				}
+				copied := cloneWord(*w)
+				c, copies[p] = &copied, &copied
+			}
+			ws = append(ws, c)
+		}
+		return ws
+	}
+}
+
+func resolveEntities(s []Entity, resolve func([]int) []*Word) {
+	for i := range s {
+		s[i].Words = resolve(s[i].MatchingTokens)
+	}
+}
+
+func resolveEntailments(s []Entailment, resolve func([]int) []*Word) {
+	for i := range s {
+		s[i].Words = resolve(s[i].WordPositions)
+	}
+}
+
+func resolveNounPhrases(s []NounPhrase, resolve func([]int) []*Word) {
+	for i := range s {
+		s[i].Words = resolve(s[i].WordPositions)
+	}
+}
+
+func resolveProperties(s []Property, resolve func([]int) []*Word) {
+	for i := range s {
+		s[i].Words = resolve(s[i].WordPositions)
+		s[i].PropertyWords = resolve(s[i].PropertyPositions)
+	}
+}
+
+func resolveRelations(s []Relation, resolve func([]int) []*Word) {
+	for i := range s {
+		s[i].Words = resolve(s[i].WordPositions)
+		for j := range s[i].Params {
+			s[i].Params[j].Words = resolve(s[i].Params[j].WordPositions)
+		}
+	}
+}
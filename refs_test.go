@@ -0,0 +1,59 @@
+package textrazor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Word references tests
+
+func TestResolvedReferences(t *testing.T) {
+	transport := textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisRelations)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithResolvedReferences())
+
+	analysis, err := client.AnalyzeText(textrazortest.Text, Params{"extractors": {"relations"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analysis.Relations) != 2 || len(analysis.Properties) != 2 {
+		t.Fatal("expect 2 relations and 2 properties, got", analysis.Relations, analysis.Properties)
+	}
+
+	r := analysis.Relations[0]
+	if len(r.Words) != 1 || r.Words[0].Token != "misled" {
+		t.Error("expect relation words [misled], got", r.Words)
+	}
+	if len(r.Params) != 2 || len(r.Params[0].Words) != 1 || r.Params[0].Words[0].Token != "Barclays" || len(r.Params[1].Words) != 4 {
+		t.Error("expect a Barclays subject and a 4 words object, got", r.Params)
+	}
+	if r.Words[0] != &analysis.Sentences[0].Words[1] {
+		t.Error("expect words to point into Sentences")
+	}
+
+	p := analysis.Properties[1]
+	if len(p.Words) != 1 || len(p.PropertyWords) != 1 || p.PropertyWords[0].Position != 10 {
+		t.Error("expect property words at 11 and 10, got", p.Words, p.PropertyWords)
+	}
+}
+
+func TestUnresolvedReferences(t *testing.T) {
+	transport := textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisRelations)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	analysis, err := client.AnalyzeText(textrazortest.Text, Params{"extractors": {"relations"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Relations[0].Words != nil {
+		t.Error("expect words to be resolved on demand only, got", analysis.Relations[0].Words)
+	}
+
+	analysis.Entities = []Entity{{MatchingTokens: []int{0, 99}}}
+	analysis.ResolveReferences()
+	if len(analysis.Relations[0].Words) != 1 || len(analysis.Entities[0].Words) != 1 || analysis.Entities[0].Words[0].Token != "Barclays" {
+		t.Error("expect resolved words ignoring unknown positions, got", analysis.Relations[0].Words, analysis.Entities[0].Words)
+	}
+}
@@ -0,0 +1,138 @@
+package textrazor
+
+import "sort"
+
+// Span is the text covered by words of an analysis
+type Span struct {
+	// Text between the first and the last word, or their tokens when the analysis holds no text
+	Text        string
+	StartingPos int
+	EndingPos   int
+	Words       []*Word
+}
+
+// ResolvedRelation is a Relation resolved into text spans
+type ResolvedRelation struct {
+	Predicate Span
+	Subjects  []Span
+	Objects   []Span
+	Others    []Span
+}
+
+// ResolveRelations returns the relations of the analysis resolved into text spans
+func (a *Analysis) ResolveRelations() []ResolvedRelation {
+	words := a.wordsByPosition()
+	resolved := make([]ResolvedRelation, len(a.Relations))
+	for i := range a.Relations {
+		resolved[i] = a.resolveRelation(words, &a.Relations[i])
+	}
+	return resolved
+}
+
+// ResolveRelation returns the predicate, subjects, objects and other params of r as text spans
+func (a *Analysis) ResolveRelation(r *Relation) ResolvedRelation {
+	return a.resolveRelation(a.wordsByPosition(), r)
+}
+
+// WordSpan returns the span of the words at positions, unknown positions are ignored
+func (a *Analysis) WordSpan(positions []int) Span {
+	return a.span(a.wordsByPosition(), positions)
+}
+
+func (a *Analysis) resolveRelation(words map[int]*Word, r *Relation) ResolvedRelation {
+	resolved := ResolvedRelation{Predicate: a.span(words, r.WordPositions)}
+	for _, p := range r.Params {
+		s := a.span(words, p.WordPositions)
+		switch p.Relation {
+		case SUBJECT:
+			resolved.Subjects = append(resolved.Subjects, s)
+		case OBJECT:
+			resolved.Objects = append(resolved.Objects, s)
+		default:
+			resolved.Others = append(resolved.Others, s)
+		}
+	}
+	return resolved
+}
+
+func (a *Analysis) wordsByPosition() map[int]*Word {
+	words := map[int]*Word{}
+	for i := range a.Sentences {
+		for j := range a.Sentences[i].Words {
+			w := &a.Sentences[i].Words[j]
+			words[w.Position] = w
+		}
+	}
+	return words
+}
+
+func (a *Analysis) span(words map[int]*Word, positions []int) Span {
+	var s Span
+	for _, p := range positions {
+		if w, ok := words[p]; ok {
+			s.Words = append(s.Words, w)
+		}
+	}
+	if len(s.Words) == 0 {
+		return s
+	}
+	sort.Slice(s.Words, func(i, j int) bool { return s.Words[i].Position < s.Words[j].Position })
+	s.StartingPos, s.EndingPos = s.Words[0].StartingPos, s.Words[0].EndingPos
+	for _, w := range s.Words[1:] {
+		if w.StartingPos < s.StartingPos {
+			s.StartingPos = w.StartingPos
+		}
+		if w.EndingPos > s.EndingPos {
+			s.EndingPos = w.EndingPos
+		}
+	}
+
+	text := a.CleanedText
+	if text == "" {
+		text = a.RawText
+	}
+	if t, ok := codePointSlice(text, s.StartingPos, s.EndingPos); ok && text != "" {
+		s.Text = t
+		return s
+	}
+	s.Text = joinTokens(s.Words)
+	return s
+}
+
+// Triple is a subject-predicate-object statement extracted from a relation
+type Triple struct {
+	Subject   string
+	Predicate string
+	Object    string
+	// Confidence is 1 when the relation has both a subject and an object, 0.5 when one of them is missing,
+	// the API doesn't score relations
+	Confidence float64
+}
+
+// Triples returns the subject-predicate-object triples of the relations, one per pair of subject and object.
+// Relations without a subject nor an object are skipped.
+func (a *Analysis) Triples() []Triple {
+	var triples []Triple
+	for _, r := range a.ResolveRelations() {
+		if len(r.Subjects) == 0 && len(r.Objects) == 0 {
+			continue
+		}
+		subjects, objects := r.Subjects, r.Objects
+		confidence := 1.0
+		if len(subjects) == 0 || len(objects) == 0 {
+			confidence = 0.5
+		}
+		if len(subjects) == 0 {
+			subjects = []Span{{}}
+		}
+		if len(objects) == 0 {
+			objects = []Span{{}}
+		}
+		for _, s := range subjects {
+			for _, o := range objects {
+				triples = append(triples, Triple{Subject: s.Text, Predicate: r.Predicate.Text, Object: o.Text, Confidence: confidence})
+			}
+		}
+	}
+	return triples
+}
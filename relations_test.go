@@ -0,0 +1,87 @@
+package textrazor
+
+import (
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Relations tests
+
+func TestResolveRelations(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisRelations)
+	if a.Relations[0].Params[0].Relation != SUBJECT || a.Relations[0].Params[1].Relation != OBJECT {
+		t.Fatal("expect a subject and an object, got", a.Relations[0].Params)
+	}
+
+	for _, text := range []string{"", textrazortest.Text} {
+		a.RawText = text
+		relations := a.ResolveRelations()
+		if len(relations) != 2 {
+			t.Fatal("expect 2 relations, got", len(relations))
+		}
+		var tests = []struct {
+			span   Span
+			expect string
+		}{
+			{relations[0].Predicate, "misled"},
+			{relations[0].Subjects[0], "Barclays"},
+			{relations[0].Objects[0], "shareholders and the public"},
+			{relations[1].Predicate, "has found"},
+			{relations[1].Subjects[0], "a BBC Panorama investigation"},
+		}
+		for _, tt := range tests {
+			if tt.span.Text != tt.expect {
+				t.Errorf("expect %q, got %q", tt.expect, tt.span.Text)
+			}
+		}
+		if s := relations[0].Objects[0]; s.StartingPos != 16 || s.EndingPos != 43 || len(s.Words) != 4 {
+			t.Errorf("expect the object to span 16:43 over 4 words, got %d:%d over %d", s.StartingPos, s.EndingPos, len(s.Words))
+		}
+		if len(relations[1].Objects) != 0 || len(relations[1].Others) != 0 {
+			t.Error("expect a relation with a single subject, got", relations[1])
+		}
+	}
+}
+
+func TestWordSpan(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisRelations)
+	if s := a.WordSpan([]int{100}); s.Text != "" || s.Words != nil {
+		t.Error("expect unknown positions to give an empty span, got", s)
+	}
+	if s := a.WordSpan([]int{20, 19}); s.Text != "BBC Panorama" {
+		t.Error("expect BBC Panorama, got", s.Text)
+	}
+}
+
+func TestTriples(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisRelations)
+	a.Relations = append(a.Relations,
+		Relation{WordPositions: []int{1}, Params: []RelationParam{{WordPositions: []int{7}, Relation: OTHER}}},
+		Relation{WordPositions: []int{1}, Params: []RelationParam{
+			{WordPositions: []int{0}, Relation: SUBJECT},
+			{WordPositions: []int{19}, Relation: SUBJECT},
+			{WordPositions: []int{5}, Relation: OBJECT},
+		}},
+	)
+
+	expect := []Triple{
+		{"Barclays", "misled", "shareholders and the public", 1},
+		{"a BBC Panorama investigation", "has found", "", 0.5},
+		{"Barclays", "misled", "public", 1},
+		{"BBC", "misled", "public", 1},
+	}
+	triples := a.Triples()
+	if len(triples) != len(expect) {
+		t.Fatal("expect", len(expect), "triples, got", triples)
+	}
+	for i := range expect {
+		if triples[i] != expect[i] {
+			t.Errorf("expect %+v, got %+v", expect[i], triples[i])
+		}
+	}
+	if triples := (&Analysis{}).Triples(); triples != nil {
+		t.Error("expect no triple without relations, got", triples)
+	}
+}
@@ -0,0 +1,92 @@
+package textrazor
+
+import (
+	"html"
+	"sort"
+	"strings"
+)
+
+// HTMLOptions configures RenderHTML
+type HTMLOptions struct {
+	// Text the entity offsets refer to, CleanedText or RawText of the analysis when empty
+	Text string
+	// Classes maps entity types, e.g. "Person", to the CSS class of their mentions
+	Classes map[string]string
+	// Class of the mentions of entities without a type in Classes, no class when empty
+	Class string
+}
+
+// RenderHTML returns the text of an analysis as escaped HTML, with the entity mentions wrapped in
+// <mark data-entity-type="..." data-entity-id="..." class="..."> elements, e.g. to build review UIs.
+//
+// The type of a mention is the first type of the entity with a class in o.Classes, or its first type.
+// When mentions overlap, the earliest and then the longest is kept. Line breaks are left as is.
+func RenderHTML(a *Analysis, o HTMLOptions) string {
+	text := o.Text
+	if text == "" {
+		text = a.CleanedText
+	}
+	if text == "" {
+		text = a.RawText
+	}
+
+	// byte offset of each code point, and of the end of the text
+	offsets := make([]int, 0, len(text)+1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+
+	entities := make([]*Entity, 0, len(a.Entities))
+	for i := range a.Entities {
+		e := &a.Entities[i]
+		if e.StartingPos >= 0 && e.StartingPos < e.EndingPos && e.EndingPos < len(offsets) {
+			entities = append(entities, e)
+		}
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		if entities[i].StartingPos != entities[j].StartingPos {
+			return entities[i].StartingPos < entities[j].StartingPos
+		}
+		return entities[i].EndingPos > entities[j].EndingPos
+	})
+
+	var b strings.Builder
+	pos := 0
+	for _, e := range entities {
+		if e.StartingPos < pos {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[offsets[pos]:offsets[e.StartingPos]]))
+		b.WriteString("<mark")
+		entityType, class := o.entityClass(e)
+		if entityType != "" {
+			b.WriteString(` data-entity-type="` + html.EscapeString(entityType) + `"`)
+		}
+		if e.EntityID != "" {
+			b.WriteString(` data-entity-id="` + html.EscapeString(e.EntityID) + `"`)
+		}
+		if class != "" {
+			b.WriteString(` class="` + html.EscapeString(class) + `"`)
+		}
+		b.WriteString(">")
+		b.WriteString(html.EscapeString(text[offsets[e.StartingPos]:offsets[e.EndingPos]]))
+		b.WriteString("</mark>")
+		pos = e.EndingPos
+	}
+	b.WriteString(html.EscapeString(text[offsets[pos]:]))
+	return b.String()
+}
+
+// entityClass returns the type and the CSS class of the mentions of an entity
+func (o HTMLOptions) entityClass(e *Entity) (string, string) {
+	for _, t := range e.Types {
+		if class, ok := o.Classes[t]; ok {
+			return t, class
+		}
+	}
+	if len(e.Types) > 0 {
+		return e.Types[0], o.Class
+	}
+	return "", o.Class
+}
@@ -0,0 +1,45 @@
+package textrazor
+
+import (
+	"strings"
+	"testing"
+)
+
+//***************************************************************
+// 			HTML rendering tests
+
+func TestRenderHTML(t *testing.T) {
+	a := &Analysis{
+		CleanedText: "Société Générale <SG> & Barclays",
+		Entities: []Entity{
+			{EntityID: "Barclays", Types: []string{"Organisation", "Company"}, StartingPos: 24, EndingPos: 32},
+			{EntityID: "Société Générale", Types: []string{"Company"}, StartingPos: 0, EndingPos: 16},
+			{EntityID: "Générale", StartingPos: 8, EndingPos: 16},
+			{StartingPos: 18, EndingPos: 20},
+			{EntityID: "out", StartingPos: 32, EndingPos: 40},
+		},
+	}
+	var tests = []struct {
+		name   string
+		opts   HTMLOptions
+		expect string
+	}{
+		{"default", HTMLOptions{},
+			`<mark data-entity-type="Company" data-entity-id="Société Générale">Société Générale</mark> &lt;<mark>SG</mark>&gt; &amp; ` +
+				`<mark data-entity-type="Organisation" data-entity-id="Barclays">Barclays</mark>`},
+		{"classes", HTMLOptions{Classes: map[string]string{"Company": "company"}, Class: "entity"},
+			`<mark data-entity-type="Company" data-entity-id="Société Générale" class="company">Société Générale</mark> &lt;<mark class="entity">SG</mark>&gt; &amp; ` +
+				`<mark data-entity-type="Company" data-entity-id="Barclays" class="company">Barclays</mark>`},
+		{"other text", HTMLOptions{Text: "Barclays"}, "Barclays"},
+	}
+	for _, tt := range tests {
+		if got := RenderHTML(a, tt.opts); got != tt.expect {
+			t.Errorf("%s: expect\n%s\ngot\n%s", tt.name, tt.expect, got)
+		}
+	}
+
+	a.CleanedText, a.RawText = "", "Barclays\n"
+	if got := RenderHTML(a, HTMLOptions{}); !strings.HasSuffix(got, "\n") || strings.Contains(got, "<mark") {
+		t.Error("expect the raw text without mentions, got", got)
+	}
+}
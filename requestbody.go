@@ -0,0 +1,99 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// bodyEncoder is implemented by the request bodies which encode themselves into a buffer,
+// saving the string returned by RequestBody.Encode and its copy
+type bodyEncoder interface {
+	encodeTo(buf *bytes.Buffer) error
+}
+
+// bodyBuffers pools the buffers encoding the request bodies, as analysis loops send many texts
+var bodyBuffers = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// maxPooledBody is the capacity above which a buffer isn't pooled, so a single large text doesn't stay in memory
+const maxPooledBody = 1 << 20
+
+// encodeRequestBody encodes body in a pooled buffer and returns a copy of the encoded body, its only allocation
+// once the pool is warm. The copy is owned by the request, which a transport may read after the call returns.
+func encodeRequestBody(body RequestBody) ([]byte, error) {
+	e, ok := body.(bodyEncoder)
+	if !ok {
+		s, err := body.Encode()
+		return []byte(s), err
+	}
+	buf := bodyBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBody {
+			buf.Reset()
+			bodyBuffers.Put(buf)
+		}
+	}()
+	if err := e.encodeTo(buf); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), buf.Bytes()...), nil
+}
+
+// encodeTo writes the params URL encoded in buf, like Encode
+func (p Params) encodeTo(buf *bytes.Buffer) error {
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	start := buf.Len()
+	for _, k := range keys {
+		for _, v := range p[k] {
+			if buf.Len() > start {
+				buf.WriteByte('&')
+			}
+			appendQueryEscape(buf, k)
+			buf.WriteByte('=')
+			appendQueryEscape(buf, v)
+		}
+	}
+	return nil
+}
+
+// appendQueryEscape writes s escaped like url.QueryEscape in buf, without the escaped copy
+func appendQueryEscape(buf *bytes.Buffer, s string) {
+	const hex = "0123456789ABCDEF"
+	buf.Grow(len(s))
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			continue
+		}
+		buf.WriteString(s[start:i])
+		start = i + 1
+		if c == ' ' {
+			buf.WriteByte('+')
+		} else {
+			buf.Write([]byte{'%', hex[c>>4], hex[c&15]})
+		}
+	}
+	buf.WriteString(s[start:])
+}
+
+// encodeTo writes the dictionary encoded in JSON in buf, like Encode
+func (d *Dictionary) encodeTo(buf *bytes.Buffer) error { return encodeJSONTo(buf, d) }
+
+// encodeTo writes the entries encoded in JSON in buf, like Encode
+func (l *DictionaryEntryList) encodeTo(buf *bytes.Buffer) error { return encodeJSONTo(buf, l.Entries) }
+
+// encodeJSONTo writes v encoded in JSON in buf, like json.Marshal
+func encodeJSONTo(buf *bytes.Buffer, v interface{}) error {
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return err
+	}
+	// unlike json.Marshal, Encode ends the value with a newline
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
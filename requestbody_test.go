@@ -0,0 +1,73 @@
+package textrazor
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Request body tests
+
+func TestEncodeRequestBody(t *testing.T) {
+	var tests = []struct {
+		name string
+		body RequestBody
+	}{
+		{"params", Params{"text": {"Barclays misled shareholders & the public: 100% «été» ~a_b-c.d/e?f=g+h"}, "extractors": {"entities", "topics"}, "a b": {""}}},
+		{"empty params", Params{}},
+		{"dictionary", &Dictionary{ID: "dict", MatchType: DictionaryMatchToken, Extra: map[string]json.RawMessage{"new": json.RawMessage(`"<b>"`)}}},
+		{"entries", &DictionaryEntryList{Entries: []DictionaryEntry{{ID: "1", Text: "a <b>", Data: EntryData{"k": {"v"}}}}}},
+		{"raw", &rawRequest{Body: `{"raw": true}`}},
+	}
+	for _, tt := range tests {
+		expect, err := tt.body.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// twice, the second time with a pooled buffer
+		for i := 0; i < 2; i++ {
+			b, err := encodeRequestBody(tt.body)
+			if err != nil || string(b) != expect {
+				t.Errorf("%s: expect %q, got %q %v", tt.name, expect, b, err)
+			}
+		}
+	}
+
+	// large bodies aren't kept by the pool
+	if _, err := encodeRequestBody(Params{"text": {strings.Repeat("x", maxPooledBody+1)}}); err != nil {
+		t.Fatal(err)
+	}
+	if buf := bodyBuffers.Get().(*bytes.Buffer); buf.Cap() > maxPooledBody {
+		t.Errorf("expect no large buffer in the pool, got %d bytes", buf.Cap())
+	}
+
+	if textrazortest.RaceEnabled {
+		t.Skip("allocations aren't counted with the race detector")
+	}
+	params := Params{"text": {strings.Repeat("Barclays misled shareholders. ", 100)}, "extractors": {"entities", "topics"}}
+	encodeRequestBody(params)
+	if allocs := testing.AllocsPerRun(100, func() { encodeRequestBody(params) }); allocs > 1 {
+		t.Errorf("expect the body to be the only allocation, got %v", allocs)
+	}
+}
+
+func BenchmarkEncodeRequestBody(b *testing.B) {
+	params := Params{"text": {strings.Repeat("Barclays misled shareholders. ", 100)}, "extractors": {"entities", "topics"}}
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			encodeRequestBody(params)
+		}
+	})
+	b.Run("Encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s, _ := params.Encode()
+			_ = []byte(s)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRetryWait is the delay before retrying a rate limited request when the API doesn't specify one,
+// it doubles on each attempt
+var defaultRetryWait = time.Second
+
+// retryAfterMessage matches delays in error messages such as "retry in 5 seconds"
+var retryAfterMessage = regexp.MustCompile(`(\d+)\s*(?:s\b|sec|second)`)
+
+// WithRateLimitRetries retries rate limited requests up to maxRetries times,
+// waiting for the delay requested by the API (Retry-After header or error message)
+// or 1 second doubled on each attempt.
+//
+// A request asking to wait longer than maxWait isn't retried, 0 means no limit.
+func WithRateLimitRetries(maxRetries int, maxWait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.maxRetryWait = maxWait
+	}
+}
+
+// retryWait returns the delay before the next attempt, and whether the failed attempt should be retried
+func (c *Client) retryWait(err error, attempt int) (time.Duration, bool) {
+	var rateErr *RateLimitError
+	if err == nil || attempt >= c.maxRetries || !errors.As(err, &rateErr) {
+		return 0, false
+	}
+	wait := rateErr.RetryAfter
+	if wait <= 0 {
+		wait = defaultRetryWait << uint(attempt)
+	}
+	if c.maxRetryWait > 0 && wait > c.maxRetryWait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryAfter returns the delay requested by a rate limited response,
+// from the Retry-After header (in seconds or as a HTTP date) or from the error message
+func retryAfter(r *HTTPResponse) time.Duration {
+	if v := strings.TrimSpace(r.Headers.Get("Retry-After")); v != "" {
+		if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+			return time.Duration(s) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+			return 0
+		}
+	}
+	if m := retryAfterMessage.FindStringSubmatch(strings.ToLower(r.Error + " " + r.Message)); m != nil {
+		s, _ := strconv.Atoi(m[1])
+		return time.Duration(s) * time.Second
+	}
+	return 0
+}
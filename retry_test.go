@@ -0,0 +1,101 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Rate limit retries tests
+
+var rateLimited = textrazortest.Reply{Status: http.StatusTooManyRequests, Body: `{"ok":false,"error":"Too many requests"}`}
+
+func TestRateLimitRetries(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	transport := textrazortest.NewSequenceTransport(rateLimited, rateLimited, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithRateLimitRetries(2, time.Second))
+	account, err := client.GetAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Plan != "FREE" {
+		t.Error("expect account.Plan == FREE, got", account.Plan)
+	}
+	if n := len(transport.Requests()); n != 3 {
+		t.Error("expect 3 attempts, got", n)
+	}
+	if transport.Requests()[2].Header.Get(apiKeyHeader) != testAPIKey {
+		t.Error("expect the API key to be sent once on retries, got", transport.Requests()[2].Header[apiKeyHeader])
+	}
+}
+
+func TestRateLimitNoRetries(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(rateLimited, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+	_, err := client.GetAccount()
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatal("expect a RateLimitError, got", err)
+	}
+	if n := len(transport.Requests()); n != 1 {
+		t.Error("expect 1 attempt, got", n)
+	}
+}
+
+func TestRateLimitRetryTooLong(t *testing.T) {
+	reply := textrazortest.Reply{Status: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"120"}}, Body: `{"ok":false}`}
+	transport := textrazortest.NewSequenceTransport(reply)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithRateLimitRetries(3, time.Minute))
+	_, err := client.GetAccount()
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || rateErr.RetryAfter != 2*time.Minute {
+		t.Fatal("expect a RateLimitError with RetryAfter == 2m, got", err)
+	}
+	if n := len(transport.Requests()); n != 1 {
+		t.Error("expect 1 attempt, got", n)
+	}
+}
+
+func TestRateLimitRetryCanceled(t *testing.T) {
+	reply := textrazortest.Reply{Status: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"60"}}, Body: `{"ok":false}`}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewSequenceTransport(reply), WithRateLimitRetries(1, 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetAccountContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expect context.DeadlineExceeded, got", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		header  string
+		message string
+		want    time.Duration
+	}{
+		{"5", "", 5 * time.Second},
+		{"", "Too many concurrent requests, retry in 3 seconds", 3 * time.Second},
+		{"", "Too many concurrent requests", 0},
+		{"garbage", "", 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), "", 0},
+	}
+	for _, tst := range tests {
+		r := &HTTPResponse{Headers: http.Header{}, Error: tst.message}
+		if tst.header != "" {
+			r.Headers.Set("Retry-After", tst.header)
+		}
+		if got := retryAfter(r); got != tst.want {
+			t.Errorf("retryAfter(%q, %q) = %v, want %v", tst.header, tst.message, got, tst.want)
+		}
+	}
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := retryAfter(&HTTPResponse{Headers: http.Header{"Retry-After": {future}}}); got < 58*time.Minute || got > time.Hour {
+		t.Error("expect about 1h for a HTTP date, got", got)
+	}
+}
@@ -0,0 +1,128 @@
+package textrazor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ArchivedSample is an analysis request and its response, archived by a Sampler.
+// It never holds the API key, sent in a header.
+type ArchivedSample struct {
+	Time     time.Time `json:"time"`
+	Language string    `json:"language"`
+	// Categories are the ids of the categories of the analysis
+	Categories []string        `json:"categories,omitempty"`
+	Params     Params          `json:"params"`
+	Status     int             `json:"status"`
+	Response   json.RawMessage `json:"response"`
+}
+
+// SampleSink stores the samples of a Sampler
+type SampleSink interface {
+	Archive(ctx context.Context, s *ArchivedSample) error
+}
+
+// SampleSinkFunc is a function implementing SampleSink
+type SampleSinkFunc func(ctx context.Context, s *ArchivedSample) error
+
+// Archive implements SampleSink
+func (f SampleSinkFunc) Archive(ctx context.Context, s *ArchivedSample) error { return f(ctx, s) }
+
+// JSONLinesSink returns a SampleSink writing each sample as a line of JSON to w
+func JSONLinesSink(w io.Writer) SampleSink {
+	var mu sync.Mutex
+	return SampleSinkFunc(func(ctx context.Context, s *ArchivedSample) error {
+		b, err := json.Marshal(s)
+		if err != nil {
+			return fmt.Errorf("sample encoding failed: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, err = w.Write(append(b, '\n'))
+		return err
+	})
+}
+
+// Sampler archives a sample of the successful analyses of a client, for quality audits.
+//
+// The sampling rate can be stratified, to archive enough samples of rare languages or categories:
+// the highest rate of the categories of an analysis applies, then the rate of its language, then Rate.
+type Sampler struct {
+	// Rate is the fraction of the analyses archived, from 0 to 1
+	Rate float64
+	// LanguageRates are the rates by language (ISO 639-2 code)
+	LanguageRates map[string]float64
+	// CategoryRates are the rates by category id
+	CategoryRates map[string]float64
+	Sink          SampleSink
+	// Sanitize, if set, edits a sample before it is archived, e.g. to mask personal data in the text
+	Sanitize func(*ArchivedSample)
+	// OnError, if set, is called with the errors of the sink, the analysis succeeds anyway
+	OnError func(error)
+
+	sample func() float64
+	now    func() time.Time
+}
+
+// NewSampler returns a Sampler archiving a fraction rate of the analyses to sink
+func NewSampler(rate float64, sink SampleSink) *Sampler {
+	return &Sampler{Rate: rate, Sink: sink}
+}
+
+// WithSampler archives a sample of the analyses
+func WithSampler(s *Sampler) Option {
+	return func(c *Client) {
+		c.sampler = s
+	}
+}
+
+// rate returns the sampling rate of an analysis
+func (s *Sampler) rate(a *Analysis) float64 {
+	found, rate := false, 0.0
+	for _, cat := range a.Categories {
+		if r, ok := s.CategoryRates[cat.CategoryID]; ok && (!found || r > rate) {
+			found, rate = true, r
+		}
+	}
+	if found {
+		return rate
+	}
+	if r, ok := s.LanguageRates[a.Language]; ok {
+		return r
+	}
+	return s.Rate
+}
+
+// archive sends an analysis to the sink if it is sampled
+func (s *Sampler) archive(ctx context.Context, params Params, a *Analysis) {
+	sample, now := s.sample, s.now
+	if sample == nil {
+		sample = rand.Float64
+	}
+	if now == nil {
+		now = time.Now
+	}
+	if s.Sink == nil || sample() >= s.rate(a) {
+		return
+	}
+
+	archived := &ArchivedSample{Time: now(), Language: a.Language, Params: copyParams(params)}
+	for _, cat := range a.Categories {
+		archived.Categories = append(archived.Categories, cat.CategoryID)
+	}
+	if a.HTTPResponse != nil {
+		archived.Status = a.HTTPResponse.Status
+		archived.Response = append(json.RawMessage(nil), a.HTTPResponse.Body...)
+	}
+	if s.Sanitize != nil {
+		s.Sanitize(archived)
+	}
+	if err := s.Sink.Archive(ctx, archived); err != nil && s.OnError != nil {
+		s.OnError(fmt.Errorf("sample archiving failed: %w", err))
+	}
+}
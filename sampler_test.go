@@ -0,0 +1,102 @@
+package textrazor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Sampler tests
+
+func TestSampler(t *testing.T) {
+	var buf bytes.Buffer
+	sampler := NewSampler(0.5, JSONLinesSink(&buf))
+	sampler.Sanitize = func(s *ArchivedSample) { s.Params.Set("text", "REDACTED") }
+	sampler.now = func() time.Time { return time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC) }
+	samples := []float64{0.1, 0.9, 0.3}
+	sampler.sample = func() float64 {
+		s := samples[0]
+		samples = samples[1:]
+		return s
+	}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisCategories), WithSampler(sampler))
+
+	params := Params{"extractors": {"entities"}}
+	for i := 0; i < 3; i++ {
+		if _, err := client.AnalyzeText(testText, params); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatal("expect 2 of the 3 analyses to be archived, got", lines)
+	}
+	var s ArchivedSample
+	if err := json.Unmarshal([]byte(lines[0]), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Params.Get("text") != "REDACTED" || s.Params.Get("extractors") != "entities" || s.Status != http.StatusOK || !s.Time.Equal(sampler.now()) || len(s.Categories) != 3 {
+		t.Errorf("unexpected sample %+v", s)
+	}
+	if strings.Contains(buf.String(), testAPIKey) {
+		t.Error("expect the API key not to be archived")
+	}
+	var a Analysis
+	if err := json.Unmarshal(s.Response, &struct{ Response *Analysis }{&a}); err != nil || len(a.Categories) != 3 {
+		t.Error("expect the archived response to hold the analysis, got", a.Categories, err)
+	}
+	if params.Get("text") != testText {
+		t.Error("expect the sanitizer to edit a copy of the params")
+	}
+}
+
+func TestSamplerRate(t *testing.T) {
+	sampler := &Sampler{
+		Rate:          0.1,
+		LanguageRates: map[string]float64{"fre": 0.5},
+		CategoryRates: map[string]float64{"01000000": 1, "04000000": 0.2},
+	}
+	var tests = []struct {
+		language   string
+		categories []string
+		expect     float64
+	}{
+		{"eng", nil, 0.1},
+		{"fre", nil, 0.5},
+		{"fre", []string{"11000000"}, 0.5},
+		{"fre", []string{"04000000"}, 0.2},
+		{"eng", []string{"04000000", "01000000"}, 1},
+	}
+	for _, tt := range tests {
+		a := &Analysis{Language: tt.language}
+		for _, id := range tt.categories {
+			a.Categories = append(a.Categories, ScoredCategory{CategoryID: id})
+		}
+		if got := sampler.rate(a); got != tt.expect {
+			t.Error(tt.language, tt.categories, "expect", tt.expect, "got", got)
+		}
+	}
+}
+
+func TestSamplerSinkError(t *testing.T) {
+	var archiveErr error
+	sampler := NewSampler(1, SampleSinkFunc(func(ctx context.Context, s *ArchivedSample) error { return errors.New("disk full") }))
+	sampler.OnError = func(err error) { archiveErr = err }
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities), WithSampler(sampler))
+
+	if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Error("expect the analysis to succeed, got", err)
+	}
+	if archiveErr == nil || !strings.Contains(archiveErr.Error(), "disk full") {
+		t.Error("expect the sink error to be reported, got", archiveErr)
+	}
+}
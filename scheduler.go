@@ -0,0 +1,234 @@
+package textrazor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Scheduler shares the concurrency of an account between tenants, e.g. the clients of several teams using one API key,
+// with weighted fair queuing: when requests are waiting, slots are granted to the tenants in proportion to their weight,
+// whatever the number of requests they queue, so a batch job can't monopolize the account.
+//
+// It is safe for concurrent use, and shared by the clients created with WithScheduler.
+type Scheduler struct {
+	mu    sync.Mutex
+	limit int
+	inUse int
+	// virtual time, the tag of the last granted request
+	vtime   float64
+	tenants map[string]*tenantQueue
+	lookup  accountLimit
+}
+
+// TenantStats are the scheduling statistics of a tenant
+type TenantStats struct {
+	Weight float64
+	// Granted is the number of slots granted, Waiting the number of requests queued, InUse the slots held
+	Granted int64
+	Waiting int
+	InUse   int
+	// TotalWait and MaxWait are the total and longest time waited for a slot
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// MeanWait returns the average time waited for a slot
+func (s TenantStats) MeanWait() time.Duration {
+	if s.Granted == 0 {
+		return 0
+	}
+	return s.TotalWait / time.Duration(s.Granted)
+}
+
+type tenantQueue struct {
+	weight float64
+	// tag of the last request of the tenant
+	last    float64
+	waiters []*schedulerWaiter
+	stats   TenantStats
+}
+
+type schedulerWaiter struct {
+	tag float64
+	// cost is the virtual time added to the tags of the tenant by the request, undone if it is canceled
+	cost    float64
+	start   time.Time
+	ready   chan struct{}
+	granted bool
+}
+
+// NewScheduler returns a Scheduler granting limit concurrent requests,
+// sized from the ConcurrentRequestLimit of the account on the first request if limit <= 0
+func NewScheduler(limit int) *Scheduler {
+	return &Scheduler{limit: limit, tenants: map[string]*tenantQueue{}}
+}
+
+// WithScheduler schedules the analyses of the client with s, on behalf of tenant.
+// The tenant can be changed for a single call with CallTenant.
+func WithScheduler(s *Scheduler, tenant string) Option {
+	return func(c *Client) {
+		c.scheduler = s
+		c.tenant = tenant
+	}
+}
+
+// CallTenant schedules a single call on behalf of tenant, see WithScheduler
+func CallTenant(tenant string) CallOption {
+	return func(o *callOptions) { o.tenant = tenant }
+}
+
+// SetWeight sets the share of the slots of a tenant, relative to the other tenants, 1 by default
+func (s *Scheduler) SetWeight(tenant string, weight float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if weight <= 0 {
+		weight = 1
+	}
+	s.tenant(tenant).weight = weight
+}
+
+// Stats returns the statistics of every tenant
+func (s *Scheduler) Stats() map[string]TenantStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make(map[string]TenantStats, len(s.tenants))
+	for name, t := range s.tenants {
+		st := t.stats
+		st.Weight, st.Waiting = t.weight, len(t.waiters)
+		stats[name] = st
+	}
+	return stats
+}
+
+// Acquire waits for a slot for tenant, the returned function releases it
+func (s *Scheduler) Acquire(ctx context.Context, tenant string) (func(), error) {
+	return s.acquire(ctx, tenant, nil)
+}
+
+// acquire is like Acquire, sizing the scheduler from the account of c when needed
+func (s *Scheduler) acquire(ctx context.Context, tenant string, c *Client) (func(), error) {
+	if err := s.init(ctx, c); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	t := s.tenant(tenant)
+	w := &schedulerWaiter{start: time.Now(), ready: make(chan struct{})}
+	// an idle tenant starts at the current virtual time, it doesn't bank credit
+	if t.last < s.vtime {
+		t.last = s.vtime
+	}
+	w.cost = 1 / t.weight
+	t.last += w.cost
+	w.tag = t.last
+	t.waiters = append(t.waiters, w)
+	s.dispatch()
+	s.mu.Unlock()
+
+	release := func() { s.release(tenant) }
+	select {
+	case <-w.ready:
+		return release, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		granted := w.granted
+		if !granted {
+			t.cancel(w)
+		}
+		s.mu.Unlock()
+		// the slot may have been granted while the context was canceled
+		if granted {
+			release()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// init sizes the scheduler from the account plan, see accountLimit
+func (s *Scheduler) init(ctx context.Context, c *Client) error {
+	s.mu.Lock()
+	limit := s.limit
+	s.mu.Unlock()
+	if limit > 0 {
+		return nil
+	}
+	limit = 1
+	if c != nil {
+		var err error
+		if limit, err = s.lookup.get(ctx, c); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit <= 0 {
+		s.limit = limit
+	}
+	return nil
+}
+
+func (s *Scheduler) release(tenant string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inUse--
+	s.tenants[tenant].stats.InUse--
+	s.dispatch()
+}
+
+// dispatch grants the free slots to the waiting requests with the smallest tags
+func (s *Scheduler) dispatch() {
+	for s.inUse < s.limit {
+		var next *tenantQueue
+		for _, t := range s.tenants {
+			if len(t.waiters) == 0 {
+				continue
+			}
+			// ties go to the oldest request
+			if w := t.waiters[0]; next == nil || w.tag < next.waiters[0].tag || w.tag == next.waiters[0].tag && w.start.Before(next.waiters[0].start) {
+				next = t
+			}
+		}
+		if next == nil {
+			return
+		}
+		w := next.waiters[0]
+		next.waiters = next.waiters[1:]
+		s.inUse++
+		s.vtime = w.tag
+		wait := time.Since(w.start)
+		next.stats.Granted++
+		next.stats.InUse++
+		next.stats.TotalWait += wait
+		if wait > next.stats.MaxWait {
+			next.stats.MaxWait = wait
+		}
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+// cancel removes a waiter which wasn't granted and undoes its virtual time,
+// so canceled requests don't use up the share of the tenant
+func (t *tenantQueue) cancel(w *schedulerWaiter) {
+	for i, other := range t.waiters {
+		if other == w {
+			// the tags of the later requests of the tenant include its cost
+			for _, later := range t.waiters[i+1:] {
+				later.tag -= w.cost
+			}
+			t.waiters = append(t.waiters[:i], t.waiters[i+1:]...)
+			t.last -= w.cost
+			return
+		}
+	}
+}
+
+func (s *Scheduler) tenant(name string) *tenantQueue {
+	t, ok := s.tenants[name]
+	if !ok {
+		t = &tenantQueue{weight: 1}
+		s.tenants[name] = t
+	}
+	return t
+}
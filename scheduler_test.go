@@ -0,0 +1,223 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			Scheduler tests
+
+// schedulerOrder queues requests of tenants, in order, on a scheduler with a single held slot,
+// and returns the tenants in the order their slots are granted
+func schedulerOrder(t *testing.T, s *Scheduler, tenants ...string) string {
+	hold, err := s.Acquire(context.Background(), "holder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		mu    sync.Mutex
+		order []string
+		wg    sync.WaitGroup
+	)
+	for i, tenant := range tenants {
+		wg.Add(1)
+		go func(tenant string) {
+			defer wg.Done()
+			release, err := s.Acquire(context.Background(), tenant)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			order = append(order, tenant)
+			mu.Unlock()
+			release()
+		}(tenant)
+		// wait for the request to be queued, to queue them in order
+		for waiting(s) != i+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	hold()
+	wg.Wait()
+	return strings.Join(order, " ")
+}
+
+func waiting(s *Scheduler) int {
+	n := 0
+	for _, st := range s.Stats() {
+		n += st.Waiting
+	}
+	return n
+}
+
+func TestSchedulerFairness(t *testing.T) {
+	var tests = []struct {
+		name    string
+		weights map[string]float64
+		tenants string
+		expect  string
+	}{
+		{"fifo", nil, "a a a", "a a a"},
+		{"equal weights", nil, "batch batch batch batch web web", "batch web batch web batch batch"},
+		{"weighted", map[string]float64{"batch": 3}, "batch batch batch batch batch batch web web", "batch batch batch web batch batch batch web"},
+		{"late tenant", nil, "batch batch batch batch batch web", "batch web batch batch batch batch"},
+	}
+	for _, tt := range tests {
+		s := NewScheduler(1)
+		for tenant, w := range tt.weights {
+			s.SetWeight(tenant, w)
+		}
+		if got := schedulerOrder(t, s, strings.Fields(tt.tenants)...); got != tt.expect {
+			t.Errorf("%s: expect %s, got %s", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestSchedulerStats(t *testing.T) {
+	s := NewScheduler(1)
+	s.SetWeight("web", 2)
+	hold, _ := s.Acquire(context.Background(), "batch")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(ctx, "web"); err != context.DeadlineExceeded {
+		t.Error("expect the deadline to be exceeded, got", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		release, err := s.Acquire(context.Background(), "web")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		release()
+	}()
+	for waiting(s) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	hold()
+	<-done
+
+	stats := s.Stats()
+	if b := stats["batch"]; b.Granted != 1 || b.InUse != 0 || b.Weight != 1 || b.MaxWait > 10*time.Millisecond {
+		t.Errorf("unexpected batch stats %+v", b)
+	}
+	if w := stats["web"]; w.Granted != 1 || w.Waiting != 0 || w.InUse != 0 || w.Weight != 2 || w.MaxWait < 10*time.Millisecond || w.MeanWait() != w.MaxWait {
+		t.Errorf("unexpected web stats %+v", w)
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	s := NewScheduler(1)
+	hold, _ := s.Acquire(context.Background(), "batch")
+
+	// canceled requests don't use up the share of the tenant
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		s.Acquire(ctx, "web")
+		cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan struct{})
+	go func() {
+		defer close(canceled)
+		s.Acquire(ctx, "web")
+	}()
+	for waiting(s) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	queued := make(chan struct{})
+	go func() {
+		defer close(queued)
+		release, err := s.Acquire(context.Background(), "web")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		release()
+	}()
+	for waiting(s) != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-canceled
+
+	s.mu.Lock()
+	web := s.tenants["web"]
+	if len(web.waiters) != 1 || web.waiters[0].tag != 2 || web.last != 2 {
+		t.Errorf("expect the canceled requests to be undone, got the tag %v", web.last)
+	}
+	s.mu.Unlock()
+	hold()
+	<-queued
+}
+
+func TestSchedulerLookup(t *testing.T) {
+	s := NewScheduler(0)
+	transport := &stalledTransport{started: make(chan struct{}, 2)}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := s.acquire(ctx, "batch", client)
+		done <- err
+	}()
+	<-transport.started
+
+	// the lookup of a tenant doesn't block the others, which observe their own context
+	if st := s.Stats(); len(st) != 0 {
+		t.Error("expect no tenant during the lookup, got", st)
+	}
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	if _, err := s.acquire(short, "web", client); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expect the deadline of the second tenant to be exceeded, got", err)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Error("expect the lookup to be canceled, got", err)
+	}
+}
+
+func TestClientScheduler(t *testing.T) {
+	transport := &concurrencyTransport{}
+	s := NewScheduler(0)
+	batch := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithScheduler(s, "batch"))
+	web := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithScheduler(s, "web"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := batch.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := web.AnalyzeTextContext(context.Background(), testText, Params{"extractors": {"entities"}}, CallTenant("admin")); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the limit of the account fixture is 2
+	if transport.max != 2 || transport.accountCalls != 1 {
+		t.Error("expect 2 concurrent requests and 1 account lookup, got", transport.max, transport.accountCalls)
+	}
+	stats := s.Stats()
+	if stats["batch"].Granted != 8 || stats["admin"].Granted != 8 || stats["web"].Granted != 0 {
+		t.Errorf("expect 8 requests for batch and admin, got %+v", stats)
+	}
+}
@@ -0,0 +1,165 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// schemaFindings returns the differences between a JSON HTTP body and the structs used to decode it
+//
+// each finding is a path followed by a description, e.g. "response.entities[].startingPos: unmodeled field"
+func schemaFindings(body []byte, response Response) ([]string, error) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+
+	w := &schemaWalker{seen: map[string]bool{}}
+	top, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expect a JSON object, got %T", v)
+	}
+	for k, value := range top {
+		if k == "response" {
+			w.walk("response", value, reflect.TypeOf(response))
+			continue
+		}
+		w.walk("", map[string]interface{}{k: value}, reflect.TypeOf(HTTPResponse{}))
+	}
+	sort.Strings(w.findings)
+	return w.findings, nil
+}
+
+type schemaWalker struct {
+	findings []string
+	seen     map[string]bool
+}
+
+func (w *schemaWalker) report(path, format string, a ...interface{}) {
+	f := path + ": " + fmt.Sprintf(format, a...)
+	if !w.seen[f] {
+		w.seen[f] = true
+		w.findings = append(w.findings, f)
+	}
+}
+
+// walk compares a decoded JSON value with the Go type it is decoded into
+//
+// the JSON kind of values decoded by a json.Unmarshaler isn't checked, except for structs
+// as their UnmarshalJSON only tolerates variations of fields that are otherwise modeled
+func (w *schemaWalker) walk(path string, v interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if v == nil || t.Kind() == reflect.Interface {
+		return
+	}
+	custom := t.Kind() != reflect.Struct && (t.Implements(unmarshalerType) || reflect.PtrTo(t).Implements(unmarshalerType))
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for k, fv := range value {
+				name := k
+				if canonical, ok := jsonAliases[t][k]; ok {
+					name = canonical
+				}
+				f, ok := fields[strings.ToLower(name)]
+				if !ok {
+					w.report(joinPath(path, k), "unmodeled field")
+					continue
+				}
+				w.walk(joinPath(path, k), fv, f.Type)
+			}
+		case reflect.Map:
+			for k, fv := range value {
+				w.walk(joinPath(path, k), fv, t.Elem())
+			}
+		default:
+			if !custom {
+				w.report(path, "object, modeled as %v", t)
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			if !custom {
+				w.report(path, "array, modeled as %v", t)
+			}
+			return
+		}
+		for _, ev := range value {
+			w.walk(path+"[]", ev, t.Elem())
+		}
+	case string:
+		if !custom && t.Kind() != reflect.String {
+			w.report(path, "string, modeled as %v", t)
+		}
+	case float64:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !custom && value != float64(int64(value)) {
+				w.report(path, "decimal number, modeled as %v", t)
+			}
+		case reflect.Float32, reflect.Float64:
+		default:
+			if !custom {
+				w.report(path, "number, modeled as %v", t)
+			}
+		}
+	case bool:
+		if !custom && t.Kind() != reflect.Bool {
+			w.report(path, "boolean, modeled as %v", t)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFields returns the fields of a struct indexed by their lowercased JSON name,
+// as encoding/json matches names case-insensitively
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, ef := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = ef
+					}
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f
+	}
+	return fields
+}
@@ -0,0 +1,231 @@
+package textrazor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxClockSkew is the difference between the local clock and the API clock above which SelfCheck warns
+var MaxClockSkew = time.Minute
+
+// CheckStatus is the outcome of a self-check
+type CheckStatus int
+
+// Valid CheckStatus values, from the best to the worst
+const (
+	CheckOK CheckStatus = iota
+	CheckWarn
+	CheckFail
+)
+
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckOK:
+		return "OK"
+	case CheckWarn:
+		return "WARN"
+	case CheckFail:
+		return "FAIL"
+	}
+	return fmt.Sprintf("CheckStatus(%d)", int(s))
+}
+
+// CheckResult is the result of a single self-check
+type CheckResult struct {
+	Name   string
+	Status CheckStatus
+	Detail string
+}
+
+// SelfCheckOptions lists the resources the application depends on, checked by SelfCheck
+type SelfCheckOptions struct {
+	Dictionaries []string
+	Classifiers  []string
+	// RecordedAnalyses are analysis response bodies recorded by the application, e.g. the Response of archived samples,
+	// compared with the structs of this version of the package by the schema check
+	RecordedAnalyses []json.RawMessage
+}
+
+// SelfCheckReport is returned by SelfCheck
+type SelfCheckReport struct {
+	Results  []CheckResult
+	Duration time.Duration
+}
+
+// Status returns the worst status of the report
+func (r *SelfCheckReport) Status() CheckStatus {
+	status := CheckOK
+	for _, res := range r.Results {
+		if res.Status > status {
+			status = res.Status
+		}
+	}
+	return status
+}
+
+// Err returns an error listing the failed checks, or nil if none failed
+func (r *SelfCheckReport) Err() error {
+	var failed []string
+	for _, res := range r.Results {
+		if res.Status == CheckFail {
+			failed = append(failed, res.Name+": "+res.Detail)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("self-check failed: %s", strings.Join(failed, "; "))
+}
+
+func (r *SelfCheckReport) add(name string, status CheckStatus, format string, a ...interface{}) {
+	r.Results = append(r.Results, CheckResult{Name: name, Status: status, Detail: fmt.Sprintf(format, a...)})
+}
+
+// SelfCheck runs cheap validations of the client configuration, intended for service boot diagnostics:
+//
+// * endpoint: the API is reachable
+//
+// * tls: requests are encrypted and the certificate is valid
+//
+// * account: the API key is valid
+//
+// * clock: the local clock is close to the API clock
+//
+// * schema: the account response and the recorded analyses have no field unmodeled by this version of the package,
+// a warning suggests upgrading it when the API has diverged
+//
+// * dictionary:<id> and classifier:<id>: the resources listed in o exist
+func (c *Client) SelfCheck(o SelfCheckOptions) *SelfCheckReport {
+	return c.SelfCheckContext(context.Background(), o)
+}
+
+// SelfCheckContext is like SelfCheck with a context
+func (c *Client) SelfCheckContext(ctx context.Context, o SelfCheckOptions, opts ...CallOption) *SelfCheckReport {
+	start := time.Now()
+	r := &SelfCheckReport{}
+
+	account, err := c.GetAccountContext(ctx, append(append([]CallOption(nil), opts...), ForceRefresh())...)
+	var apiErr *APIError
+	switch {
+	case err == nil || errors.As(err, &apiErr):
+		r.add("endpoint", CheckOK, "%s is reachable", c.endpointURL())
+		if c.UseEncryption {
+			r.add("tls", CheckOK, "requests are encrypted")
+		} else {
+			r.add("tls", CheckWarn, "requests are not encrypted, the API key is sent in clear text")
+		}
+	case isCertificateError(err):
+		r.add("endpoint", CheckOK, "%s is reachable", c.endpointURL())
+		r.add("tls", CheckFail, "%v", err)
+	default:
+		r.add("endpoint", CheckFail, "%v", err)
+	}
+
+	var authErr *AuthenticationError
+	switch {
+	case err == nil:
+		r.add("account", CheckOK, "%s plan, %d/%d requests used today", account.Plan, account.RequestsUsedToday, account.PlanDailyIncludedRequests)
+		r.addClockCheck(account.HTTPResponse)
+		r.addSchemaCheck(account.HTTPResponse, o.RecordedAnalyses)
+	case errors.As(err, &authErr):
+		r.add("account", CheckFail, "invalid API key: %v", err)
+	default:
+		r.add("account", CheckFail, "%v", err)
+	}
+	if err != nil && len(o.RecordedAnalyses) > 0 {
+		r.addSchemaCheck(nil, o.RecordedAnalyses)
+	}
+
+	for _, id := range o.Dictionaries {
+		if _, err := c.GetDictionaryContext(ctx, id, opts...); err != nil {
+			r.add("dictionary:"+id, CheckFail, "%v", err)
+		} else {
+			r.add("dictionary:"+id, CheckOK, "exists")
+		}
+	}
+	for _, id := range o.Classifiers {
+		if _, err := c.ListClassifierCategoriesContext(ctx, id, ListOptions{Limit: 1}, opts...); err != nil {
+			r.add("classifier:"+id, CheckFail, "%v", err)
+		} else {
+			r.add("classifier:"+id, CheckOK, "exists")
+		}
+	}
+
+	r.Duration = time.Since(start)
+	return r
+}
+
+// addClockCheck compares the Date header of the response with the local clock
+func (r *SelfCheckReport) addClockCheck(resp *HTTPResponse) {
+	if resp == nil || resp.Headers.Get("Date") == "" {
+		r.add("clock", CheckWarn, "the API response has no Date header")
+		return
+	}
+	date, err := http.ParseTime(resp.Headers.Get("Date"))
+	if err != nil {
+		r.add("clock", CheckWarn, "invalid Date header: %v", err)
+		return
+	}
+	skew := time.Since(date).Round(time.Second)
+	if skew > MaxClockSkew || skew < -MaxClockSkew {
+		r.add("clock", CheckWarn, "the local clock is %v away from the API clock", skew)
+		return
+	}
+	r.add("clock", CheckOK, "skew %v", skew)
+}
+
+// addSchemaCheck compares the account response, when its body is kept, and the recorded analyses with the structs decoding them
+func (r *SelfCheckReport) addSchemaCheck(account *HTTPResponse, analyses []json.RawMessage) {
+	type recorded struct {
+		body     []byte
+		response Response
+	}
+	var responses []recorded
+	if account != nil && account.Body != nil {
+		responses = append(responses, recorded{account.Body, &Account{}})
+	}
+	for _, body := range analyses {
+		responses = append(responses, recorded{body, &Analysis{}})
+	}
+	if len(responses) == 0 {
+		return
+	}
+
+	seen := map[string]bool{}
+	var findings []string
+	for i, resp := range responses {
+		found, err := schemaFindings(resp.body, resp.response)
+		if err != nil {
+			r.add("schema", CheckWarn, "response %d can't be parsed: %v", i, err)
+			return
+		}
+		for _, f := range found {
+			if !seen[f] {
+				seen[f] = true
+				findings = append(findings, f)
+			}
+		}
+	}
+	if len(findings) == 0 {
+		r.add("schema", CheckOK, "%d responses match textrazor-go %s", len(responses), Version())
+		return
+	}
+	sort.Strings(findings)
+	r.add("schema", CheckWarn, "the API has diverged from what textrazor-go %s models, consider upgrading it: %s", Version(), strings.Join(findings, "; "))
+}
+
+// isCertificateError reports whether err is caused by an invalid server certificate
+func isCertificateError(err error) bool {
+	var hostErr x509.HostnameError
+	var authorityErr x509.UnknownAuthorityError
+	var certErr *tls.CertificateVerificationError
+	return errors.As(err, &hostErr) || errors.As(err, &authorityErr) || errors.As(err, &certErr)
+}
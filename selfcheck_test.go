@@ -0,0 +1,137 @@
+package textrazor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			SelfCheck tests
+
+// selfCheckServer replies to account, dictionary and classifier requests, unknown resources are not found
+func selfCheckServer(date string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", date)
+		switch {
+		case r.URL.Path == "/account/":
+			fmt.Fprint(w, textrazortest.Account)
+		case r.URL.Path == "/entities/"+dictID:
+			fmt.Fprint(w, textrazortest.Dictionary)
+		case strings.HasPrefix(r.URL.Path, "/categories/cls/"):
+			fmt.Fprint(w, textrazortest.Categories)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"ok":false,"error":"not found"}`)
+		}
+	}))
+}
+
+func TestSelfCheck(t *testing.T) {
+	now := time.Now().UTC().Format(http.TimeFormat)
+	skewed := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+
+	var tests = []struct {
+		date   string
+		opts   SelfCheckOptions
+		expect map[string]CheckStatus
+		status CheckStatus
+	}{
+		{now, SelfCheckOptions{Dictionaries: []string{dictID}, Classifiers: []string{"cls"}},
+			map[string]CheckStatus{"endpoint": CheckOK, "tls": CheckWarn, "account": CheckOK, "clock": CheckOK, "schema": CheckOK, "dictionary:" + dictID: CheckOK, "classifier:cls": CheckOK}, CheckWarn},
+		{skewed, SelfCheckOptions{Dictionaries: []string{"missing"}},
+			map[string]CheckStatus{"clock": CheckWarn, "dictionary:missing": CheckFail}, CheckFail},
+	}
+
+	for _, tt := range tests {
+		server := selfCheckServer(tt.date)
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, false, server.URL, server.URL, http.DefaultTransport)
+		report := client.SelfCheck(tt.opts)
+		server.Close()
+
+		results := map[string]CheckStatus{}
+		for _, res := range report.Results {
+			results[res.Name] = res.Status
+			t.Log(res.Name, res.Status, res.Detail)
+		}
+		for name, status := range tt.expect {
+			if results[name] != status {
+				t.Error("expect", name, status, "got", results[name])
+			}
+		}
+		if report.Status() != tt.status || (report.Err() != nil) != (tt.status == CheckFail) {
+			t.Error("expect status", tt.status, "got", report.Status(), report.Err())
+		}
+	}
+}
+
+func TestSelfCheckUnreachable(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, url, url, DefaultTransport(true))
+	report := client.SelfCheck(SelfCheckOptions{})
+	if report.Results[0].Name != "endpoint" || report.Results[0].Status != CheckFail || report.Status() != CheckFail {
+		t.Error("expect the endpoint to be unreachable, got", report.Results)
+	}
+
+	// the options of the caller are left unchanged, even with a spare capacity
+	opts := make([]CallOption, 1, 2)
+	opts[0] = CallHeaders(http.Header{"X-Check": {"1"}})
+	client.SelfCheckContext(context.Background(), SelfCheckOptions{}, opts...)
+	if opts[:2][1] != nil {
+		t.Error("expect the call options of the caller to be left unchanged")
+	}
+}
+
+func TestSelfCheckInvalidCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, server.URL, server.URL, DefaultTransport(true))
+	report := client.SelfCheck(SelfCheckOptions{})
+	results := map[string]CheckStatus{}
+	for _, res := range report.Results {
+		results[res.Name] = res.Status
+	}
+	if results["endpoint"] != CheckOK || results["tls"] != CheckFail {
+		t.Error("expect a reachable endpoint with an invalid certificate, got", report.Results)
+	}
+}
+
+func TestSelfCheckSchema(t *testing.T) {
+	server := selfCheckServer(time.Now().UTC().Format(http.TimeFormat))
+	defer server.Close()
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, false, server.URL, server.URL, http.DefaultTransport)
+
+	diverged := strings.Replace(textrazortest.AnalysisFull, `"entities":`, `"sentiment": {"score": 0.5}, "entities":`, 1)
+	var tests = []struct {
+		analyses []json.RawMessage
+		status   CheckStatus
+		detail   string
+	}{
+		{[]json.RawMessage{json.RawMessage(textrazortest.AnalysisFull)}, CheckOK, "2 responses match textrazor-go (devel)"},
+		{[]json.RawMessage{json.RawMessage(diverged)}, CheckWarn, "the API has diverged from what textrazor-go (devel) models, consider upgrading it: response.sentiment: unmodeled field"},
+		{[]json.RawMessage{json.RawMessage("<html>")}, CheckWarn, "response 1 can't be parsed"},
+	}
+	for _, tt := range tests {
+		report := client.SelfCheck(SelfCheckOptions{RecordedAnalyses: tt.analyses})
+		var schema *CheckResult
+		for i := range report.Results {
+			if report.Results[i].Name == "schema" {
+				schema = &report.Results[i]
+			}
+		}
+		if schema == nil || schema.Status != tt.status || !strings.HasPrefix(schema.Detail, tt.detail) {
+			t.Errorf("expect a %v schema check %q, got %+v", tt.status, tt.detail, schema)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package textrazor
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+)
+
+// LatencyModel estimates the duration of an analysis from the size of the document
+type LatencyModel struct {
+	Base  time.Duration
+	PerKB time.Duration
+}
+
+// DefaultLatencyModel is a rough estimate of the latency of the API for the entities and topics extractors,
+// calibrate it with the latencies measured on your documents
+var DefaultLatencyModel = LatencyModel{Base: 400 * time.Millisecond, PerKB: 20 * time.Millisecond}
+
+// Latency returns the estimated duration of the analysis of a document of size bytes
+func (m LatencyModel) Latency(size int) time.Duration {
+	return m.Base + time.Duration(float64(m.PerKB)*float64(size)/1000)
+}
+
+// Simulation describes a batch of analyses, and the settings of the client and the account running it
+type Simulation struct {
+	// Sizes are the sizes in bytes of the documents, in the order they are analyzed, one request each
+	Sizes []int
+
+	// Concurrency is the number of concurrent analyses, see CallConcurrency, DefaultManyConcurrency if 0
+	Concurrency int
+	// ConcurrencyLimit is the concurrency limit of the client or the account, no limit if 0
+	ConcurrencyLimit int
+	// RateLimit is the number of requests per second allowed by the RateLimiter or the Pacer of the client, no limit if 0
+	RateLimit float64
+
+	// DailyQuota and UsedToday are the daily requests of the plan and the requests already sent today,
+	// the quota isn't checked if DailyQuota is 0. A new day starts every 24 hours from the start of the batch.
+	DailyQuota int
+	UsedToday  int
+
+	// Latency is the latency of the API, DefaultLatencyModel if zero
+	Latency LatencyModel
+}
+
+// SimulationResult is the estimate of Simulate
+type SimulationResult struct {
+	Requests int
+	Bytes    int64
+	// Runtime is the time to analyze every document
+	Runtime time.Duration
+	// PeakConcurrency is the maximum number of concurrent analyses
+	PeakConcurrency int
+	// Days is the number of days of quota the batch spans, PeakDailyRequests the most requests counted in a day,
+	// including UsedToday on the first day
+	Days              int
+	PeakDailyRequests int
+	// Overage is the number of requests above the daily quota, billed or rejected depending on the plan
+	Overage int
+}
+
+// Simulate estimates the runtime, the concurrency and the quota usage of a batch of analyses without sending them,
+// to plan its capacity before committing to it. The documents are analyzed by a pool of workers as AnalyzeBatch does.
+func Simulate(s Simulation) SimulationResult {
+	workers := s.Concurrency
+	if workers <= 0 {
+		workers = DefaultManyConcurrency
+	}
+	if s.ConcurrencyLimit > 0 && workers > s.ConcurrencyLimit {
+		workers = s.ConcurrencyLimit
+	}
+	latency := s.Latency
+	if latency == (LatencyModel{}) {
+		latency = DefaultLatencyModel
+	}
+	var interval time.Duration
+	if s.RateLimit > 0 {
+		interval = time.Duration(float64(time.Second) / s.RateLimit)
+	}
+
+	r := SimulationResult{Requests: len(s.Sizes)}
+	// free holds the time each worker is free at
+	free := &durationHeap{}
+	for i := 0; i < workers && i < len(s.Sizes); i++ {
+		heap.Push(free, time.Duration(0))
+	}
+	type event struct {
+		at    time.Duration
+		delta int
+	}
+	events := make([]event, 0, 2*len(s.Sizes))
+	daily := map[int]int{}
+	var nextSlot time.Duration
+	for _, size := range s.Sizes {
+		start := heap.Pop(free).(time.Duration)
+		if start < nextSlot {
+			start = nextSlot
+		}
+		nextSlot = start + interval
+		end := start + latency.Latency(size)
+		heap.Push(free, end)
+
+		r.Bytes += int64(size)
+		if end > r.Runtime {
+			r.Runtime = end
+		}
+		events = append(events, event{start, 1}, event{end, -1})
+		daily[int(start/(24*time.Hour))]++
+	}
+
+	// an analysis ending when another starts doesn't overlap it
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at != events[j].at {
+			return events[i].at < events[j].at
+		}
+		return events[i].delta < events[j].delta
+	})
+	running := 0
+	for _, e := range events {
+		running += e.delta
+		if running > r.PeakConcurrency {
+			r.PeakConcurrency = running
+		}
+	}
+
+	for day, n := range daily {
+		if day == 0 {
+			n += s.UsedToday
+		}
+		if day+1 > r.Days {
+			r.Days = day + 1
+		}
+		if n > r.PeakDailyRequests {
+			r.PeakDailyRequests = n
+		}
+		if s.DailyQuota > 0 && n > s.DailyQuota {
+			r.Overage += n - s.DailyQuota
+		}
+	}
+	return r
+}
+
+// durationHeap is a min-heap of durations
+type durationHeap []time.Duration
+
+func (h durationHeap) Len() int            { return len(h) }
+func (h durationHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h durationHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *durationHeap) Push(x interface{}) { *h = append(*h, x.(time.Duration)) }
+func (h *durationHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
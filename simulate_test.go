@@ -0,0 +1,51 @@
+package textrazor
+
+import (
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			Simulate tests
+
+func repeatSizes(n, size int) []int {
+	sizes := make([]int, n)
+	for i := range sizes {
+		sizes[i] = size
+	}
+	return sizes
+}
+
+func TestSimulate(t *testing.T) {
+	second := LatencyModel{Base: time.Second}
+	var tests = []struct {
+		name   string
+		s      Simulation
+		expect SimulationResult
+	}{
+		{"concurrency", Simulation{Sizes: repeatSizes(10, 1000), Concurrency: 2, Latency: second},
+			SimulationResult{Requests: 10, Bytes: 10000, Runtime: 5 * time.Second, PeakConcurrency: 2, Days: 1, PeakDailyRequests: 10}},
+		{"account limit", Simulation{Sizes: repeatSizes(10, 0), Concurrency: 5, ConcurrencyLimit: 1, Latency: second},
+			SimulationResult{Requests: 10, Runtime: 10 * time.Second, PeakConcurrency: 1, Days: 1, PeakDailyRequests: 10}},
+		// one request every 2 seconds, each lasting 1 second, never overlap
+		{"rate limit", Simulation{Sizes: repeatSizes(3, 0), Concurrency: 3, RateLimit: 0.5, Latency: second},
+			SimulationResult{Requests: 3, Runtime: 5 * time.Second, PeakConcurrency: 1, Days: 1, PeakDailyRequests: 3}},
+		{"latency per KB", Simulation{Sizes: []int{2000, 500}, Latency: LatencyModel{Base: time.Second, PerKB: time.Second}},
+			SimulationResult{Requests: 2, Bytes: 2500, Runtime: 3 * time.Second, PeakConcurrency: 2, Days: 1, PeakDailyRequests: 2}},
+		{"quota", Simulation{Sizes: repeatSizes(10, 0), DailyQuota: 12, UsedToday: 5, Latency: second},
+			SimulationResult{Requests: 10, Runtime: 3 * time.Second, PeakConcurrency: 4, Days: 1, PeakDailyRequests: 15, Overage: 3}},
+		// 1 request per hour over 2 days, the quota is reset after 24 hours
+		{"days", Simulation{Sizes: repeatSizes(48, 0), RateLimit: 1.0 / 3600, DailyQuota: 20, UsedToday: 10, Latency: second},
+			SimulationResult{Requests: 48, Runtime: 47*time.Hour + time.Second, PeakConcurrency: 1, Days: 2, PeakDailyRequests: 34, Overage: 18}},
+		{"empty", Simulation{}, SimulationResult{}},
+	}
+	for _, tt := range tests {
+		if r := Simulate(tt.s); r != tt.expect {
+			t.Errorf("%s: expect %+v, got %+v", tt.name, tt.expect, r)
+		}
+	}
+
+	if r := Simulate(Simulation{Sizes: []int{1000}}); r.Runtime != 420*time.Millisecond {
+		t.Error("expect the default latency model, got", r.Runtime)
+	}
+}
@@ -0,0 +1,74 @@
+package textrazor
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// clientStats are the counters of a Client, updated atomically
+type clientStats struct {
+	requests    atomic.Int64
+	inFlight    atomic.Int64
+	retries     atomic.Int64
+	failures    atomic.Int64
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+	goroutines  atomic.Int64
+}
+
+// Snapshot is the state of a Client at a point in time, for monitoring
+type Snapshot struct {
+	// Requests is the number of HTTP requests sent, including retries
+	Requests int64
+	// InFlight is the number of HTTP requests waiting for a response
+	InFlight int64
+	// Retries is the number of rate limited requests retried
+	Retries int64
+	// Failures is the number of API requests which failed, after retries
+	Failures int64
+	// Goroutines is the number of goroutines running for fan-out calls, e.g. AnalyzeMany or canary analyses
+	Goroutines int64
+
+	// AccountCacheHits and AccountCacheMisses count GetAccount calls, when WithAccountCache is used
+	AccountCacheHits   int64
+	AccountCacheMisses int64
+
+	// ConcurrencyLimit and ConcurrencyInUse are the size and the used slots of the concurrency limit,
+	// 0 when there's no limit or the account wasn't queried yet
+	ConcurrencyLimit int
+	ConcurrencyInUse int
+
+	// QuotaLimit, QuotaUsed and QuotaRemaining are the daily budget of the QuotaGuard, when WithQuotaGuard is used
+	QuotaLimit     int
+	QuotaUsed      int
+	QuotaRemaining int
+}
+
+// Snapshot returns the current state of the client
+func (c *Client) Snapshot() Snapshot {
+	s := Snapshot{
+		Requests:           c.stats.requests.Load(),
+		InFlight:           c.stats.inFlight.Load(),
+		Retries:            c.stats.retries.Load(),
+		Failures:           c.stats.failures.Load(),
+		Goroutines:         c.stats.goroutines.Load(),
+		AccountCacheHits:   c.stats.cacheHits.Load(),
+		AccountCacheMisses: c.stats.cacheMisses.Load(),
+	}
+	if c.concurrency != nil {
+		c.concurrency.mu.Lock()
+		s.ConcurrencyLimit, s.ConcurrencyInUse = cap(c.concurrency.slots), len(c.concurrency.slots)
+		c.concurrency.mu.Unlock()
+	}
+	if c.quota != nil {
+		s.QuotaLimit, s.QuotaUsed, s.QuotaRemaining = c.quota.Limit, c.quota.Used(), c.quota.Remaining()
+	}
+	return s
+}
+
+// PublishExpvar publishes the snapshot of the client as an expvar variable, served on /debug/vars
+//
+// like expvar.Publish, it panics if the name is already used
+func (c *Client) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} { return c.Snapshot() }))
+}
@@ -0,0 +1,60 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Snapshot tests
+
+func TestSnapshot(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	transport := textrazortest.NewSequenceTransport(
+		rateLimited,
+		textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account},
+		textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities},
+		textrazortest.Reply{Status: http.StatusInternalServerError, Body: textrazortest.Error},
+	)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithRateLimitRetries(1, 0), WithAccountCache(time.Hour), WithAccountConcurrencyLimit(), WithQuotaGuard(NewQuotaGuard(10, QuotaReject)))
+
+	// the account is requested by the concurrency limit, and cached
+	if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}}); err == nil {
+		t.Fatal("expect an error")
+	}
+
+	expect := Snapshot{
+		Requests:           4,
+		Retries:            1,
+		Failures:           1,
+		AccountCacheHits:   1,
+		AccountCacheMisses: 1,
+		ConcurrencyLimit:   2,
+		QuotaLimit:         10,
+		QuotaUsed:          2,
+		QuotaRemaining:     8,
+	}
+	if s := client.Snapshot(); s != expect {
+		t.Errorf("expect %+v, got %+v", expect, s)
+	}
+
+	client.PublishExpvar("textrazor_test")
+	var published Snapshot
+	if err := json.Unmarshal([]byte(expvar.Get("textrazor_test").String()), &published); err != nil || published != expect {
+		t.Error("expect the snapshot to be published, got", published, err)
+	}
+}
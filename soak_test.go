@@ -0,0 +1,248 @@
+//go:build soak
+// +build soak
+
+package textrazor
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Soak tests against a simulated API
+//
+// go test -tags=soak -run Soak -v
+//
+// TEXTRAZOR_SOAK_DURATION sets the duration of each test, 30s by default.
+// The statistics are reported with t.Log, the tests fail when the throughput
+// exceeds the rate limit or the callers aren't served fairly.
+
+const soakDurationEnv = "TEXTRAZOR_SOAK_DURATION"
+
+func soakDuration(t *testing.T) time.Duration {
+	d := 30 * time.Second
+	if v := os.Getenv(soakDurationEnv); v != "" {
+		var err error
+		if d, err = time.ParseDuration(v); err != nil {
+			t.Fatal(soakDurationEnv, err)
+		}
+	}
+	return d
+}
+
+// soakServer simulates the API with random latencies and errors
+type soakServer struct {
+	*httptest.Server
+
+	// mean latency, exponentially distributed
+	latency time.Duration
+	// probabilities of a rate limited response and of an internal error
+	rateLimited, failure float64
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func newSoakServer(latency time.Duration, rateLimited, failure float64) *soakServer {
+	s := &soakServer{latency: latency, rateLimited: rateLimited, failure: failure, rnd: rand.New(rand.NewSource(1))}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *soakServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	delay := time.Duration(s.rnd.ExpFloat64() * float64(s.latency))
+	p := s.rnd.Float64()
+	s.mu.Unlock()
+	time.Sleep(delay)
+
+	switch {
+	case p < s.rateLimited:
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"ok":false,"error":"too many requests"}`))
+	case p < s.rateLimited+s.failure:
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(textrazortest.Error))
+	default:
+		w.Write([]byte(textrazortest.AnalysisEntities))
+	}
+}
+
+// soakStats are the results of the callers of a soak test
+type soakStats struct {
+	mu        sync.Mutex
+	calls     []int
+	failures  atomic.Int64
+	latencies []time.Duration
+}
+
+func (s *soakStats) record(caller int, d time.Duration, err error) {
+	if err != nil {
+		s.failures.Add(1)
+	}
+	s.mu.Lock()
+	s.calls[caller]++
+	s.latencies = append(s.latencies, d)
+	s.mu.Unlock()
+}
+
+// fairness returns Jain's fairness index of the calls per caller, 1 when every caller made as many calls
+func (s *soakStats) fairness() float64 {
+	var sum, squares float64
+	for _, n := range s.calls {
+		sum += float64(n)
+		squares += float64(n) * float64(n)
+	}
+	if squares == 0 {
+		return 0
+	}
+	return sum * sum / (float64(len(s.calls)) * squares)
+}
+
+func (s *soakStats) percentile(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+	return s.latencies[int(math.Ceil(p*float64(len(s.latencies))))-1]
+}
+
+func (s *soakStats) total() int {
+	n := 0
+	for _, c := range s.calls {
+		n += c
+	}
+	return n
+}
+
+// soak runs callers analyzing texts in a loop with client for d
+func soak(client *Client, callers int, d time.Duration) *soakStats {
+	stats := &soakStats{calls: make([]int, callers)}
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(caller int) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				start := time.Now()
+				_, err := client.AnalyzeTextContext(ctx, testText, Params{"extractors": {"entities"}})
+				if ctx.Err() != nil {
+					return
+				}
+				stats.record(caller, time.Since(start), err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return stats
+}
+
+func TestSoakRateLimiter(t *testing.T) {
+	defer checkLeaks(t)()
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = 10 * time.Millisecond
+	d := soakDuration(t)
+
+	const perSecond, burst, callers = 50, 5, 16
+	server := newSoakServer(20*time.Millisecond, 0.05, 0.01)
+	defer server.Close()
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, false, server.URL, "", DefaultTransport(DefaultUseCompression),
+		WithRateLimiter(NewRateLimiter(perSecond, burst)), WithRateLimitRetries(3, 0), WithConcurrencyLimit(4))
+
+	stats := soak(client, callers, d)
+	snapshot := client.Snapshot()
+	throughput := float64(snapshot.Requests) / d.Seconds()
+	t.Logf("%d calls, %d failed, %d requests, %d retries, %.1f requests/s (limit %d/s)",
+		stats.total(), stats.failures.Load(), snapshot.Requests, snapshot.Retries, throughput, perSecond)
+	t.Logf("latency p50 %v, p95 %v, p99 %v, fairness %.3f over %d callers",
+		stats.percentile(0.50), stats.percentile(0.95), stats.percentile(0.99), stats.fairness(), callers)
+
+	if limit := perSecond + float64(burst)/d.Seconds(); throughput > limit*1.05 {
+		t.Errorf("expect at most %.1f requests/s, got %.1f", limit, throughput)
+	}
+	if throughput < perSecond*0.8 {
+		t.Errorf("expect the limiter to be saturated at about %d requests/s, got %.1f", perSecond, throughput)
+	}
+	if f := stats.fairness(); f < 0.9 {
+		t.Errorf("expect a fairness index of at least 0.9, got %.3f", f)
+	}
+	if snapshot.InFlight != 0 || snapshot.ConcurrencyInUse != 0 {
+		t.Errorf("expect no request left in flight, got %+v", snapshot)
+	}
+}
+
+func TestSoakRetries(t *testing.T) {
+	defer checkLeaks(t)()
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+	d := soakDuration(t)
+
+	// every rate limited request is retried until it succeeds, up to 10 times
+	const rateLimited = 0.3
+	server := newSoakServer(5*time.Millisecond, rateLimited, 0)
+	defer server.Close()
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, false, server.URL, "", DefaultTransport(DefaultUseCompression),
+		WithRateLimitRetries(10, 0))
+
+	stats := soak(client, 8, d)
+	snapshot := client.Snapshot()
+	ratio := float64(snapshot.Retries) / float64(snapshot.Requests)
+	t.Logf("%d calls, %d failed, %d requests, %d retries (%.1f%%)",
+		stats.total(), stats.failures.Load(), snapshot.Requests, snapshot.Retries, ratio*100)
+
+	// a call fails when 11 attempts in a row are rate limited
+	if expect := float64(stats.total()) * math.Pow(rateLimited, 11); float64(stats.failures.Load()) > 10*expect+1 {
+		t.Errorf("expect about %.2f failed calls, got %d", expect, stats.failures.Load())
+	}
+	if math.Abs(ratio-rateLimited) > 0.05 {
+		t.Errorf("expect about %.0f%% of retried requests, got %.1f%%", rateLimited*100, ratio*100)
+	}
+}
+
+func TestSoakScheduler(t *testing.T) {
+	defer checkLeaks(t)()
+	d := soakDuration(t)
+
+	// a batch job with many callers shares the account with a few interactive callers
+	server := newSoakServer(10*time.Millisecond, 0, 0)
+	defer server.Close()
+	s := NewScheduler(4)
+	s.SetWeight("web", 2)
+	newClient := func(tenant string) *Client {
+		return NewCustomClient(testAPIKey, DefaultUseCompression, false, server.URL, "", DefaultTransport(DefaultUseCompression), WithScheduler(s, tenant))
+	}
+	batch, web := newClient("batch"), newClient("web")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); soak(batch, 32, d) }()
+	go func() { defer wg.Done(); soak(web, 4, d) }()
+	wg.Wait()
+
+	stats := s.Stats()
+	b, w := stats["batch"], stats["web"]
+	share := float64(w.Granted) / float64(w.Granted+b.Granted)
+	t.Logf("batch: %d requests, mean wait %v, max wait %v", b.Granted, b.MeanWait(), b.MaxWait)
+	t.Logf("web: %d requests, mean wait %v, max wait %v, %.1f%% of the slots (weight 2 of 3)", w.Granted, w.MeanWait(), w.MaxWait, share*100)
+
+	if share < 0.6 {
+		t.Errorf("expect the web tenant to get about 2/3 of the slots, got %.1f%%", share*100)
+	}
+	if w.MeanWait() > b.MeanWait() {
+		t.Errorf("expect the web tenant to wait less than the batch one, got %v and %v", w.MeanWait(), b.MeanWait())
+	}
+}
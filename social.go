@@ -0,0 +1,199 @@
+package textrazor
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind is a kind of social media token handled by a SocialFilter
+type TokenKind int
+
+// Valid TokenKind values
+const (
+	TokenURL TokenKind = iota
+	TokenMention
+	TokenEmoji
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenURL:
+		return "url"
+	case TokenMention:
+		return "mention"
+	case TokenEmoji:
+		return "emoji"
+	}
+	return "unknown"
+}
+
+// TokenAction defines what a SocialFilter does with a kind of token
+type TokenAction int
+
+// Valid TokenAction values
+const (
+	// TokenKeep leaves the tokens in the text
+	TokenKeep TokenAction = iota
+	// TokenStrip removes the tokens from the text
+	TokenStrip
+	// TokenPlaceholder replaces the tokens with the placeholder of their kind
+	TokenPlaceholder
+)
+
+// Default placeholders of a SocialFilter
+const (
+	DefaultURLPlaceholder     = "URL"
+	DefaultMentionPlaceholder = "USER"
+	DefaultEmojiPlaceholder   = "EMOJI"
+)
+
+// TokenSpan is a token found by a SocialFilter, its offsets are code points of the original text
+type TokenSpan struct {
+	Kind        TokenKind
+	Text        string
+	StartingPos int
+	EndingPos   int
+}
+
+// SocialFilter removes or replaces the URLs, @mentions and emojis of social media texts,
+// which otherwise pollute entity and phrase extraction. It implements TextTransform:
+//
+//	textrazor.WithTextTransform(textrazor.SocialFilter{URLs: textrazor.TokenStrip, Emojis: textrazor.TokenStrip})
+type SocialFilter struct {
+	URLs     TokenAction
+	Mentions TokenAction
+	Emojis   TokenAction
+	// Placeholders replace the tokens with the TokenPlaceholder action, the Default*Placeholder by default
+	Placeholders map[TokenKind]string
+}
+
+var (
+	urlToken     = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+	mentionToken = regexp.MustCompile(`@\w+`)
+)
+
+// Transform implements TextTransform
+func (f SocialFilter) Transform(text string) (string, *OffsetMap) {
+	filtered, _, m := f.Filter(text)
+	return filtered, m
+}
+
+// Filter returns the filtered text, the tokens found in text, and the OffsetMap of the filtered text
+func (f SocialFilter) Filter(text string) (string, []TokenSpan, *OffsetMap) {
+	type match struct {
+		kind       TokenKind
+		start, end int // bytes
+	}
+	var matches []match
+	if f.URLs != TokenKeep {
+		for _, loc := range urlToken.FindAllStringIndex(text, -1) {
+			end := loc[0] + len(strings.TrimRight(text[loc[0]:loc[1]], ".,;:!?)]}'"))
+			matches = append(matches, match{TokenURL, loc[0], end})
+		}
+	}
+	if f.Mentions != TokenKeep {
+		for _, loc := range mentionToken.FindAllStringIndex(text, -1) {
+			// not an email address
+			if r, _ := utf8.DecodeLastRuneInString(text[:loc[0]]); loc[0] == 0 || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.') {
+				matches = append(matches, match{TokenMention, loc[0], loc[1]})
+			}
+		}
+	}
+	if f.Emojis != TokenKeep {
+		start := -1
+		for i, r := range text {
+			switch {
+			case isEmoji(r) || (start >= 0 && isEmojiModifier(r)):
+				if start < 0 {
+					start = i
+				}
+			case start >= 0:
+				matches = append(matches, match{TokenEmoji, start, i})
+				start = -1
+			}
+		}
+		if start >= 0 {
+			matches = append(matches, match{TokenEmoji, start, len(text)})
+		}
+	}
+	// the first of overlapping tokens wins, the URL for a URL holding an @
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	var (
+		b      strings.Builder
+		spans  []TokenSpan
+		m      = &OffsetMap{}
+		last   = 0 // bytes
+		offset = 0 // code points
+	)
+	for _, t := range matches {
+		if t.start < last {
+			continue
+		}
+		unchanged := utf8.RuneCountInString(text[last:t.start])
+		b.WriteString(text[last:t.start])
+		m.Add(unchanged, unchanged)
+		offset += unchanged
+
+		token := text[t.start:t.end]
+		length := utf8.RuneCountInString(token)
+		spans = append(spans, TokenSpan{Kind: t.kind, Text: token, StartingPos: offset, EndingPos: offset + length})
+		replacement := token
+		switch f.action(t.kind) {
+		case TokenStrip:
+			replacement = ""
+		case TokenPlaceholder:
+			replacement = f.placeholder(t.kind)
+		}
+		b.WriteString(replacement)
+		m.Add(length, utf8.RuneCountInString(replacement))
+		offset += length
+		last = t.end
+	}
+	rest := utf8.RuneCountInString(text[last:])
+	b.WriteString(text[last:])
+	m.Add(rest, rest)
+	return b.String(), spans, m
+}
+
+func (f SocialFilter) action(k TokenKind) TokenAction {
+	switch k {
+	case TokenURL:
+		return f.URLs
+	case TokenMention:
+		return f.Mentions
+	}
+	return f.Emojis
+}
+
+func (f SocialFilter) placeholder(k TokenKind) string {
+	if p, ok := f.Placeholders[k]; ok {
+		return p
+	}
+	switch k {
+	case TokenURL:
+		return DefaultURLPlaceholder
+	case TokenMention:
+		return DefaultMentionPlaceholder
+	}
+	return DefaultEmojiPlaceholder
+}
+
+// isEmoji reports whether r is a pictographic symbol, including flags
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // mahjong to symbols and pictographs extended-A, with flags
+		r >= 0x2600 && r <= 0x27BF, // miscellaneous symbols and dingbats
+		r >= 0x2B00 && r <= 0x2BFF: // arrows and stars
+		return !isEmojiModifier(r)
+	}
+	return false
+}
+
+// isEmojiModifier reports whether r modifies the emoji before it: zero width joiner, variation selector, skin tone or tag
+func isEmojiModifier(r rune) bool {
+	return r == 0x200D || r == 0xFE0F || r == 0x20E3 || (r >= 0x1F3FB && r <= 0x1F3FF) || (r >= 0xE0020 && r <= 0xE007F)
+}
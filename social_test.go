@@ -0,0 +1,60 @@
+package textrazor
+
+import (
+	"reflect"
+	"testing"
+)
+
+//***************************************************************
+// 			Social media filter tests
+
+func TestSocialFilter(t *testing.T) {
+	text := "@bbcpanorama Barclays misled investors 😡👍🏽 https://bbc.in/x1?a=b. Mail me@example.com"
+	var tests = []struct {
+		name   string
+		filter SocialFilter
+		expect string
+	}{
+		{"keep", SocialFilter{}, text},
+		{"strip", SocialFilter{URLs: TokenStrip, Mentions: TokenStrip, Emojis: TokenStrip},
+			" Barclays misled investors  . Mail me@example.com"},
+		{"placeholder", SocialFilter{URLs: TokenPlaceholder, Mentions: TokenPlaceholder, Emojis: TokenPlaceholder, Placeholders: map[TokenKind]string{TokenEmoji: ""}},
+			"USER Barclays misled investors  URL. Mail me@example.com"},
+		{"emojis only", SocialFilter{Emojis: TokenPlaceholder},
+			"@bbcpanorama Barclays misled investors EMOJI https://bbc.in/x1?a=b. Mail me@example.com"},
+	}
+	for _, tt := range tests {
+		if got, _ := tt.filter.Transform(text); got != tt.expect {
+			t.Errorf("%s: expect %q, got %q", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestSocialFilterSpans(t *testing.T) {
+	text := "Go 🇫🇷 @rob_pike: see www.golang.org/doc!"
+	filtered, spans, m := SocialFilter{URLs: TokenPlaceholder, Mentions: TokenStrip, Emojis: TokenStrip}.Filter(text)
+	if filtered != "Go  : see URL!" {
+		t.Fatal("unexpected filtered text", filtered)
+	}
+	expect := []TokenSpan{
+		{TokenEmoji, "🇫🇷", 3, 5},
+		{TokenMention, "@rob_pike", 6, 15},
+		{TokenURL, "www.golang.org/doc", 21, 39},
+	}
+	if !reflect.DeepEqual(spans, expect) {
+		t.Errorf("expect %+v, got %+v", expect, spans)
+	}
+	for _, s := range spans {
+		if got := (&Entity{StartingPos: s.StartingPos, EndingPos: s.EndingPos}).Mention(text); got != s.Text {
+			t.Errorf("expect the span of %s to match it, got %s", s.Text, got)
+		}
+	}
+
+	// "see" and "URL" in the filtered text
+	if m.Start(6) != 17 || m.End(9) != 20 || m.Start(10) != 21 || m.End(13) != 39 || m.Start(13) != 39 {
+		t.Error("unexpected offsets mapping", m.segments)
+	}
+	if s := spans[0].Kind.String(); s != "emoji" {
+		t.Error("expect emoji, got", s)
+	}
+}
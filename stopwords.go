@@ -0,0 +1,170 @@
+package textrazor
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// bundledStopwords are the stopwords of the major languages supported by the API, by ISO 639-2 (B) code
+var bundledStopwords = map[string]string{
+	"eng": `a about above after again against all am an and any are as at be because been before being below between
+		both but by can could did do does doing down during each few for from further had has have having he her here
+		hers herself him himself his how i if in into is it its itself just me more most my myself no nor not now of off
+		on once only or other our ours ourselves out over own same she should so some such than that the their theirs
+		them themselves then there these they this those through to too under until up very was we were what when where
+		which while who whom why will with would you your yours yourself yourselves 's`,
+	"fre": `a à au aux avec ce ces cette dans de des du elle elles en est et été être eu il ils je la le les leur leurs lui
+		ma mais me même mes moi mon ne nos notre nous on ont ou où par pas pour qu que qui sa se ses son sont sur ta te
+		tes toi ton tu un une vos votre vous y c d j l m n s t`,
+	"ger": `aber alle als also am an auch auf aus bei bin bis bist da damit dann das dass dein deine dem den der des dich
+		die dir doch du durch ein eine einem einen einer eines er es euer eure für hat hatte hier ich ihr ihre im in ist
+		ja jede jeder kein keine man mein meine mich mir mit nach nicht noch nun nur ob oder ohne sein seine sich sie
+		sind so über um und uns unser unter vom von vor war waren was weil wenn wer wie wir wird wo zu zum zur`,
+	"spa": `a al algo algunos ante antes como con contra cual cuando de del desde donde durante e el él ella ellas ellos
+		en entre era es esa ese eso esta está este esto estos fue ha han hasta la las le les lo los más me mi mis muy ni
+		no nos nosotros o otra otro para pero poco por porque que qué se sea ser si sí sin sobre son su sus también tu
+		tus un una uno unos y ya yo`,
+	"ita": `a ad al alla alle anche che chi ci come con contro cui da dal dalla dei del della delle di dove e è ed era
+		gli ha hanno i il in io la le lei lo loro lui ma mi mia mio ne negli nei nel nella noi non o per perché più
+		quale quando quella quello questa questo se sei si sia sono su sua sue suo sul sulla tra tu tutti un una uno
+		voi`,
+	"por": `a à ao aos as às até com como da das de dela dele do dos e é ela elas ele eles em entre era essa esse esta
+		este eu foi foram há isso isto já lhe mais mas me mesmo meu minha muito na nas não nem no nos nós num numa o
+		os ou para pela pelo por quando que quem se sem seu sua suas são também te tem tu um uma você`,
+	"dut": `aan al alles als bij dan dat de der deze die dit door dus een en er ge geen haar had heb heeft hem het hier hij
+		hoe hun ik in is je kan kon maar me men met mij mijn naar niet niets nog nu of om omdat ons ook op over te tot
+		u uit van veel voor want was wat we wel werd wie wij wordt zal ze zich zij zijn zo zonder`,
+	"swe": `alla att av blev bli de dem den denna deras dess det detta dig din du där efter ej eller en er ett från för
+		ha hade han hans har henne hon honom här i icke ingen inte jag ju kan man med men mig min mot mycket ni nu när
+		och om oss på sedan sig sin sina sitt skulle som så till under upp ut utan vad var vi vid är åt över`,
+	"dan": `af alle at blev blive de dem den denne der det dette dig din disse du efter eller en end er et for fra ham han
+		hans har havde hende hun hvad hvis hvor i ikke ind jeg kan man med meget men mig min mod ned noget nu når og
+		også om op os over på sig sin skal som til ud under var vi vil være`,
+	"nor": `alle at av bare da de dei deg den denne der det dette du eg ein eit eller en er et etter for fra han hans har
+		hun hva hvis hvor i ikke inn jeg kan man med meg men min mot mye ned noe nå og også om opp oss over på seg sin
+		skal som så til ut var vi vil være`,
+	"rus": `а без бы был была были было в вам вас весь во вот все всё вы где да для до его ее её если есть еще ещё же за
+		и из или им их к как когда кто ли мне мы на над нас не него нее неё нет ни них но ну о об от по под при про с
+		так также там то только тот ты у уже чем что чтобы эта эти это я`,
+}
+
+// stopwordAliases maps the terminologic (T) ISO 639-2 codes to the bibliographic (B) codes of bundledStopwords
+var stopwordAliases = map[string]string{"fra": "fre", "deu": "ger", "nld": "dut"}
+
+var (
+	stopwordsMu sync.RWMutex
+	// stopwordSets are the stopwords in use, built from bundledStopwords on first use or set with SetStopwords
+	stopwordSets = map[string]map[string]bool{}
+)
+
+// stopwordLanguage returns the code of lang in bundledStopwords
+func stopwordLanguage(lang string) string {
+	if code, ok := stopwordAliases[lang]; ok {
+		return code
+	}
+	return lang
+}
+
+// Stopwords returns the stopwords of a language, from its ISO 639-2 code, e.g. "eng", sorted.
+// Lists are bundled for English, French, German, Spanish, Italian, Portuguese, Dutch,
+// Swedish, Danish, Norwegian and Russian, they are replaced with SetStopwords.
+func Stopwords(lang string) []string {
+	set := stopwordSet(lang)
+	words := make([]string, 0, len(set))
+	for w := range set {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// SetStopwords replaces the stopwords of a language used by the helpers of the client, e.g. Keywords,
+// words are compared lowercased. A nil list restores the bundled list.
+func SetStopwords(lang string, words []string) {
+	lang = stopwordLanguage(lang)
+	stopwordsMu.Lock()
+	defer stopwordsMu.Unlock()
+	if words == nil {
+		delete(stopwordSets, lang)
+		return
+	}
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[strings.ToLower(w)] = true
+	}
+	stopwordSets[lang] = set
+}
+
+// IsStopword reports whether word is a stopword of a language, ignoring case.
+// Languages without stopwords have none.
+func IsStopword(lang, word string) bool {
+	return stopwordSet(lang)[strings.ToLower(word)]
+}
+
+// stopwordSet returns the stopwords in use for a language, which mustn't be modified
+func stopwordSet(lang string) map[string]bool {
+	lang = stopwordLanguage(lang)
+	stopwordsMu.RLock()
+	set, ok := stopwordSets[lang]
+	stopwordsMu.RUnlock()
+	if ok {
+		return set
+	}
+
+	stopwordsMu.Lock()
+	defer stopwordsMu.Unlock()
+	if set, ok := stopwordSets[lang]; ok {
+		return set
+	}
+	set = map[string]bool{}
+	for _, w := range strings.Fields(bundledStopwords[lang]) {
+		set[w] = true
+	}
+	stopwordSets[lang] = set
+	return set
+}
+
+// Keyword is a lemma of an analysis ranked by Keywords
+type Keyword struct {
+	Lemma string
+	Count int
+	// Score is the share of the words of the analysis, without stopwords, which are the lemma
+	Score float64
+}
+
+// Keywords returns the n most frequent lemmas of the analysis, all of them when n is 0, without the stopwords
+// of its language, the punctuation and the numbers. It needs the "words" extractor.
+func (a *Analysis) Keywords(n int) []Keyword {
+	counts := map[string]int{}
+	total := 0
+	for _, s := range a.Sentences {
+		for _, w := range s.Words {
+			lemma := strings.ToLower(w.Lemma)
+			if lemma == "" {
+				lemma = strings.ToLower(w.Token)
+			}
+			if strings.IndexFunc(lemma, unicode.IsLetter) < 0 || IsStopword(a.Language, lemma) || IsStopword(a.Language, w.Token) {
+				continue
+			}
+			counts[lemma]++
+			total++
+		}
+	}
+
+	keywords := make([]Keyword, 0, len(counts))
+	for lemma, count := range counts {
+		keywords = append(keywords, Keyword{Lemma: lemma, Count: count, Score: float64(count) / float64(total)})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Count != keywords[j].Count {
+			return keywords[i].Count > keywords[j].Count
+		}
+		return keywords[i].Lemma < keywords[j].Lemma
+	})
+	if n > 0 && n < len(keywords) {
+		keywords = keywords[:n]
+	}
+	return keywords
+}
@@ -0,0 +1,59 @@
+package textrazor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Stopwords tests
+
+func TestStopwords(t *testing.T) {
+	var tests = []struct {
+		lang, word string
+		expect     bool
+	}{
+		{"eng", "The", true},
+		{"eng", "bank", false},
+		{"fre", "les", true},
+		{"fra", "les", true},
+		{"deu", "Und", true},
+		{"spa", "también", true},
+		{"rus", "это", true},
+		{"xyz", "the", false},
+	}
+	for _, tt := range tests {
+		if got := IsStopword(tt.lang, tt.word); got != tt.expect {
+			t.Errorf("%s %q: expect %v, got %v", tt.lang, tt.word, tt.expect, got)
+		}
+	}
+
+	for _, lang := range []string{"eng", "fre", "ger", "spa", "ita", "por", "dut", "swe", "dan", "nor", "rus"} {
+		if len(Stopwords(lang)) < 50 {
+			t.Errorf("expect a stopword list for %s, got %v", lang, Stopwords(lang))
+		}
+	}
+	if words := Stopwords("xyz"); len(words) != 0 {
+		t.Error("expect no stopwords, got", words)
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	a := decodeAnalysis(t, textrazortest.AnalysisWords)
+
+	expect := []Keyword{{"bank", 1, 1.0 / 13}, {"barclays", 1, 1.0 / 13}, {"bbc", 1, 1.0 / 13}}
+	if keywords := a.Keywords(3); !reflect.DeepEqual(keywords, expect) {
+		t.Errorf("expect %v, got %v", expect, keywords)
+	}
+	if keywords := a.Keywords(0); len(keywords) != 13 {
+		t.Error("expect 13 keywords, got", keywords)
+	}
+
+	SetStopwords("eng", []string{"Bank", "Barclays"})
+	defer SetStopwords("eng", nil)
+	if keywords := a.Keywords(1); len(keywords) != 1 || keywords[0].Lemma != "the" || keywords[0].Count != 3 {
+		t.Error("expect the overridden stopwords, got", keywords)
+	}
+}
@@ -0,0 +1,54 @@
+package textrazor
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// AnalyzeStream analyzes the documents received from in with the same params, on CallConcurrency workers,
+// and sends their results to the returned channel in the order the analyses finish.
+// A failed analysis is sent with its error, it doesn't stop the others.
+//
+// The channel isn't buffered: when the results aren't read, the workers stop reading in.
+// It's closed once in is closed and every document is analyzed, or once ctx is done:
+// the documents received but not analyzed yet are then dropped.
+func (c *Client) AnalyzeStream(ctx context.Context, in <-chan Document, params Params, opts ...CallOption) <-chan BatchResult {
+	workers := newCallOptions(opts).concurrency
+	if workers <= 0 {
+		workers = DefaultManyConcurrency
+	}
+
+	out := make(chan BatchResult)
+	// the last worker to return closes out
+	var remaining atomic.Int64
+	remaining.Store(int64(workers))
+	g := &group{running: &c.stats.goroutines}
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			defer func() {
+				if remaining.Add(-1) == 0 {
+					close(out)
+				}
+			}()
+			for {
+				var d Document
+				select {
+				case <-ctx.Done():
+					return nil
+				case doc, ok := <-in:
+					if !ok {
+						return nil
+					}
+					d = doc
+				}
+				a, err := c.AnalyzeTextContext(ctx, d.Text, copyParams(params), opts...)
+				select {
+				case <-ctx.Done():
+					return nil
+				case out <- BatchResult{Document: d, Analysis: a, Err: err}:
+				}
+			}
+		})
+	}
+	return out
+}
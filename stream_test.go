@@ -0,0 +1,91 @@
+package textrazor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			AnalyzeStream tests
+
+func TestAnalyzeStream(t *testing.T) {
+	transport := &classifyingTransport{categories: map[string][]ScoredCategory{
+		"football": {{CategoryID: "1", Label: "football"}},
+		"rugby":    {{CategoryID: "2", Label: "rugby"}},
+	}}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &lockedTransport{rt: transport})
+
+	in := make(chan Document)
+	go func() {
+		defer close(in)
+		for _, d := range []Document{{"d1", "rugby"}, {"d2", "cinema"}, {"d3", "football"}} {
+			in <- d
+		}
+	}()
+
+	results := map[string]BatchResult{}
+	for r := range client.AnalyzeStream(context.Background(), in, Params{"extractors": {"entities"}}, CallConcurrency(2)) {
+		results[r.Document.ID] = r
+	}
+	if len(results) != 3 {
+		t.Fatal("expect 3 results, got", results)
+	}
+	if r := results["d2"]; r.Err == nil || r.Analysis != nil {
+		t.Error("expect the analysis of cinema to fail, got", r)
+	}
+	for _, id := range []string{"d1", "d3"} {
+		r := results[id]
+		if r.Err != nil || len(r.Analysis.Categories) != 1 || r.Analysis.Categories[0].Label != r.Document.Text {
+			t.Errorf("expect the analysis of %s, got %v %v", id, r.Analysis, r.Err)
+		}
+	}
+}
+
+func TestAnalyzeStreamConcurrency(t *testing.T) {
+	transport := &concurrencyTransport{}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	in := make(chan Document, 12)
+	for i := 0; i < cap(in); i++ {
+		in <- Document{Text: testText}
+	}
+	close(in)
+	n := 0
+	for r := range client.AnalyzeStream(context.Background(), in, Params{"extractors": {"entities"}}, CallConcurrency(3)) {
+		if r.Err != nil {
+			t.Fatal(r.Err)
+		}
+		n++
+	}
+	if max := atomic.LoadInt32(&transport.max); n != 12 || max != 3 {
+		t.Errorf("expect 12 results of 3 concurrent analyses, got %d of %d", n, max)
+	}
+}
+
+func TestAnalyzeStreamCanceled(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, &concurrencyTransport{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// in is never closed and the results aren't read: the workers only stop with the context
+	in := make(chan Document, 4)
+	for i := 0; i < cap(in); i++ {
+		in <- Document{Text: testText}
+	}
+	out := client.AnalyzeStream(ctx, in, Params{"extractors": {"entities"}}, CallConcurrency(2))
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expect the results channel to be closed once the context is canceled")
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package textrazor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnmodeledFields matches an UnmodeledFieldsError with errors.Is
+var ErrUnmodeledFields = errors.New("unmodeled response fields")
+
+// WithStrictDecoding fails the calls whose responses have fields this package doesn't model with an *UnmodeledFieldsError,
+// e.g. in the tests of an integration, to notice when the API returns new fields.
+//
+// Unlike json.Decoder.DisallowUnknownFields, which stops at the first unknown field and doesn't apply to the structs
+// decoded by their own UnmarshalJSON, every unmodeled field is listed, at any depth.
+// The variations tolerated when decoding, e.g. numbers encoded as strings, aren't errors.
+func WithStrictDecoding() Option {
+	return func(c *Client) { c.strictDecoding = true }
+}
+
+// UnmodeledFieldsError is returned with strict decoding when a response has unmodeled fields,
+// the response is decoded anyway
+type UnmodeledFieldsError struct {
+	// Fields are the paths of the unmodeled fields, e.g. "response.entities[].sentiment"
+	Fields       []string
+	HTTPResponse *HTTPResponse
+}
+
+func (e *UnmodeledFieldsError) Error() string {
+	return fmt.Sprintf("response has %d unmodeled fields: %s", len(e.Fields), strings.Join(e.Fields, ", "))
+}
+
+// Is reports whether target is ErrUnmodeledFields
+func (e *UnmodeledFieldsError) Is(target error) bool { return target == ErrUnmodeledFields }
+
+// strictCheck returns an *UnmodeledFieldsError if the body of r has unmodeled fields
+func strictCheck(r *HTTPResponse) error {
+	findings, err := schemaFindings(r.Body, r.Response)
+	if err != nil {
+		return fmt.Errorf("http response body parsing failed: %v", err)
+	}
+	var fields []string
+	for _, f := range findings {
+		if path, ok := strings.CutSuffix(f, ": unmodeled field"); ok {
+			fields = append(fields, path)
+		}
+	}
+	if len(fields) > 0 {
+		return &UnmodeledFieldsError{Fields: fields, HTTPResponse: r}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package textrazor
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Strict decoding tests
+
+func TestStrictDecoding(t *testing.T) {
+	body := strings.Replace(textrazortest.AnalysisEntities, `"entityId"`, `"sentiment": 0.5, "entityId"`, 1)
+	body = strings.Replace(body, `"language"`, `"version": "2", "language"`, 1)
+	client := func(body string, opts ...Option) *Client {
+		return NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, body), opts...)
+	}
+
+	if _, err := client(body).AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal("expect unmodeled fields to be ignored by default, got", err)
+	}
+	if _, err := client(textrazortest.AnalysisFull, WithStrictDecoding()).AnalyzeText(testText, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal("expect a fully modeled response to be decoded, got", err)
+	}
+
+	_, err := client(body, WithStrictDecoding()).AnalyzeText(testText, Params{"extractors": {"entities"}})
+	var strictErr *UnmodeledFieldsError
+	if !errors.As(err, &strictErr) || !errors.Is(err, ErrUnmodeledFields) {
+		t.Fatal("expect an UnmodeledFieldsError, got", err)
+	}
+	if expect := []string{"response.entities[].sentiment", "response.version"}; !reflect.DeepEqual(strictErr.Fields, expect) {
+		t.Errorf("expect the unmodeled fields %v, got %v", expect, strictErr.Fields)
+	}
+	if a, ok := strictErr.HTTPResponse.Response.(*Analysis); !ok || len(a.Entities) != 4 {
+		t.Error("expect the response to be decoded anyway, got", strictErr.HTTPResponse.Response)
+	}
+	if !strings.Contains(err.Error(), "response.entities[].sentiment, response.version") {
+		t.Error("expect the fields to be listed, got", err)
+	}
+}
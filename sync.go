@@ -0,0 +1,91 @@
+package textrazor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// DictionaryDiff lists the changes needed to make the entries of a dictionary match the desired entries
+type DictionaryDiff struct {
+	Add    []DictionaryEntry
+	Update []DictionaryEntry
+	// Delete holds the ids of the entries to delete
+	Delete []string
+}
+
+// Empty reports whether the dictionary already matches the desired entries
+func (d *DictionaryDiff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Update) == 0 && len(d.Delete) == 0
+}
+
+// DiffDictionary compares remote entries with the desired entries, matched by id.
+// An entry is updated when its text or its data differs.
+//
+// every desired entry must have a unique id
+func DiffDictionary(remote, desired []DictionaryEntry) (*DictionaryDiff, error) {
+	existing := make(map[string]DictionaryEntry, len(remote))
+	for _, e := range remote {
+		existing[e.ID] = e
+	}
+
+	diff := &DictionaryDiff{}
+	seen := make(map[string]bool, len(desired))
+	for _, e := range desired {
+		if e.ID == "" {
+			return nil, fmt.Errorf("dictionary entry '%s' has no id", e.Text)
+		}
+		if seen[e.ID] {
+			return nil, fmt.Errorf("duplicate dictionary entry id '%s'", e.ID)
+		}
+		seen[e.ID] = true
+
+		r, ok := existing[e.ID]
+		switch {
+		case !ok:
+			diff.Add = append(diff.Add, e)
+		case r.Text != e.Text || !r.Data.Equal(e.Data):
+			diff.Update = append(diff.Update, e)
+		}
+	}
+	for id := range existing {
+		if !seen[id] {
+			diff.Delete = append(diff.Delete, id)
+		}
+	}
+	sort.Strings(diff.Delete)
+	return diff, nil
+}
+
+// SyncDictionary makes the entries of a dictionary match the desired entries, applying only the changes:
+// new and modified entries are added, as the API replaces an entry added with an existing id,
+// and the entries missing from desired are deleted. It returns the applied changes.
+//
+// Calling it again with the same entries does nothing.
+func (c *Client) SyncDictionary(dictID string, desired []DictionaryEntry) (*DictionaryDiff, error) {
+	return c.SyncDictionaryContext(context.Background(), dictID, desired)
+}
+
+// SyncDictionaryContext is like SyncDictionary with a context
+func (c *Client) SyncDictionaryContext(ctx context.Context, dictID string, desired []DictionaryEntry, opts ...CallOption) (*DictionaryDiff, error) {
+	remote, err := c.AllDictionaryEntriesContext(ctx, dictID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary entries listing failed: %w", err)
+	}
+	diff, err := DiffDictionary(remote, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if upserts := append(append([]DictionaryEntry(nil), diff.Add...), diff.Update...); len(upserts) > 0 {
+		if _, err := c.AddDictionaryEntriesContext(ctx, dictID, upserts, opts...); err != nil {
+			return nil, fmt.Errorf("dictionary entries update failed: %w", err)
+		}
+	}
+	for _, id := range diff.Delete {
+		if _, err := c.DeleteDictionaryEntryContext(ctx, dictID, id, opts...); err != nil {
+			return nil, fmt.Errorf("dictionary entry '%s' deletion failed: %w", id, err)
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,131 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//***************************************************************
+// 			SyncDictionary tests
+
+// dictionaryServer is an in-memory implementation of the dictionary entries endpoints
+type dictionaryServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	entries map[string]DictionaryEntry
+	writes  int
+}
+
+func newDictionaryServer(entries ...DictionaryEntry) *dictionaryServer {
+	s := &dictionaryServer{entries: map[string]DictionaryEntry{}}
+	for _, e := range entries {
+		s.entries[e.ID] = e
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *dictionaryServer) client() *Client {
+	return NewCustomClient(testAPIKey, DefaultUseCompression, false, s.URL, s.URL, http.DefaultTransport)
+}
+
+func (s *dictionaryServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	reply := map[string]interface{}{"ok": true}
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[2] == "_all":
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		list := DictionaryEntryList{Limit: limit, Offset: offset, Total: len(s.entries), Entries: []DictionaryEntry{}}
+		for _, e := range s.sorted() {
+			if offset > 0 {
+				offset--
+				continue
+			}
+			if len(list.Entries) < limit {
+				list.Entries = append(list.Entries, e)
+			}
+		}
+		reply["response"] = list
+	case r.Method == http.MethodPost && len(parts) == 2:
+		s.writes++
+		var entries []DictionaryEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, e := range entries {
+			s.entries[e.ID] = e
+		}
+	case r.Method == http.MethodDelete && len(parts) == 3:
+		s.writes++
+		delete(s.entries, parts[2])
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(reply)
+}
+
+func (s *dictionaryServer) sorted() []DictionaryEntry {
+	var entries []DictionaryEntry
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries
+}
+
+func TestSyncDictionary(t *testing.T) {
+	server := newDictionaryServer(
+		DictionaryEntry{ID: "DEV1", Text: "Ken Thompson"},
+		DictionaryEntry{ID: "DEV2", Text: "Bjarne Stroustrup"},
+		DictionaryEntry{ID: "DEV3", Text: "Rob Pike", Data: EntryData{"lang": {"go"}}},
+	)
+	defer server.Close()
+	client := server.client()
+
+	desired := []DictionaryEntry{
+		{ID: "DEV1", Text: "Ken Thompson", Data: EntryData{}},
+		{ID: "DEV3", Text: "Rob Pike", Data: EntryData{"lang": {"Go"}}},
+		{ID: "DEV4", Text: "Robert Griesemer"},
+	}
+	diff, err := client.SyncDictionary(dictID, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Add) != 1 || diff.Add[0].ID != "DEV4" || len(diff.Update) != 1 || diff.Update[0].ID != "DEV3" || len(diff.Delete) != 1 || diff.Delete[0] != "DEV2" {
+		t.Errorf("expect DEV4 added, DEV3 updated and DEV2 deleted, got %+v", diff)
+	}
+	if entries := server.sorted(); len(entries) != 3 || entries[1].Data.Get("lang") != "Go" || entries[2].ID != "DEV4" {
+		t.Error("expect the dictionary to match the desired entries, got", entries)
+	}
+
+	writes := server.writes
+	diff, err = client.SyncDictionary(dictID, desired)
+	if err != nil || !diff.Empty() || server.writes != writes {
+		t.Errorf("expect a second sync to do nothing, got %+v %v", diff, err)
+	}
+}
+
+func TestDiffDictionaryErrors(t *testing.T) {
+	var tests = [][]DictionaryEntry{
+		{{Text: "no id"}},
+		{{ID: "DEV1"}, {ID: "DEV1"}},
+	}
+	for _, desired := range tests {
+		if _, err := DiffDictionary(nil, desired); err == nil {
+			t.Error("expect", desired, "to fail")
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package textrazor
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Tags label the requests of a context, e.g. {"pipeline": "news", "tenant": "acme"}, so a client shared by several
+// pipelines attributes its usage: they are set in the RequestMetrics sent to the MetricsSink and appended to the logs.
+type Tags map[string]string
+
+// tagsKey is the context key of the tags
+type tagsKey struct{}
+
+// WithTags returns a context whose requests are labeled with tags, added to the tags of ctx, if any
+func WithTags(ctx context.Context, tags Tags) context.Context {
+	merged := Tags{}
+	for k, v := range TagsFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, tagsKey{}, merged)
+}
+
+// TagsFromContext returns the tags of a context, nil if there is none.
+// The tags mustn't be modified.
+func TagsFromContext(ctx context.Context) Tags {
+	tags, _ := ctx.Value(tagsKey{}).(Tags)
+	return tags
+}
+
+// String returns the tags sorted by key, e.g. "pipeline=news tenant=acme"
+func (t Tags) String() string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + t[k]
+	}
+	return strings.Join(pairs, " ")
+}
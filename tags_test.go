@@ -0,0 +1,66 @@
+package textrazor
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Context tags tests
+
+func TestWithTags(t *testing.T) {
+	ctx := WithTags(context.Background(), Tags{"pipeline": "news", "tenant": "acme"})
+	child := WithTags(ctx, Tags{"tenant": "globex", "stage": "enrich"})
+
+	if tags := TagsFromContext(ctx); !reflect.DeepEqual(tags, Tags{"pipeline": "news", "tenant": "acme"}) {
+		t.Error("expect the tags of the parent unchanged, got", tags)
+	}
+	if tags := TagsFromContext(child); tags.String() != "pipeline=news stage=enrich tenant=globex" {
+		t.Error("expect the tags merged, got", tags)
+	}
+	if tags := TagsFromContext(context.Background()); tags != nil {
+		t.Error("expect no tags, got", tags)
+	}
+}
+
+func TestTagsMetricsAndLogger(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	var logs bytes.Buffer
+	metrics := &recordingMetrics{}
+	transport := textrazortest.NewSequenceTransport(rateLimited, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithRateLimitRetries(1, time.Second), WithMetrics(metrics), WithLogger(log.New(&logs, "", 0)))
+
+	ctx := WithTags(context.Background(), Tags{"pipeline": "news", "tenant": "100%"})
+	if _, err := client.GetAccountContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(metrics.metrics) != 3 {
+		t.Fatal("expect the metrics of 3 attempts, got", metrics.metrics)
+	}
+	for i, m := range metrics.metrics[:2] {
+		if m.Tags.String() != "pipeline=news tenant=100%" {
+			t.Errorf("expect the tags of the context in the attempt %d, got %v", i, m.Tags)
+		}
+	}
+	if m := metrics.metrics[2]; m.Tags != nil {
+		t.Error("expect no tags without tagged context, got", m.Tags)
+	}
+	if !strings.Contains(logs.String(), "textrazor: GET /account/ rate limited, retry 1 in 1ms [pipeline=news tenant=100%]\n") {
+		t.Error("expect the tags to be logged, got", logs.String())
+	}
+}
@@ -0,0 +1,292 @@
+package textrazor
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// TaxonomyOptions maps the columns of a spreadsheet to the fields of classifier categories,
+// see ReadCategoriesCSV and ReadCategoriesXLSX
+type TaxonomyOptions struct {
+	// SkipRows is the number of rows skipped before the header or the first category, e.g. a title
+	SkipRows int
+	// Header reads the first row after SkipRows as the names of the columns
+	Header bool
+	// IDColumn, LabelColumn and QueryColumn are the columns of the id, label and query of the categories,
+	// "A", "B" and "C" by default. A column is a header name when Header is set, or a column letter.
+	IDColumn, LabelColumn, QueryColumn string
+	// Comma is the field delimiter of CSV, ',' by default, '\t' for TSV
+	Comma rune
+	// Sheet is the name of the XLSX worksheet, the first one by default
+	Sheet string
+}
+
+// ReadCategoriesCSV reads the categories of a classifier from a CSV spreadsheet, e.g. exported from Google Sheets.
+// Empty rows are skipped, a row without id or query is an error.
+func ReadCategoriesCSV(r io.Reader, o TaxonomyOptions) ([]Category, error) {
+	cr := csv.NewReader(r)
+	if o.Comma != 0 {
+		cr.Comma = o.Comma
+	}
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("taxonomy import failed: %w", err)
+	}
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return categoriesFromRows(rows, o)
+}
+
+// ReadCategoriesXLSX reads the categories of a classifier from a worksheet of an XLSX workbook of the given size,
+// like ReadCategoriesCSV. Only the values of the cells are read, formulas and formats are ignored.
+func ReadCategoriesXLSX(r io.ReaderAt, size int64, o TaxonomyOptions) ([]Category, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("taxonomy import failed: %w", err)
+	}
+	rows, err := readXLSXSheet(zr, o.Sheet)
+	if err != nil {
+		return nil, fmt.Errorf("taxonomy import failed: %w", err)
+	}
+	return categoriesFromRows(rows, o)
+}
+
+// CreateClassifierFromCategories creates a new classifier from categories, e.g. read by ReadCategoriesCSV
+func (c *Client) CreateClassifierFromCategories(ID string, categories []Category) (*HTTPResponse, error) {
+	return c.CreateClassifierFromCategoriesContext(context.Background(), ID, categories)
+}
+
+// CreateClassifierFromCategoriesContext is like CreateClassifierFromCategories with a context
+func (c *Client) CreateClassifierFromCategoriesContext(ctx context.Context, ID string, categories []Category, opts ...CallOption) (*HTTPResponse, error) {
+	b, err := json.Marshal(categories)
+	if err != nil {
+		return nil, fmt.Errorf("classifier encoding failed: %v", err)
+	}
+	return c.CreateClassifierFromJSONContext(ctx, ID, string(b), opts...)
+}
+
+// categoriesFromRows returns the categories of the rows of a spreadsheet
+func categoriesFromRows(rows [][]string, o TaxonomyOptions) ([]Category, error) {
+	if o.SkipRows >= len(rows) {
+		return nil, nil
+	}
+	rows = rows[o.SkipRows:]
+	var header []string
+	if o.Header {
+		header, rows = rows[0], rows[1:]
+	}
+
+	var columns [3]int
+	for i, name := range [3]string{o.IDColumn, o.LabelColumn, o.QueryColumn} {
+		if name == "" {
+			name = string(rune('A' + i))
+		}
+		index, err := taxonomyColumn(name, header)
+		if err != nil {
+			return nil, fmt.Errorf("taxonomy import failed: %v", err)
+		}
+		columns[i] = index
+	}
+
+	var categories []Category
+	for i, row := range rows {
+		cell := func(column int) string {
+			if column >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[column])
+		}
+		cat := Category{CategoryID: cell(columns[0]), Label: cell(columns[1]), Query: cell(columns[2])}
+		if cat == (Category{}) {
+			continue
+		}
+		if cat.CategoryID == "" || cat.Query == "" {
+			line := i + 1 + o.SkipRows
+			if o.Header {
+				line++
+			}
+			return nil, fmt.Errorf("taxonomy import failed: row %d: expect an id and a query, got %q", line, row)
+		}
+		categories = append(categories, cat)
+	}
+	return categories, nil
+}
+
+// taxonomyColumn returns the index of a column, a name of the header or a column letter
+func taxonomyColumn(name string, header []string) (int, error) {
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+			return i, nil
+		}
+	}
+	if index, ok := columnIndex(name); ok {
+		return index, nil
+	}
+	return -1, fmt.Errorf("unknown column %q", name)
+}
+
+// columnIndex returns the index of a spreadsheet column letter, e.g. 0 for "A" and 27 for "AB",
+// up to the 3 letters of the last column of a spreadsheet
+func columnIndex(letters string) (int, bool) {
+	if len(letters) > 3 {
+		return -1, false
+	}
+	index := 0
+	for _, r := range strings.ToUpper(letters) {
+		if r < 'A' || r > 'Z' {
+			return -1, false
+		}
+		index = index*26 + int(r-'A') + 1
+	}
+	return index - 1, index > 0
+}
+
+// xlsxText is a text of the shared strings, or an inline string, split in runs when formatted
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	s := t.T
+	for _, r := range t.Runs {
+		s += r.T
+	}
+	return s
+}
+
+// readXLSXSheet returns the rows of the cell values of a worksheet, the first one when name is empty
+func readXLSXSheet(zr *zip.Reader, name string) ([][]string, error) {
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeZipXML(zr, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if err := decodeZipXML(zr, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+
+	rID := ""
+	for _, s := range workbook.Sheets {
+		if name == "" || s.Name == name {
+			rID = s.RID
+			break
+		}
+	}
+	target := ""
+	for _, r := range rels.Relationships {
+		if rID != "" && r.ID == rID {
+			target = r.Target
+		}
+	}
+	if target == "" {
+		return nil, fmt.Errorf("worksheet %q not found", name)
+	}
+	if strings.HasPrefix(target, "/") {
+		target = strings.TrimPrefix(target, "/")
+	} else {
+		target = path.Join("xl", target)
+	}
+
+	var shared struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if err := decodeZipXML(zr, "xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, errZipFileNotFound) {
+		return nil, err
+	}
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R      string   `xml:"r,attr"`
+				T      string   `xml:"t,attr"`
+				V      string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeZipXML(zr, target, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, r := range sheet.Rows {
+		// missing rows are empty
+		for r.R > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+		var row []string
+		for _, c := range r.Cells {
+			column := len(row)
+			if c.R != "" {
+				index, ok := columnIndex(strings.TrimRight(c.R, "0123456789"))
+				if !ok {
+					return nil, fmt.Errorf("invalid cell reference %q", c.R)
+				}
+				column = index
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			switch c.T {
+			case "s":
+				i, err := strconv.Atoi(c.V)
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, fmt.Errorf("invalid shared string %q in cell %s", c.V, c.R)
+				}
+				row[column] = shared.Items[i].String()
+			case "inlineStr":
+				row[column] = c.Inline.String()
+			default:
+				row[column] = c.V
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+var errZipFileNotFound = errors.New("file not found")
+
+// decodeZipXML decodes the XML file of a zip archive into v
+func decodeZipXML(zr *zip.Reader, name string, v interface{}) error {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		if err := xml.NewDecoder(rc).Decode(v); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: %w", name, errZipFileNotFound)
+}
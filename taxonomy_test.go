@@ -0,0 +1,129 @@
+package textrazor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Taxonomy import tests
+
+// xlsxWorkbook returns an XLSX workbook with a "Notes" sheet and a "Taxonomy" sheet
+func xlsxWorkbook(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Notes" sheetId="1" r:id="rId1"/><sheet name="Taxonomy" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Category</t></si><si><t>Name</t></si><si><t>Query</t></si><si><r><t>Sport</t></r><r><t xml:space="preserve"> news</t></r></si><si><t>or(concept('sport'))</t></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>notes</t></is></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>Editorial taxonomy</t></is></c></row>
+<row r="3"><c r="B3" t="s"><v>0</v></c><c r="C3" t="s"><v>2</v></c><c r="D3" t="s"><v>1</v></c></row>
+<row r="4"><c r="B4"><v>1</v></c><c r="C4" t="s"><v>4</v></c><c r="D4" t="s"><v>3</v></c></row>
+<row r="6"><c r="B6" t="str"><v>2</v></c><c r="C6" t="inlineStr"><is><t>or(concept('politics'))</t></is></c></row>
+</sheetData></worksheet>`,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.WriteString(w, content)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadCategoriesXLSX(t *testing.T) {
+	b := xlsxWorkbook(t)
+	categories, err := ReadCategoriesXLSX(bytes.NewReader(b), int64(len(b)), TaxonomyOptions{
+		Sheet: "Taxonomy", SkipRows: 2, Header: true, IDColumn: "category", LabelColumn: "Name", QueryColumn: "C",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []Category{
+		{CategoryID: "1", Label: "Sport news", Query: "or(concept('sport'))"},
+		{CategoryID: "2", Query: "or(concept('politics'))"},
+	}
+	if !reflect.DeepEqual(categories, expect) {
+		t.Errorf("expect %+v, got %+v", expect, categories)
+	}
+
+	var tests = []struct {
+		o      TaxonomyOptions
+		expect string
+	}{
+		{TaxonomyOptions{Sheet: "Missing"}, `worksheet "Missing" not found`},
+		{TaxonomyOptions{}, `row 1: expect an id and a query, got ["notes"]`},
+		{TaxonomyOptions{Sheet: "Taxonomy", Header: true, SkipRows: 2, IDColumn: "Identifier"}, `unknown column "Identifier"`},
+	}
+	for _, tt := range tests {
+		if _, err := ReadCategoriesXLSX(bytes.NewReader(b), int64(len(b)), tt.o); err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%+v: expect the error %s, got %v", tt.o, tt.expect, err)
+		}
+	}
+	if _, err := ReadCategoriesXLSX(strings.NewReader("id,label"), 8, TaxonomyOptions{}); err == nil {
+		t.Error("expect an error for a file which isn't a workbook")
+	}
+}
+
+func TestReadCategoriesCSV(t *testing.T) {
+	var tests = []struct {
+		csv    string
+		o      TaxonomyOptions
+		expect []Category
+		err    string
+	}{
+		{"1,Sport,or(concept('sport'))\n\n2,,or(concept('politics'))\n", TaxonomyOptions{},
+			[]Category{{CategoryID: "1", Label: "Sport", Query: "or(concept('sport'))"}, {CategoryID: "2", Query: "or(concept('politics'))"}}, ""},
+		{"\ufeffQuery;ID;Label\nor(concept('sport'));1;Sport\n", TaxonomyOptions{Header: true, Comma: ';', IDColumn: "id", LabelColumn: "label", QueryColumn: "query"},
+			[]Category{{CategoryID: "1", Label: "Sport", Query: "or(concept('sport'))"}}, ""},
+		{"Taxonomy\n1,Sport\n", TaxonomyOptions{SkipRows: 1}, nil, "row 2: expect an id and a query"},
+		{"1,\"Sport\n", TaxonomyOptions{}, nil, "taxonomy import failed"},
+		{"", TaxonomyOptions{Header: true}, nil, ""},
+	}
+	for _, tt := range tests {
+		categories, err := ReadCategoriesCSV(strings.NewReader(tt.csv), tt.o)
+		if (err == nil) != (tt.err == "") || (err != nil && !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%q: expect the error '%s', got %v", tt.csv, tt.err, err)
+		}
+		if !reflect.DeepEqual(categories, tt.expect) {
+			t.Errorf("%q: expect %+v, got %+v", tt.csv, tt.expect, categories)
+		}
+	}
+}
+
+func TestCreateClassifierFromCategories(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: `{"ok": true}`})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+	categories := []Category{{CategoryID: "1", Label: "Sport", Query: "or(concept('sport'))"}}
+	if _, err := client.CreateClassifierFromCategories("editorial", categories); err != nil {
+		t.Fatal(err)
+	}
+
+	req := transport.Requests()[0]
+	var sent []Category
+	body, _ := io.ReadAll(req.Body)
+	if err := json.Unmarshal(body, &sent); err != nil || !reflect.DeepEqual(sent, categories) {
+		t.Errorf("expect the categories to be sent, got %s %v", body, err)
+	}
+	if req.Method != http.MethodPut || !strings.HasSuffix(req.URL.Path, "/categories/editorial") {
+		t.Error("unexpected request", req.Method, req.URL)
+	}
+}
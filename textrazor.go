@@ -5,11 +5,14 @@ package textrazor
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // default values used by NewDefaultClient
@@ -18,6 +21,8 @@ const (
 	DefaultSecureEndpoint = "https://api.textrazor.com"
 	DefaultUseCompression = true
 	DefaultUseEncryption  = true
+	// DefaultKeepResponseBody keeps the raw body of the responses in HTTPResponse.Body, see WithResponseBody
+	DefaultKeepResponseBody = true
 )
 
 const (
@@ -83,11 +88,14 @@ func (r *EmptyResponse) setHTTPResponse(*HTTPResponse) {}
 
 // HTTPResponse https://www.textrazor.com/docs/rest#TextRazorResponse
 type HTTPResponse struct {
-	Status   int         `json:"-"`
-	Headers  http.Header `json:"-"`
-	Body     []byte      `json:"-"`
-	Time     float32     `json:"time"`
-	Response Response    `json:"response"`
+	Status  int         `json:"-"`
+	Headers http.Header `json:"-"`
+	// Body is the raw body of the response, nil for successful responses with WithResponseBody(false)
+	Body     []byte   `json:"-"`
+	Time     float64  `json:"time"`
+	Response Response `json:"response"`
+	// Meta describes how the response was received
+	Meta CallMeta `json:"-"`
 
 	// FIXME: most replies returns an object called 'response', except for 'GET /entities/'
 	// which returns a json array called 'dictionaries'
@@ -104,65 +112,93 @@ func (r *HTTPResponse) ParseBody() error {
 }
 
 // Analysis https://www.textrazor.com/docs/rest#TextRazorResponse
+//
+// An analysis encoded with encoding/json, e.g. to be stored, decodes to the same analysis but its HTTPResponse,
+// word references are resolved again with ResolveReferences.
 type Analysis struct {
 	HTTPResponse           *HTTPResponse    `json:"-"`
 	CustomAnnotationOutput string           `json:"customAnnotationOutput"`
+	Language               string           `json:"language"`
+	LanguageIsReliable     bool             `json:"languageIsReliable"`
 	CleanedText            string           `json:"cleanedText"`
 	RawText                string           `json:"rawText"`
 	Entailments            []Entailment     `json:"entailments"`
 	Entities               []Entity         `json:"entities"`
 	Topics                 []Topic          `json:"topics"`
+	CoarseTopics           []Topic          `json:"coarseTopics"`
 	Categories             []ScoredCategory `json:"categories"`
 	NounPhrases            []NounPhrase     `json:"nounPhrases"`
 	Properties             []Property       `json:"properties"`
 	Relations              []Relation       `json:"relations"`
 	Sentences              []Sentence       `json:"sentences"`
 	MatchingRules          []string         `json:"matchingRules"`
+	// Fingerprint identifies the options of the analysis, set by the client, see Client.Fingerprint
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// word references are resolved, including when decoding, see WithResolvedReferences
+	resolveRefs bool
+	// entities kept when decoding, see WithMaxEntities
+	maxEntities int
+	// size from which the sections are decoded concurrently, see WithConcurrentDecode
+	concurrentDecode int
 }
 
 func (a *Analysis) setHTTPResponse(r *HTTPResponse) { a.HTTPResponse = r }
 
 // Entity https://www.textrazor.com/docs/rest#Entity
 type Entity struct {
-	ID              int               `json:"id"`
-	EntityID        string            `json:"entityId"`
-	EntityEnglishID string            `json:"entityEnglishId"`
-	CustomEntityID  string            `json:"customEntityId"`
-	ConfidenceScore float32           `json:"confidenceScore"`
-	Types           []string          `json:"type"`
-	FreebaseTypes   []string          `json:"freebaseTypes"`
-	FreebaseID      string            `json:"freebaseId"`
-	WikidataID      string            `json:"wikidataId"`
-	MatchingTokens  []int             `json:"matchingTokens"`
-	MatchedText     string            `json:"matchedText"`
-	Data            map[string]string `json:"data"`
-	RelevanceScore  float32           `json:"relevanceScore"`
-	WikiLink        string            `json:"wikiLink"`
+	ID              int       `json:"id"`
+	EntityID        string    `json:"entityId"`
+	EntityEnglishID string    `json:"entityEnglishId"`
+	CustomEntityID  string    `json:"customEntityId"`
+	ConfidenceScore float64   `json:"confidenceScore"`
+	Types           []string  `json:"type"`
+	FreebaseTypes   []string  `json:"freebaseTypes"`
+	FreebaseID      string    `json:"freebaseId"`
+	WikidataID      string    `json:"wikidataId"`
+	MatchingTokens  []int     `json:"matchingTokens"`
+	MatchedText     string    `json:"matchedText"`
+	StartingPos     int       `json:"startingPos"`
+	EndingPos       int       `json:"endingPos"`
+	Data            EntryData `json:"data"`
+	RelevanceScore  float64   `json:"relevanceScore"`
+	WikiLink        string    `json:"wikiLink"`
+
+	// Extra holds the fields returned by the API that Entity doesn't model
+	Extra map[string]json.RawMessage `json:"-"`
+	// Words matching MatchingTokens, set by Analysis.ResolveReferences
+	Words []*Word `json:"-"`
 }
 
 // Topic https://www.textrazor.com/docs/rest#Topic
 type Topic struct {
+	ID         int     `json:"id"`
 	Label      string  `json:"label"`
-	Score      float32 `json:"score"`
+	Score      float64 `json:"score"`
 	WikiLink   string  `json:"wikiLink"`
 	WikidataID string  `json:"wikidataId"`
 }
 
 // ScoredCategory https://www.textrazor.com/docs/rest#ScoredCategory
 type ScoredCategory struct {
+	ID           int     `json:"id"`
 	CategoryID   string  `json:"categoryId"`
 	Label        string  `json:"label"`
-	Score        float32 `json:"score"`
+	Score        float64 `json:"score"`
 	ClassifierID string  `json:"classifierId"`
 }
 
 // Entailment https://www.textrazor.com/docs/rest#Entailment
 type Entailment struct {
-	ContextScore  float32           `json:"contextScore"`
-	EntailedTree  map[string]string `json:"entailedTree"`
-	WordPositions []int             `json:"wordPositions"`
-	PriorScore    float32           `json:"priorScore"`
-	Score         float32           `json:"score"`
+	ID            int           `json:"id"`
+	ContextScore  float64       `json:"contextScore"`
+	EntailedTree  *EntailedWord `json:"entailedTree"`
+	WordPositions []int         `json:"wordPositions"`
+	PriorScore    float64       `json:"priorScore"`
+	Score         float64       `json:"score"`
+
+	// Words matching WordPositions, set by Analysis.ResolveReferences
+	Words []*Word `json:"-"`
 }
 
 // RelationType defines the type for the relation field in RelationParam
@@ -178,50 +214,87 @@ const (
 // RelationParam https://www.textrazor.com/docs/rest#RelationParam
 type RelationParam struct {
 	WordPositions []int        `json:"wordPositions"`
-	Relation      RelationType `json:"classifierId"`
+	Relation      RelationType `json:"relation"`
+
+	// Words matching WordPositions, set by Analysis.ResolveReferences
+	Words []*Word `json:"-"`
 }
 
 // NounPhrase https://www.textrazor.com/docs/rest#NounPhrase
 type NounPhrase struct {
+	ID            int   `json:"id"`
 	WordPositions []int `json:"wordPositions"`
+
+	// Words matching WordPositions, set by Analysis.ResolveReferences
+	Words []*Word `json:"-"`
 }
 
 // Property https://www.textrazor.com/docs/rest#Property
 type Property struct {
+	ID                int   `json:"id"`
 	WordPositions     []int `json:"wordPositions"`
 	PropertyPositions []int `json:"propertyPositions"`
+
+	// Words matching WordPositions and PropertyPositions, set by Analysis.ResolveReferences
+	Words         []*Word `json:"-"`
+	PropertyWords []*Word `json:"-"`
 }
 
 // Relation https://www.textrazor.com/docs/rest#Relation
 type Relation struct {
+	ID            int             `json:"id"`
 	Params        []RelationParam `json:"params"`
 	WordPositions []int           `json:"wordPositions"`
+
+	// Words matching WordPositions, set by Analysis.ResolveReferences
+	Words []*Word `json:"-"`
 }
 
-// SenseScore defines a map with scores of each Wordnet sense the word may be a part of
-type SenseScore map[string]float32
+// Sense is a Wordnet sense the word may be a part of, with its score
+type Sense struct {
+	Sense string  `json:"sense"`
+	Score float64 `json:"score"`
+}
 
-// SuggestionScore defines a map with scores of each spelling suggestion that might replace the word
-type SuggestionScore map[string]float32
+// SpellingSuggestion is a spelling that might replace the word, with its score
+type SpellingSuggestion struct {
+	Suggestion string  `json:"suggestion"`
+	Score      float64 `json:"score"`
+}
 
 // Word https://www.textrazor.com/docs/rest#Word
 type Word struct {
-	EndingPos           int               `json:"endingPos"`
-	StartingPos         int               `json:"startingPos"`
-	Lemma               string            `json:"lemma"`
-	ParentPosition      int               `json:"parentPosition"`
-	PartOfSpeech        string            `json:"partOfSpeech"`
-	Senses              []SenseScore      `json:"senses"`
-	SpellingSuggestions []SuggestionScore `json:"spellingSuggestions"`
-	Position            int               `json:"position"`
-	RelationToParent    string            `json:"relationToParent"`
-	Stem                string            `json:"stem"`
-	Token               string            `json:"token"`
+	EndingPos           int                  `json:"endingPos"`
+	StartingPos         int                  `json:"startingPos"`
+	Lemma               string               `json:"lemma"`
+	ParentPosition      int                  `json:"parentPosition"`
+	PartOfSpeech        string               `json:"partOfSpeech"`
+	Senses              []Sense              `json:"senses"`
+	SpellingSuggestions []SpellingSuggestion `json:"spellingSuggestions"`
+	Position            int                  `json:"position"`
+	RelationToParent    string               `json:"relationToParent"`
+	Stem                string               `json:"stem"`
+	Token               string               `json:"token"`
+}
+
+// TopSense returns the sense of the word with the best score, false if the word has no sense
+func (w *Word) TopSense() (Sense, bool) {
+	if len(w.Senses) == 0 {
+		return Sense{}, false
+	}
+	top := w.Senses[0]
+	for _, s := range w.Senses[1:] {
+		if s.Score > top.Score {
+			top = s
+		}
+	}
+	return top, true
 }
 
 // Sentence https://www.textrazor.com/docs/rest#Sentence
 type Sentence struct {
-	Words []Word `json:"words"`
+	Position int    `json:"position"`
+	Words    []Word `json:"words"`
 }
 
 // Dictionary https://www.textrazor.com/docs/rest#Dictionary
@@ -231,6 +304,9 @@ type Dictionary struct {
 	CaseInsensitive bool          `json:"caseInsensitive"`
 	ID              string        `json:"id"`
 	Language        string        `json:"language"`
+
+	// Extra holds the fields returned by the API that Dictionary doesn't model, they are sent back by CreateDictionary
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Encode encodes the Dictionary struct in JSON
@@ -243,10 +319,10 @@ func (d *Dictionary) setHTTPResponse(r *HTTPResponse) { d.HTTPResponse = r }
 
 // DictionaryEntry https://www.textrazor.com/docs/rest#DictionaryEntry
 type DictionaryEntry struct {
-	HTTPResponse *HTTPResponse     `json:"-"`
-	ID           string            `json:"id"`
-	Text         string            `json:"text"`
-	Data         map[string]string `json:"data"`
+	HTTPResponse *HTTPResponse `json:"-"`
+	ID           string        `json:"id"`
+	Text         string        `json:"text"`
+	Data         EntryData     `json:"data"`
 }
 
 func (e *DictionaryEntry) setHTTPResponse(r *HTTPResponse) { e.HTTPResponse = r }
@@ -295,7 +371,7 @@ type Account struct {
 	Plan                   string        `json:"plan"`
 	ConcurrentRequestLimit int           `json:"concurrentRequestLimit"`
 	ConcurrentRequestsUsed int           `json:"concurrentRequestsUsed"`
-	// FIXME: the DOC says planDailyIncludedRequests but the api responds with planDailyRequestsIncluded
+	// the doc says planDailyIncludedRequests but the api responds with planDailyRequestsIncluded, both are accepted
 	PlanDailyIncludedRequests int `json:"planDailyRequestsIncluded"`
 	RequestsUsedToday         int `json:"requestsUsedToday"`
 }
@@ -307,102 +383,261 @@ func DefaultHeaders(contentType string) http.Header {
 	return http.Header{"Content-Type": {contentType}}
 }
 
-// DefaultTransport creates a compressed or uncompressed http.Transport
+// DefaultTransport creates a compressed or uncompressed http.Transport, with the default timeouts
 func DefaultTransport(useCompression bool) http.RoundTripper {
-	return &http.Transport{
-		DisableCompression: !useCompression,
-	}
+	return TimeoutTransport(useCompression, DefaultDialTimeout, DefaultTLSHandshakeTimeout, DefaultResponseHeaderTimeout)
 }
 
 // Client defines a TextRazor http client
 type Client struct {
-	apiKey         string
+	keys           KeyProvider
 	useCompression bool
 	UseEncryption  bool
 	Endpoint       string
 	SecureEndpoint string
 	httpTransport  http.RoundTripper
-}
+
+	// retry policy for rate limited requests, see WithRateLimitRetries
+	maxRetries   int
+	maxRetryWait time.Duration
+
+	// limits concurrent Analyze calls, see WithAccountConcurrencyLimit
+	concurrency *concurrencyLimiter
+	// daily requests budget, see WithQuotaGuard
+	quota *QuotaGuard
+	// throttles every HTTP request, see WithRateLimiter
+	rateLimiter RateLimiter
+	// default timeout of API calls, see WithTimeout
+	timeout time.Duration
+	// memoized GetAccount results, see WithAccountCache
+	accountCache *accountCache
+
+	// fraction of the remaining time reserved to decode responses, see WithDecodeReserve
+	decodeReserve float64
+	// parameters added to every analysis, see WithDefaultParams
+	defaultParams Params
+
+	// counters exposed by Snapshot
+	stats clientStats
+	// resolve word references of analyses, see WithResolvedReferences
+	resolveRefs bool
+	// entities kept when decoding analyses, see WithMaxEntities
+	maxEntities int
+	// size from which the sections of analyses are decoded concurrently, see WithConcurrentDecode
+	concurrentDecode int
+	// texts are truncated to this size, see WithTruncation
+	maxTextSize int
+	// fail on unmodeled response fields, see WithStrictDecoding
+	strictDecoding  bool
+	maxResponseSize int64
+	keepBody        bool
+	// what to do when the language of an analysis isn't the languageOverride, see WithLanguageMismatchPolicy
+	languagePolicy LanguageMismatchPolicy
+	// transforms applied to texts before their analysis, see WithTextTransform
+	transforms []TextTransform
+	// archives a sample of the analyses, see WithSampler
+	sampler *Sampler
+	// analyses responses cache, see WithCache and WithCachePolicy
+	cache       Cache
+	cachePolicy *CachePolicy
+	// see WithMetrics and WithLogger
+	metrics MetricsSink
+	logger  Logger
+	// headers added to every request, see WithHeaders
+	headers http.Header
+	// shares the account concurrency between tenants, see WithScheduler
+	scheduler *Scheduler
+	tenant    string
+	// see OnRequest and OnResponse
+	requestHooks  []func(*http.Request)
+	responseHooks []func(*HTTPResponse)
+}
+
+// Option configures optional behaviors of a Client
+type Option func(*Client)
 
 // NewClient returns a TextRazor client with default parameters
-func NewClient(apiKey string) *Client {
-	return NewCustomClient(apiKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, DefaultTransport(DefaultUseCompression))
+func NewClient(apiKey string, opts ...Option) *Client {
+	return NewCustomClient(apiKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, DefaultTransport(DefaultUseCompression), opts...)
 }
 
 // NewCustomClient returns a TextRazor client with custom parameters and custom transport
-func NewCustomClient(apiKey string, useCompression, useEncryption bool, endpoint, secureEndpoint string, transport http.RoundTripper) *Client {
-	return &Client{apiKey: apiKey,
+func NewCustomClient(apiKey string, useCompression, useEncryption bool, endpoint, secureEndpoint string, transport http.RoundTripper, opts ...Option) *Client {
+	c := &Client{keys: StaticKey(apiKey),
 		useCompression: useCompression,
 		UseEncryption:  useEncryption,
 		Endpoint:       endpoint,
 		SecureEndpoint: secureEndpoint,
-		httpTransport:  transport}
+		httpTransport:  transport,
+		keepBody:       DefaultKeepResponseBody}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// doRequest execute a http request with the client parameters and transport
-func (c *Client) doRequest(path, method string, headers http.Header, body RequestBody, response Response) (*HTTPResponse, error) {
-	client := &http.Client{Transport: c.httpTransport}
-
-	// set endpointURL
-	endpointURL := c.Endpoint
+// endpointURL returns the endpoint used by the client
+func (c *Client) endpointURL() string {
 	if c.UseEncryption {
-		endpointURL = c.SecureEndpoint
+		return c.SecureEndpoint
+	}
+	return c.Endpoint
+}
+
+// joinURL appends path, which may hold a query, to the path of endpoint,
+// so the API can be served under a sub-path, e.g. https://gateway.corp/textrazor
+func joinURL(endpoint, path string) (*url.URL, error) {
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	p, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + strings.TrimLeft(p.Path, "/")
+	u.RawPath = ""
+	if p.RawQuery != "" {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+		u.RawQuery += p.RawQuery
+	}
+	return u, nil
+}
+
+// doRequest execute a http request with the client parameters and transport,
+// rate limited requests are retried according to the client retry policy
+func (c *Client) doRequest(ctx context.Context, path, method string, headers http.Header, body RequestBody, response Response, opts ...CallOption) (_ *HTTPResponse, err error) {
+	ctx, timer, owner := withCallTimer(ctx)
+	if owner {
+		defer func() { err = timer.wrap(err) }()
+	}
+	o := newCallOptions(opts)
+	ctx, cancel := c.withTimeout(ctx, o)
+	defer cancel()
+	decodeReserve := c.decodeReserve
+	if o.decodeReserve > 0 {
+		decodeReserve = o.decodeReserve
 	}
 
+	headers = c.mergeHeaders(headers, o)
+	endpointURL := c.endpointURL()
+
 	// generate URL
-	u, err := url.ParseRequestURI(endpointURL + path)
+	u, err := joinURL(endpointURL, path)
 	if err != nil {
 		return nil, fmt.Errorf("URI parsing failed '%v': %v", endpointURL+path, err)
 	}
 
-	// generate the request body
-	bodyStr := ""
+	// generate the request body, shared by the attempts
+	var bodyBytes []byte
 	if body != nil {
-		bodyStr, err = body.Encode()
+		bodyBytes, err = encodeRequestBody(body)
 		if err != nil {
 			return nil, fmt.Errorf("body request encoding failed: %v", err)
 		}
 	}
 
+	for attempt := 0; ; attempt++ {
+		if c.rateLimiter != nil {
+			start := time.Now()
+			err := c.rateLimiter.Wait(ctx)
+			timer.track(PhaseRateLimiter, start)
+			if err != nil {
+				return nil, err
+			}
+		}
+		c.stats.requests.Add(1)
+		c.stats.inFlight.Add(1)
+		start := time.Now()
+		httpResponse, err := c.do(ctx, u.String(), method, headers, bodyBytes, response, decodeReserve)
+		c.stats.inFlight.Add(-1)
+		c.observe(ctx, method, path, attempt, start, httpResponse, err)
+		wait, retry := c.retryWait(err, attempt)
+		if !retry {
+			if err != nil {
+				c.stats.failures.Add(1)
+			}
+			return httpResponse, err
+		}
+		c.stats.retries.Add(1)
+		c.logf(ctx, "%s %s rate limited, retry %d in %v", method, path, attempt+1, wait)
+		start = time.Now()
+		err = sleep(ctx, wait)
+		timer.track(PhaseRetryWait, start)
+		if err != nil {
+			c.stats.failures.Add(1)
+			return nil, err
+		}
+	}
+}
+
+// do execute a single http request attempt
+func (c *Client) do(ctx context.Context, urlStr, method string, headers http.Header, body []byte, response Response, decodeReserve float64) (*HTTPResponse, error) {
+	client := &http.Client{Transport: c.httpTransport}
+
+	// the exchange leaves a part of the remaining time to decode the response
+	httpCtx, cancel := reserveDeadline(ctx, decodeReserve)
+	defer cancel()
+	timer, _ := ctx.Value(callTimerKey{}).(*callTimer)
+	start := time.Now()
+
 	// create a Request with the URL and the Body
-	req, err := http.NewRequest(method, u.String(), bytes.NewBufferString(bodyStr))
+	req, err := http.NewRequestWithContext(httpCtx, method, urlStr, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("http request creation failed: %v", err)
 	}
 
-	// set headers
+	// set headers, copied as the request may be retried
 	if headers != nil {
-		req.Header = headers
+		req.Header = headers.Clone()
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", userAgent())
 	}
-	req.Header.Add(apiKeyHeader, c.apiKey)
+	if err := c.setAPIKey(ctx, req.Header); err != nil {
+		return nil, fmt.Errorf("api key retrieval failed: %w", err)
+	}
+	meta := CallMeta{CompressionRequested: c.setAcceptEncoding(req.Header)}
+	c.runRequestHooks(req)
 
 	// execute the request
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request execution failed: %v", err)
+		timer.track(PhaseHTTP, start)
+		return nil, fmt.Errorf("http request execution failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// get the response body
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("http response body read failed: %v", err)
-	}
+	timer.track(PhaseHTTP, start)
 
-	// build the response struct and decode json if request is successful
-	httpResponse := &HTTPResponse{Status: resp.StatusCode, Headers: resp.Header, Body: respBody, Response: response}
+	// decode the response, errors are decoded on a best effort basis
+	httpResponse := &HTTPResponse{Status: resp.StatusCode, Headers: resp.Header, Response: response, Meta: meta}
 	response.setHTTPResponse(httpResponse)
-
+	defer timer.track(PhaseDecode, time.Now())
+	var decodeErr *responseDecodeError
+	if err := c.decodeResponse(resp, httpResponse, resp.StatusCode != http.StatusOK || c.bodyNeeded()); err != nil && !errors.As(err, &decodeErr) {
+		return nil, err
+	}
+	c.runResponseHooks(httpResponse)
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %v", resp.StatusCode)
+		return nil, newAPIError(httpResponse)
 	}
-	err = httpResponse.ParseBody()
-	if err != nil {
-		return nil, fmt.Errorf("http response body parsing failed: %v", err)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("http response body parsing failed: %v", decodeErr.err)
 	}
 
 	if !httpResponse.Ok {
-		return nil, fmt.Errorf("unexpected 'ok' field value: %v", httpResponse.Ok)
+		return nil, newAPIError(httpResponse)
+	}
+	if c.strictDecoding {
+		if err := strictCheck(httpResponse); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := response.(*Analysis); !ok || c.cache == nil && c.sampler == nil {
+		c.discardBody(httpResponse)
 	}
 
 	return httpResponse, nil
@@ -416,14 +651,96 @@ func (c *Client) doRequest(path, method string, headers http.Header, body Reques
 //
 // https://www.textrazor.com/docs/rest#analysis
 func (c *Client) Analyze(params Params) (*Analysis, error) {
-	analysis := &Analysis{}
+	return c.AnalyzeContext(context.Background(), params)
+}
+
+// AnalyzeContext is like Analyze with a context
+func (c *Client) AnalyzeContext(ctx context.Context, params Params, opts ...CallOption) (*Analysis, error) {
 	if (params.Get("text") == "" && params.Get("url") == "") || (params.Get("text") != "" && params.Get("url") != "") {
 		return nil, fmt.Errorf("either 'url' or 'text' should be specified, not both")
 	}
+	if len(c.transforms) > 0 && params.Get("text") != "" {
+		return c.analyzeTransformed(ctx, params, opts...)
+	}
+	return c.analyzeParams(ctx, params, opts...)
+}
+
+// analyzeParams analyzes valid params with the cache, limits and policies of the client
+func (c *Client) analyzeParams(ctx context.Context, params Params, opts ...CallOption) (_ *Analysis, err error) {
+	params = c.withDefaultParams(params)
 	if params.Get("extractors") == "" {
 		return nil, fmt.Errorf("at least one 'extractors' should be specified")
 	}
-	if _, err := c.doRequest("/", http.MethodPost, DefaultHeaders(contentTypeURL), params, analysis); err != nil {
+	o := newCallOptions(opts)
+	params, truncatedFrom := c.truncateText(params)
+	var cacheKey string
+	if c.cache != nil {
+		cacheKey = c.cacheKey(params)
+		if cached := c.cachedAnalysis(ctx, cacheKey, o); cached != nil {
+			cached.Fingerprint = c.fingerprint(params)
+			c.discardBody(cached.HTTPResponse)
+			return cached, nil
+		}
+	}
+	// the timeout includes the time spent waiting for the quota and concurrency limits
+	ctx, timer, owner := withCallTimer(ctx)
+	if owner {
+		defer func() { err = timer.wrap(err) }()
+	}
+	ctx, cancel := c.withTimeout(ctx, o)
+	defer cancel()
+	analysis, err := c.analyzeWithinSize(ctx, timer, params, truncatedFrom, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if analysis, err = c.checkLanguage(ctx, timer, params, analysis, opts...); err != nil {
+		return nil, err
+	}
+	analysis.Fingerprint = c.fingerprint(params)
+	if c.cache != nil {
+		c.cacheAnalysis(ctx, cacheKey, analysis, o)
+	}
+	if c.sampler != nil {
+		c.sampler.archive(ctx, params, analysis)
+	}
+	c.discardBody(analysis.HTTPResponse)
+	return analysis, nil
+}
+
+// analyze sends an analysis request, within the quota and concurrency limits of the client
+func (c *Client) analyze(ctx context.Context, timer *callTimer, params Params, opts ...CallOption) (*Analysis, error) {
+	if c.quota != nil {
+		start := time.Now()
+		err := c.quota.Reserve(ctx)
+		timer.track(PhaseQuota, start)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.concurrency != nil {
+		start := time.Now()
+		release, err := c.concurrency.acquire(ctx, c)
+		timer.track(PhaseConcurrency, start)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	if c.scheduler != nil {
+		tenant := c.tenant
+		if o := newCallOptions(opts); o.tenant != "" {
+			tenant = o.tenant
+		}
+		start := time.Now()
+		release, err := c.scheduler.acquire(ctx, tenant, c)
+		timer.track(PhaseConcurrency, start)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	analysis := c.newAnalysis()
+	if _, err := c.doRequest(ctx, "/", http.MethodPost, DefaultHeaders(contentTypeURL), params, analysis, opts...); err != nil {
 		return nil, err
 	}
 	return analysis, nil
@@ -431,20 +748,45 @@ func (c *Client) Analyze(params Params) (*Analysis, error) {
 
 // AnalyzeText returns a text analysis of the given text
 func (c *Client) AnalyzeText(text string, params Params) (*Analysis, error) {
+	return c.AnalyzeTextContext(context.Background(), text, params)
+}
+
+// AnalyzeTextContext is like AnalyzeText with a context
+func (c *Client) AnalyzeTextContext(ctx context.Context, text string, params Params, opts ...CallOption) (*Analysis, error) {
 	params.Set("text", text)
-	return c.Analyze(params)
+	return c.AnalyzeContext(ctx, params, opts...)
 }
 
 // AnalyzeURL returns a text analysis of the given URL
 func (c *Client) AnalyzeURL(urlStr string, params Params) (*Analysis, error) {
+	return c.AnalyzeURLContext(context.Background(), urlStr, params)
+}
+
+// AnalyzeURLContext is like AnalyzeURL with a context
+func (c *Client) AnalyzeURLContext(ctx context.Context, urlStr string, params Params, opts ...CallOption) (*Analysis, error) {
 	params.Set("url", urlStr)
-	return c.Analyze(params)
+	return c.AnalyzeContext(ctx, params, opts...)
 }
 
 // GetAccount returns an Account struct with plan and usage
 func (c *Client) GetAccount() (*Account, error) {
+	return c.GetAccountContext(context.Background())
+}
+
+// GetAccountContext is like GetAccount with a context
+func (c *Client) GetAccountContext(ctx context.Context, opts ...CallOption) (*Account, error) {
+	if c.accountCache != nil {
+		return c.accountCache.get(ctx, newCallOptions(opts).forceRefresh, func() (*Account, error) {
+			c.stats.cacheMisses.Add(1)
+			return c.getAccount(ctx, opts...)
+		}, func() { c.stats.cacheHits.Add(1) })
+	}
+	return c.getAccount(ctx, opts...)
+}
+
+func (c *Client) getAccount(ctx context.Context, opts ...CallOption) (*Account, error) {
 	account := &Account{}
-	if _, err := c.doRequest("/account/", http.MethodGet, nil, nil, account); err != nil {
+	if _, err := c.doRequest(ctx, "/account/", http.MethodGet, nil, nil, account, opts...); err != nil {
 		return nil, err
 	}
 	return account, nil
@@ -452,18 +794,33 @@ func (c *Client) GetAccount() (*Account, error) {
 
 // CreateDictionary creates a new dictionary using Dictionary struct properties
 func (c *Client) CreateDictionary(d *Dictionary) (*HTTPResponse, error) {
-	return c.doRequest("/entities/"+d.ID, http.MethodPut, DefaultHeaders(contentTypeJSON), d, &EmptyResponse{})
+	return c.CreateDictionaryContext(context.Background(), d)
+}
+
+// CreateDictionaryContext is like CreateDictionary with a context
+func (c *Client) CreateDictionaryContext(ctx context.Context, d *Dictionary, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/entities/"+d.ID, http.MethodPut, DefaultHeaders(contentTypeJSON), d, &EmptyResponse{}, opts...)
 }
 
 // GetDictionaries returns a list of all dictionaries
 func (c *Client) GetDictionaries() (*HTTPResponse, error) { // FIXME: would be better to return a slice of Dictionary, but need to figured out how to keep the HTTPResponse reference
-	return c.doRequest("/entities/", http.MethodGet, nil, nil, &EmptyResponse{})
+	return c.GetDictionariesContext(context.Background())
+}
+
+// GetDictionariesContext is like GetDictionaries with a context
+func (c *Client) GetDictionariesContext(ctx context.Context, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/entities/", http.MethodGet, nil, nil, &EmptyResponse{}, opts...)
 }
 
 // GetDictionary returns a Dictionary by id
 func (c *Client) GetDictionary(ID string) (*Dictionary, error) {
+	return c.GetDictionaryContext(context.Background(), ID)
+}
+
+// GetDictionaryContext is like GetDictionary with a context
+func (c *Client) GetDictionaryContext(ctx context.Context, ID string, opts ...CallOption) (*Dictionary, error) {
 	dict := &Dictionary{}
-	if _, err := c.doRequest("/entities/"+ID, http.MethodGet, nil, nil, dict); err != nil {
+	if _, err := c.doRequest(ctx, "/entities/"+ID, http.MethodGet, nil, nil, dict, opts...); err != nil {
 		return nil, err
 	}
 	return dict, nil
@@ -471,24 +828,62 @@ func (c *Client) GetDictionary(ID string) (*Dictionary, error) {
 
 // DeleteDictionary deletes a dictionary by id
 func (c *Client) DeleteDictionary(ID string) (*HTTPResponse, error) {
-	return c.doRequest("/entities/"+ID, http.MethodDelete, nil, nil, &EmptyResponse{})
+	return c.DeleteDictionaryContext(context.Background(), ID)
+}
+
+// DeleteDictionaryContext is like DeleteDictionary with a context
+func (c *Client) DeleteDictionaryContext(ctx context.Context, ID string, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/entities/"+ID, http.MethodDelete, nil, nil, &EmptyResponse{}, opts...)
 }
 
 // AddDictionaryEntries adds entries to a dictionary
 func (c *Client) AddDictionaryEntries(ID string, e []DictionaryEntry) (*HTTPResponse, error) {
-	return c.doRequest("/entities/"+ID+"/", http.MethodPost, DefaultHeaders(contentTypeJSON), &DictionaryEntryList{Entries: e}, &EmptyResponse{})
+	return c.AddDictionaryEntriesContext(context.Background(), ID, e)
+}
+
+// AddDictionaryEntriesContext is like AddDictionaryEntries with a context
+func (c *Client) AddDictionaryEntriesContext(ctx context.Context, ID string, e []DictionaryEntry, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/entities/"+ID+"/", http.MethodPost, DefaultHeaders(contentTypeJSON), &DictionaryEntryList{Entries: e}, &EmptyResponse{}, opts...)
 }
 
 // AddDictionaryEntry adds an entry to a dictionary
 func (c *Client) AddDictionaryEntry(ID string, e *DictionaryEntry) (*HTTPResponse, error) {
-	return c.AddDictionaryEntries(ID, []DictionaryEntry{*e})
+	return c.AddDictionaryEntryContext(context.Background(), ID, e)
+}
+
+// AddDictionaryEntryContext is like AddDictionaryEntry with a context
+func (c *Client) AddDictionaryEntryContext(ctx context.Context, ID string, e *DictionaryEntry, opts ...CallOption) (*HTTPResponse, error) {
+	return c.AddDictionaryEntriesContext(ctx, ID, []DictionaryEntry{*e}, opts...)
+}
+
+// GetDictionaryEntries returns a page of the entries of a dictionary
+//
+// Deprecated: use ListDictionaryEntries, or AllDictionaryEntries for every entry.
+func (c *Client) GetDictionaryEntries(ID string, limit, offset int) (*DictionaryEntryList, error) {
+	deprecated("GetDictionaryEntries", "ListDictionaryEntries")
+	return c.ListDictionaryEntriesContext(context.Background(), ID, ListOptions{Limit: limit, Offset: offset})
+}
+
+// GetDictionaryEntriesContext is like GetDictionaryEntries with a context
+//
+// Deprecated: use ListDictionaryEntriesContext, or AllDictionaryEntriesContext for every entry.
+func (c *Client) GetDictionaryEntriesContext(ctx context.Context, ID string, limit, offset int, opts ...CallOption) (*DictionaryEntryList, error) {
+	deprecated("GetDictionaryEntriesContext", "ListDictionaryEntriesContext")
+	return c.ListDictionaryEntriesContext(ctx, ID, ListOptions{Limit: limit, Offset: offset}, opts...)
+}
+
+// ListDictionaryEntries returns a page of the entries of a dictionary
+func (c *Client) ListDictionaryEntries(ID string, o ListOptions) (*DictionaryEntryList, error) {
+	return c.ListDictionaryEntriesContext(context.Background(), ID, o)
 }
 
-// GetDictionaryEntries returns a list of all entries for a dictionary
-func (c *Client) GetDictionaryEntries(ID string, limit, offset int) (*DictionaryEntryList, error) { // FIXME: would be better to return a slice of Dictionary, but need to figured out how to keep the HTTPResponse reference
-	params := Params{"limit": {string(limit)}, "offset": {string(offset)}}
+// ListDictionaryEntriesContext is like ListDictionaryEntries with a context
+func (c *Client) ListDictionaryEntriesContext(ctx context.Context, ID string, o ListOptions, opts ...CallOption) (*DictionaryEntryList, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
 	el := &DictionaryEntryList{}
-	if _, err := c.doRequest("/entities/"+ID+"/_all", http.MethodGet, nil, params, el); err != nil {
+	if _, err := c.doRequest(ctx, "/entities/"+ID+"/_all?"+o.Encode(), http.MethodGet, nil, nil, el, opts...); err != nil {
 		return nil, err
 	}
 	return el, nil
@@ -496,8 +891,13 @@ func (c *Client) GetDictionaryEntries(ID string, limit, offset int) (*Dictionary
 
 // GetDictionaryEntry returns a Dictionary Entry by id
 func (c *Client) GetDictionaryEntry(dictID, entryID string) (*DictionaryEntry, error) {
+	return c.GetDictionaryEntryContext(context.Background(), dictID, entryID)
+}
+
+// GetDictionaryEntryContext is like GetDictionaryEntry with a context
+func (c *Client) GetDictionaryEntryContext(ctx context.Context, dictID, entryID string, opts ...CallOption) (*DictionaryEntry, error) {
 	e := &DictionaryEntry{}
-	if _, err := c.doRequest("/entities/"+dictID+"/"+entryID, http.MethodGet, nil, nil, e); err != nil {
+	if _, err := c.doRequest(ctx, "/entities/"+dictID+"/"+entryID, http.MethodGet, nil, nil, e, opts...); err != nil {
 		return nil, err
 	}
 	return e, nil
@@ -505,29 +905,72 @@ func (c *Client) GetDictionaryEntry(dictID, entryID string) (*DictionaryEntry, e
 
 // DeleteDictionaryEntry deletes a Dictionary Entry by id
 func (c *Client) DeleteDictionaryEntry(dictID, entryID string) (*HTTPResponse, error) {
-	return c.doRequest("/entities/"+dictID+"/"+entryID, http.MethodDelete, nil, nil, &EmptyResponse{})
+	return c.DeleteDictionaryEntryContext(context.Background(), dictID, entryID)
+}
+
+// DeleteDictionaryEntryContext is like DeleteDictionaryEntry with a context
+func (c *Client) DeleteDictionaryEntryContext(ctx context.Context, dictID, entryID string, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/entities/"+dictID+"/"+entryID, http.MethodDelete, nil, nil, &EmptyResponse{}, opts...)
 }
 
 // CreateClassifierFromJSON creates a new classifier from a JSON string
 func (c *Client) CreateClassifierFromJSON(ID, jsonStr string) (*HTTPResponse, error) {
-	return c.doRequest("/categories/"+ID, http.MethodPut, DefaultHeaders(contentTypeJSON), &rawRequest{Body: jsonStr}, &EmptyResponse{})
+	return c.CreateClassifierFromJSONContext(context.Background(), ID, jsonStr)
+}
+
+// CreateClassifierFromJSONContext is like CreateClassifierFromJSON with a context
+func (c *Client) CreateClassifierFromJSONContext(ctx context.Context, ID, jsonStr string, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/categories/"+ID, http.MethodPut, DefaultHeaders(contentTypeJSON), &rawRequest{Body: jsonStr}, &EmptyResponse{}, opts...)
 }
 
 // CreateClassifierFromCSV creates a new classifier from a CSV string
 func (c *Client) CreateClassifierFromCSV(ID, csvStr string) (*HTTPResponse, error) {
-	return c.doRequest("/categories/"+ID, http.MethodPut, DefaultHeaders(contentTypeCSV), &rawRequest{Body: csvStr}, &EmptyResponse{})
+	return c.CreateClassifierFromCSVContext(context.Background(), ID, csvStr)
+}
+
+// CreateClassifierFromCSVContext is like CreateClassifierFromCSV with a context
+func (c *Client) CreateClassifierFromCSVContext(ctx context.Context, ID, csvStr string, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/categories/"+ID, http.MethodPut, DefaultHeaders(contentTypeCSV), &rawRequest{Body: csvStr}, &EmptyResponse{}, opts...)
 }
 
 // DeleteClassifier deletes a Classifier by id
 func (c *Client) DeleteClassifier(ID string) (*HTTPResponse, error) {
-	return c.doRequest("/categories/"+ID, http.MethodDelete, nil, nil, &EmptyResponse{})
+	return c.DeleteClassifierContext(context.Background(), ID)
+}
+
+// DeleteClassifierContext is like DeleteClassifier with a context
+func (c *Client) DeleteClassifierContext(ctx context.Context, ID string, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/categories/"+ID, http.MethodDelete, nil, nil, &EmptyResponse{}, opts...)
 }
 
-// GetClassifierCategories returns a list of all categories for a Classifier
+// GetClassifierCategories returns a page of the categories of a Classifier
+//
+// Deprecated: use ListClassifierCategories, or AllClassifierCategories for every category.
 func (c *Client) GetClassifierCategories(ID string, limit, offset int) (*CategoryList, error) {
-	params := Params{"limit": {string(limit)}, "offset": {string(offset)}}
+	deprecated("GetClassifierCategories", "ListClassifierCategories")
+	return c.ListClassifierCategoriesContext(context.Background(), ID, ListOptions{Limit: limit, Offset: offset})
+}
+
+// GetClassifierCategoriesContext is like GetClassifierCategories with a context
+//
+// Deprecated: use ListClassifierCategoriesContext, or AllClassifierCategoriesContext for every category.
+func (c *Client) GetClassifierCategoriesContext(ctx context.Context, ID string, limit, offset int, opts ...CallOption) (*CategoryList, error) {
+	deprecated("GetClassifierCategoriesContext", "ListClassifierCategoriesContext")
+	return c.ListClassifierCategoriesContext(ctx, ID, ListOptions{Limit: limit, Offset: offset}, opts...)
+}
+
+// ListClassifierCategories returns a page of the categories of a Classifier
+func (c *Client) ListClassifierCategories(ID string, o ListOptions) (*CategoryList, error) {
+	return c.ListClassifierCategoriesContext(context.Background(), ID, o)
+}
+
+// ListClassifierCategoriesContext is like ListClassifierCategories with a context
+func (c *Client) ListClassifierCategoriesContext(ctx context.Context, ID string, o ListOptions, opts ...CallOption) (*CategoryList, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
 	cl := &CategoryList{}
-	if _, err := c.doRequest("/categories/"+ID+"/_all", http.MethodGet, nil, params, cl); err != nil {
+	if _, err := c.doRequest(ctx, "/categories/"+ID+"/_all?"+o.Encode(), http.MethodGet, nil, nil, cl, opts...); err != nil {
 		return nil, err
 	}
 	return cl, nil
@@ -535,8 +978,13 @@ func (c *Client) GetClassifierCategories(ID string, limit, offset int) (*Categor
 
 // GetClassifierCategory returns a Classifier Category by id
 func (c *Client) GetClassifierCategory(clID, catID string) (*Category, error) {
+	return c.GetClassifierCategoryContext(context.Background(), clID, catID)
+}
+
+// GetClassifierCategoryContext is like GetClassifierCategory with a context
+func (c *Client) GetClassifierCategoryContext(ctx context.Context, clID, catID string, opts ...CallOption) (*Category, error) {
 	cat := &Category{}
-	if _, err := c.doRequest("/categories/"+clID+"/"+catID, http.MethodGet, nil, nil, cat); err != nil {
+	if _, err := c.doRequest(ctx, "/categories/"+clID+"/"+catID, http.MethodGet, nil, nil, cat, opts...); err != nil {
 		return nil, err
 	}
 	return cat, nil
@@ -544,5 +992,10 @@ func (c *Client) GetClassifierCategory(clID, catID string) (*Category, error) {
 
 // DeleteClassifierCategory deletes a Classifier Category by id
 func (c *Client) DeleteClassifierCategory(clID, catID string) (*HTTPResponse, error) {
-	return c.doRequest("/categories/"+clID+"/"+catID, http.MethodDelete, nil, nil, &EmptyResponse{})
+	return c.DeleteClassifierCategoryContext(context.Background(), clID, catID)
+}
+
+// DeleteClassifierCategoryContext is like DeleteClassifierCategory with a context
+func (c *Client) DeleteClassifierCategoryContext(ctx context.Context, clID, catID string, opts ...CallOption) (*HTTPResponse, error) {
+	return c.doRequest(ctx, "/categories/"+clID+"/"+catID, http.MethodDelete, nil, nil, &EmptyResponse{}, opts...)
 }
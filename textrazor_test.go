@@ -1,11 +1,14 @@
 package textrazor
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
 )
 
 const (
@@ -59,14 +62,7 @@ func (t *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		return nil, fmt.Errorf("expected error")
 	}
 
-	headers := ""
-	if len(req.Header) > 0 {
-		for k, v := range req.Header {
-			headers = "-H '" + k + ": " + v[0] + "'"
-		}
-	}
-	body, _ := ioutil.ReadAll(req.Body)
-	t.t.Log("curl -X", req.Method, "-d '"+string(body)+"'", headers, req.URL)
+	t.t.Log(CurlCommand(req))
 
 	response := &http.Response{
 		Header:     make(http.Header),
@@ -606,7 +602,7 @@ func TestTransportFailure(t *testing.T) {
 
 func TestHTTPRequestFailure(t *testing.T) {
 	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, http.StatusOK, "", false))
-	_, err := client.doRequest("/", "INVALID_METHOD€€€", nil, nil, &Analysis{})
+	_, err := client.doRequest(context.Background(), "/", "INVALID_METHOD€€€", nil, nil, &Analysis{})
 	if err != nil {
 		t.Log(err)
 	}
@@ -617,7 +613,7 @@ func TestHTTPRequestFailure(t *testing.T) {
 
 func TestHTTPResponseFailure(t *testing.T) {
 	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, http.StatusOK, "FAKE_READ_ISSUE", false))
-	_, err := client.doRequest("/", http.MethodPost, nil, nil, &Analysis{})
+	_, err := client.doRequest(context.Background(), "/", http.MethodPost, nil, nil, &Analysis{})
 	if err != nil {
 		t.Log(err)
 	}
@@ -628,7 +624,7 @@ func TestHTTPResponseFailure(t *testing.T) {
 
 func TestEmptyHTTPResponseBody(t *testing.T) {
 	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, http.StatusOK, "", false))
-	_, err := client.doRequest("/", http.MethodPost, nil, nil, &Analysis{})
+	_, err := client.doRequest(context.Background(), "/", http.MethodPost, nil, nil, &Analysis{})
 	if err != nil {
 		t.Log(err)
 	}
@@ -643,7 +639,7 @@ func (f *faultyBody) Encode() (string, error) { return "", fmt.Errorf("bad body"
 
 func TestHTTPRequestBodyFailure(t *testing.T) {
 	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, FakeTransport(t, http.StatusOK, "", false))
-	_, err := client.doRequest("/", http.MethodPost, nil, &faultyBody{}, &Analysis{})
+	_, err := client.doRequest(context.Background(), "/", http.MethodPost, nil, &faultyBody{}, &Analysis{})
 	if err != nil {
 		t.Log(err)
 	}
@@ -681,3 +677,58 @@ func TestParams(t *testing.T) {
 		t.Error("p.Encode should encore in URL format")
 	}
 }
+
+//***************************************************************
+// 			Word tests
+func TestWordTopSense(t *testing.T) {
+	var tests = []struct {
+		senses []Sense
+		expect string
+		ok     bool
+	}{
+		{nil, "", false},
+		{[]Sense{{"bank.n.01", 0.2}}, "bank.n.01", true},
+		{[]Sense{{"bank.n.01", 0.2}, {"depository_financial_institution.n.01", 0.7}, {"bank.n.03", 0.1}}, "depository_financial_institution.n.01", true},
+	}
+	for _, tt := range tests {
+		w := Word{Senses: tt.senses}
+		if s, ok := w.TopSense(); s.Sense != tt.expect || ok != tt.ok {
+			t.Errorf("expect %s %v, got %s %v", tt.expect, tt.ok, s.Sense, ok)
+		}
+	}
+}
+
+//***************************************************************
+// 			Endpoint tests
+func TestJoinURL(t *testing.T) {
+	var tests = []struct {
+		endpoint, path, expect string
+	}{
+		{DefaultSecureEndpoint, "/", "https://api.textrazor.com/"},
+		{DefaultSecureEndpoint, "/account/", "https://api.textrazor.com/account/"},
+		{"https://gateway.corp/textrazor", "/", "https://gateway.corp/textrazor/"},
+		{"https://gateway.corp/textrazor/", "/entities/dict1", "https://gateway.corp/textrazor/entities/dict1"},
+		{"https://gateway.corp/textrazor/", "/entities/dict1/_all?limit=10", "https://gateway.corp/textrazor/entities/dict1/_all?limit=10"},
+		{"https://gateway.corp/textrazor?tenant=a", "/entities/dict1/_all?limit=10", "https://gateway.corp/textrazor/entities/dict1/_all?tenant=a&limit=10"},
+	}
+	for _, tt := range tests {
+		u, err := joinURL(tt.endpoint, tt.path)
+		if err != nil || u.String() != tt.expect {
+			t.Errorf("expect %s, got %v %v", tt.expect, u, err)
+		}
+	}
+	if _, err := joinURL("gateway.corp", "/"); err == nil {
+		t.Error("expect a relative endpoint to fail")
+	}
+}
+
+func TestEndpointPathPrefix(t *testing.T) {
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.Account})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, true, DefaultEndpoint, "https://gateway.corp/textrazor/", transport)
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if u := transport.Requests()[0].URL.String(); u != "https://gateway.corp/textrazor/account/" {
+		t.Error("expect the account path under the endpoint path, got", u)
+	}
+}
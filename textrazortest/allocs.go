@@ -0,0 +1,62 @@
+package textrazortest
+
+import (
+	"runtime"
+	"testing"
+)
+
+// Allocations are the memory allocated by a run of an operation, on average, see MeasureAllocs
+type Allocations struct {
+	Allocs float64
+	Bytes  float64
+}
+
+// AllocBudget is the maximum memory allocated by a run of an operation, see CheckAllocs
+type AllocBudget struct {
+	// Allocs is the maximum number of allocations, 0 means no limit
+	Allocs float64
+	// Bytes is the maximum number of bytes allocated, 0 means no limit
+	Bytes float64
+}
+
+// PerInputByte returns the budget of an operation on an input of size bytes, e.g. decoding a fixture,
+// allowing allocs allocations and bytes bytes per byte of input
+func PerInputByte(size int, allocs, bytes float64) AllocBudget {
+	return AllocBudget{Allocs: allocs * float64(size), Bytes: bytes * float64(size)}
+}
+
+// MeasureAllocs returns the memory allocated by a run of f, on average over runs, after a warm-up run.
+// Like testing.AllocsPerRun, it sets GOMAXPROCS to 1 while measuring.
+func MeasureAllocs(runs int, f func()) Allocations {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	f()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := 0; i < runs; i++ {
+		f()
+	}
+	runtime.ReadMemStats(&after)
+	return Allocations{
+		Allocs: float64(after.Mallocs-before.Mallocs) / float64(runs),
+		Bytes:  float64(after.TotalAlloc-before.TotalAlloc) / float64(runs),
+	}
+}
+
+// CheckAllocs fails the test when a run of f allocates more than the budget, on average over runs,
+// so performance work like pooling isn't undone by a later change. It returns the measured allocations.
+// The test is skipped with the race detector, see RaceEnabled.
+func CheckAllocs(t testing.TB, budget AllocBudget, runs int, f func()) Allocations {
+	t.Helper()
+	if RaceEnabled {
+		t.Skip("allocations aren't counted with the race detector")
+	}
+	a := MeasureAllocs(runs, f)
+	if budget.Allocs > 0 && a.Allocs > budget.Allocs {
+		t.Errorf("allocation budget exceeded: %.0f allocations per run, expect at most %.0f", a.Allocs, budget.Allocs)
+	}
+	if budget.Bytes > 0 && a.Bytes > budget.Bytes {
+		t.Errorf("allocation budget exceeded: %.0f bytes per run, expect at most %.0f", a.Bytes, budget.Bytes)
+	}
+	return a
+}
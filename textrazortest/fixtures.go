@@ -0,0 +1,3026 @@
+package textrazortest
+
+// Text is the document analyzed by every Analysis* fixture.
+const Text = "Barclays misled shareholders and the public about one of the biggest investments in the bank's history, a BBC Panorama investigation has found."
+
+// Analysis fixtures, one per extractor, as returned by POST /
+const (
+	// AnalysisEntities is a response for the 'entities' extractor
+	AnalysisEntities = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "entities": [
+            {
+                "id": 0,
+                "type": [
+                    "Agent",
+                    "Organisation",
+                    "Company",
+                    "Bank"
+                ],
+                "matchingTokens": [
+                    0
+                ],
+                "entityId": "Barclays",
+                "freebaseTypes": [
+                    "/business/employer",
+                    "/business/business_operation",
+                    "/organization/organization"
+                ],
+                "confidenceScore": 4.376,
+                "wikiLink": "http://en.wikipedia.org/wiki/Barclays",
+                "matchedText": "Barclays",
+                "freebaseId": "/m/01yx7f",
+                "relevanceScore": 0.7246,
+                "entityEnglishId": "Barclays",
+                "startingPos": 0,
+                "endingPos": 8,
+                "wikidataId": "Q245343"
+            },
+            {
+                "id": 1,
+                "type": [
+                    "Agent",
+                    "Organisation",
+                    "Company",
+                    "Broadcaster",
+                    "TelevisionStation"
+                ],
+                "matchingTokens": [
+                    19
+                ],
+                "entityId": "BBC",
+                "freebaseTypes": [
+                    "/tv/tv_network",
+                    "/business/employer",
+                    "/broadcast/producer"
+                ],
+                "confidenceScore": 1.726,
+                "wikiLink": "http://en.wikipedia.org/wiki/BBC",
+                "matchedText": "BBC",
+                "freebaseId": "/m/0ncl8zk",
+                "relevanceScore": 0.4451,
+                "entityEnglishId": "BBC",
+                "startingPos": 106,
+                "endingPos": 109,
+                "wikidataId": "Q9531"
+            },
+            {
+                "id": 2,
+                "type": [
+                    "Work",
+                    "TelevisionShow"
+                ],
+                "matchingTokens": [
+                    20
+                ],
+                "entityId": "Panorama (TV programme)",
+                "freebaseTypes": [
+                    "/tv/tv_program"
+                ],
+                "confidenceScore": 2.113,
+                "wikiLink": "http://en.wikipedia.org/wiki/Panorama_(TV_programme)",
+                "matchedText": "Panorama",
+                "freebaseId": "/m/01j9kc",
+                "relevanceScore": 0.3877,
+                "entityEnglishId": "Panorama (TV programme)",
+                "startingPos": 110,
+                "endingPos": 118,
+                "wikidataId": "Q1516034"
+            },
+            {
+                "id": 3,
+                "type": [
+                    "Person"
+                ],
+                "matchingTokens": [
+                    2
+                ],
+                "entityId": "Shareholder",
+                "freebaseTypes": [
+                    "/business/job_title"
+                ],
+                "confidenceScore": 0.9562,
+                "wikiLink": "http://en.wikipedia.org/wiki/Shareholder",
+                "matchedText": "shareholders",
+                "freebaseId": "/m/0l7_8",
+                "relevanceScore": 0.5127,
+                "entityEnglishId": "Shareholder",
+                "startingPos": 16,
+                "endingPos": 28,
+                "wikidataId": "Q1766910"
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisCustomEntities is a response for the 'entities' extractor with a custom dictionary, whose entity data holds lists of values
+	AnalysisCustomEntities = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "entities": [
+            {
+                "id": 0,
+                "matchingTokens": [
+                    0
+                ],
+                "entityId": "Barclays",
+                "customEntityId": "BARC",
+                "confidenceScore": 0.5,
+                "matchedText": "Barclays",
+                "relevanceScore": 0,
+                "startingPos": 0,
+                "endingPos": 8,
+                "data": {
+                    "ticker": [
+                        "BARC"
+                    ],
+                    "exchange": [
+                        "LSE",
+                        "NYSE"
+                    ]
+                }
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisTopics is a response for the 'topics' extractor, including the coarse topics
+	AnalysisTopics = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "topics": [
+            {
+                "id": 0,
+                "label": "Banking",
+                "wikiLink": "http://en.wikipedia.org/Category:Banking",
+                "score": 0.9487,
+                "wikidataId": "Q22687"
+            },
+            {
+                "id": 1,
+                "label": "Barclays",
+                "wikiLink": "http://en.wikipedia.org/Category:Barclays",
+                "score": 0.8723,
+                "wikidataId": "Q245343"
+            },
+            {
+                "id": 2,
+                "label": "BBC",
+                "wikiLink": "http://en.wikipedia.org/Category:BBC",
+                "score": 0.6251,
+                "wikidataId": "Q9531"
+            },
+            {
+                "id": 3,
+                "label": "Investigative journalism",
+                "wikiLink": "http://en.wikipedia.org/Category:Investigative_journalism",
+                "score": 0.5032,
+                "wikidataId": "Q1139793"
+            }
+        ],
+        "coarseTopics": [
+            {
+                "id": 0,
+                "label": "Business",
+                "wikiLink": "http://en.wikipedia.org/Category:Business",
+                "score": 0.9487,
+                "wikidataId": "Q4830453"
+            },
+            {
+                "id": 1,
+                "label": "Finance",
+                "wikiLink": "http://en.wikipedia.org/Category:Finance",
+                "score": 0.9102,
+                "wikidataId": "Q43015"
+            },
+            {
+                "id": 2,
+                "label": "Mass media",
+                "wikiLink": "http://en.wikipedia.org/Category:Mass_media",
+                "score": 0.5032,
+                "wikidataId": "Q11033"
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisCategories is a response for the 'textrazor_newscodes' classifier
+	AnalysisCategories = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "categories": [
+            {
+                "id": 0,
+                "classifierId": "textrazor_newscodes",
+                "categoryId": "04006000",
+                "label": "economy, business and finance>financial and business service",
+                "score": 0.5713
+            },
+            {
+                "id": 1,
+                "classifierId": "textrazor_newscodes",
+                "categoryId": "04006002",
+                "label": "economy, business and finance>financial and business service>banking",
+                "score": 0.5371
+            },
+            {
+                "id": 2,
+                "classifierId": "textrazor_newscodes",
+                "categoryId": "13010000",
+                "label": "science and technology>media",
+                "score": 0.2436
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisEntailments is a response for the 'entailments' extractor
+	AnalysisEntailments = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "entailments": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    2
+                ],
+                "entailedTree": {
+                    "word": "stockholder",
+                    "wordId": 0,
+                    "parentId": -1
+                },
+                "contextScore": 0.1373,
+                "priorScore": 0.9961,
+                "score": 0.5862
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    21
+                ],
+                "entailedTree": {
+                    "word": "probe",
+                    "wordId": 0,
+                    "parentId": -1
+                },
+                "contextScore": 0.0917,
+                "priorScore": 0.4501,
+                "score": 0.2536
+            }
+        ],
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD"
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS"
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS"
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ","
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN"
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": "."
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisRelations is a response for the 'relations' extractor, which also returns properties
+	AnalysisRelations = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "relations": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    1
+                ],
+                "params": [
+                    {
+                        "relation": "SUBJECT",
+                        "wordPositions": [
+                            0
+                        ]
+                    },
+                    {
+                        "relation": "OBJECT",
+                        "wordPositions": [
+                            2,
+                            3,
+                            4,
+                            5
+                        ]
+                    }
+                ]
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    22,
+                    23
+                ],
+                "params": [
+                    {
+                        "relation": "SUBJECT",
+                        "wordPositions": [
+                            18,
+                            19,
+                            20,
+                            21
+                        ]
+                    }
+                ]
+            }
+        ],
+        "properties": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    21
+                ],
+                "propertyPositions": [
+                    19,
+                    20
+                ]
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    11
+                ],
+                "propertyPositions": [
+                    10
+                ]
+            }
+        ],
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 1,
+                        "relationToParent": "nsubj"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD",
+                        "parentPosition": 23,
+                        "relationToParent": "ccomp"
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS",
+                        "parentPosition": 1,
+                        "relationToParent": "dobj"
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC",
+                        "parentPosition": 2,
+                        "relationToParent": "cc"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 5,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 2,
+                        "relationToParent": "conj"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 1,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD",
+                        "parentPosition": 6,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 7,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 11,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS",
+                        "parentPosition": 11,
+                        "relationToParent": "amod"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS",
+                        "parentPosition": 8,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 11,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 14,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 16,
+                        "relationToParent": "poss"
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS",
+                        "parentPosition": 14,
+                        "relationToParent": "possessive"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 12,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ",",
+                        "parentPosition": 23,
+                        "relationToParent": "punct"
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 21,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 21,
+                        "relationToParent": "nn"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 21,
+                        "relationToParent": "nn"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 23,
+                        "relationToParent": "nsubj"
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ",
+                        "parentPosition": 23,
+                        "relationToParent": "aux"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN"
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": ".",
+                        "parentPosition": 23,
+                        "relationToParent": "punct"
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisNounPhrases is a response for the 'phrases' extractor
+	AnalysisNounPhrases = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "nounPhrases": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    2,
+                    3,
+                    4,
+                    5
+                ]
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    9,
+                    10,
+                    11
+                ]
+            },
+            {
+                "id": 2,
+                "wordPositions": [
+                    13,
+                    14,
+                    15,
+                    16
+                ]
+            },
+            {
+                "id": 3,
+                "wordPositions": [
+                    18,
+                    19,
+                    20,
+                    21
+                ]
+            }
+        ],
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD"
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS"
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS"
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ","
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN"
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": "."
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisWords is a response for the 'words' extractor
+	AnalysisWords = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD"
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS"
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS"
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ","
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN"
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": "."
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisDependencyTrees is a response for the 'dependency-trees' extractor
+	AnalysisDependencyTrees = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 1,
+                        "relationToParent": "nsubj"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD",
+                        "parentPosition": 23,
+                        "relationToParent": "ccomp"
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS",
+                        "parentPosition": 1,
+                        "relationToParent": "dobj"
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC",
+                        "parentPosition": 2,
+                        "relationToParent": "cc"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 5,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 2,
+                        "relationToParent": "conj"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 1,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD",
+                        "parentPosition": 6,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 7,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 11,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS",
+                        "parentPosition": 11,
+                        "relationToParent": "amod"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS",
+                        "parentPosition": 8,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 11,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 14,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 16,
+                        "relationToParent": "poss"
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS",
+                        "parentPosition": 14,
+                        "relationToParent": "possessive"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 12,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ",",
+                        "parentPosition": 23,
+                        "relationToParent": "punct"
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 21,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 21,
+                        "relationToParent": "nn"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 21,
+                        "relationToParent": "nn"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 23,
+                        "relationToParent": "nsubj"
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ",
+                        "parentPosition": 23,
+                        "relationToParent": "aux"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN"
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": ".",
+                        "parentPosition": 23,
+                        "relationToParent": "punct"
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisSenses is a response for the 'senses' extractor
+	AnalysisSenses = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD",
+                        "senses": [
+                            {
+                                "sense": "mislead.v.01",
+                                "score": 0.8842
+                            }
+                        ]
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS",
+                        "senses": [
+                            {
+                                "sense": "stockholder.n.01",
+                                "score": 0.9531
+                            }
+                        ]
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS",
+                        "senses": [
+                            {
+                                "sense": "investment.n.01",
+                                "score": 0.7013
+                            },
+                            {
+                                "sense": "investing.n.01",
+                                "score": 0.2102
+                            }
+                        ]
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN",
+                        "senses": [
+                            {
+                                "sense": "bank.n.01",
+                                "score": 0.6627
+                            },
+                            {
+                                "sense": "depository_financial_institution.n.01",
+                                "score": 0.3121
+                            }
+                        ]
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ","
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN",
+                        "senses": [
+                            {
+                                "sense": "probe.n.01",
+                                "score": 0.8315
+                            }
+                        ]
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN",
+                        "senses": [
+                            {
+                                "sense": "find.v.03",
+                                "score": 0.5902
+                            }
+                        ]
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": "."
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisSpelling is a response for the 'spelling' extractor
+	AnalysisSpelling = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD"
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS",
+                        "spellingSuggestions": [
+                            {
+                                "suggestion": "shareholders",
+                                "score": 0.9912
+                            }
+                        ]
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS"
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ","
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN"
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN"
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": "."
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisCustomAnnotations is a response for custom prolog rules, with their annotation output
+	AnalysisCustomAnnotations = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "customAnnotationOutput": "barclays_news(Barclays, BBC).\n",
+        "matchingRules": [
+            "barclays_news"
+        ]
+    },
+    "time": 0.0123,
+    "ok": true
+}`
+
+	// AnalysisFull is a response for every extractor at once, with the raw and cleaned text returned
+	AnalysisFull = `{
+    "response": {
+        "language": "eng",
+        "languageIsReliable": true,
+        "rawText": "Barclays misled shareholders and the public about one of the biggest investments in the bank's history, a BBC Panorama investigation has found.",
+        "cleanedText": "Barclays misled shareholders and the public about one of the biggest investments in the bank's history, a BBC Panorama investigation has found.",
+        "customAnnotationOutput": "barclays_news(Barclays, BBC).\n",
+        "matchingRules": [
+            "barclays_news"
+        ],
+        "entities": [
+            {
+                "id": 0,
+                "type": [
+                    "Agent",
+                    "Organisation",
+                    "Company",
+                    "Bank"
+                ],
+                "matchingTokens": [
+                    0
+                ],
+                "entityId": "Barclays",
+                "freebaseTypes": [
+                    "/business/employer",
+                    "/business/business_operation",
+                    "/organization/organization"
+                ],
+                "confidenceScore": 4.376,
+                "wikiLink": "http://en.wikipedia.org/wiki/Barclays",
+                "matchedText": "Barclays",
+                "freebaseId": "/m/01yx7f",
+                "relevanceScore": 0.7246,
+                "entityEnglishId": "Barclays",
+                "startingPos": 0,
+                "endingPos": 8,
+                "wikidataId": "Q245343"
+            },
+            {
+                "id": 1,
+                "type": [
+                    "Agent",
+                    "Organisation",
+                    "Company",
+                    "Broadcaster",
+                    "TelevisionStation"
+                ],
+                "matchingTokens": [
+                    19
+                ],
+                "entityId": "BBC",
+                "freebaseTypes": [
+                    "/tv/tv_network",
+                    "/business/employer",
+                    "/broadcast/producer"
+                ],
+                "confidenceScore": 1.726,
+                "wikiLink": "http://en.wikipedia.org/wiki/BBC",
+                "matchedText": "BBC",
+                "freebaseId": "/m/0ncl8zk",
+                "relevanceScore": 0.4451,
+                "entityEnglishId": "BBC",
+                "startingPos": 106,
+                "endingPos": 109,
+                "wikidataId": "Q9531"
+            },
+            {
+                "id": 2,
+                "type": [
+                    "Work",
+                    "TelevisionShow"
+                ],
+                "matchingTokens": [
+                    20
+                ],
+                "entityId": "Panorama (TV programme)",
+                "freebaseTypes": [
+                    "/tv/tv_program"
+                ],
+                "confidenceScore": 2.113,
+                "wikiLink": "http://en.wikipedia.org/wiki/Panorama_(TV_programme)",
+                "matchedText": "Panorama",
+                "freebaseId": "/m/01j9kc",
+                "relevanceScore": 0.3877,
+                "entityEnglishId": "Panorama (TV programme)",
+                "startingPos": 110,
+                "endingPos": 118,
+                "wikidataId": "Q1516034"
+            },
+            {
+                "id": 3,
+                "type": [
+                    "Person"
+                ],
+                "matchingTokens": [
+                    2
+                ],
+                "entityId": "Shareholder",
+                "freebaseTypes": [
+                    "/business/job_title"
+                ],
+                "confidenceScore": 0.9562,
+                "wikiLink": "http://en.wikipedia.org/wiki/Shareholder",
+                "matchedText": "shareholders",
+                "freebaseId": "/m/0l7_8",
+                "relevanceScore": 0.5127,
+                "entityEnglishId": "Shareholder",
+                "startingPos": 16,
+                "endingPos": 28,
+                "wikidataId": "Q1766910"
+            }
+        ],
+        "topics": [
+            {
+                "id": 0,
+                "label": "Banking",
+                "wikiLink": "http://en.wikipedia.org/Category:Banking",
+                "score": 0.9487,
+                "wikidataId": "Q22687"
+            },
+            {
+                "id": 1,
+                "label": "Barclays",
+                "wikiLink": "http://en.wikipedia.org/Category:Barclays",
+                "score": 0.8723,
+                "wikidataId": "Q245343"
+            },
+            {
+                "id": 2,
+                "label": "BBC",
+                "wikiLink": "http://en.wikipedia.org/Category:BBC",
+                "score": 0.6251,
+                "wikidataId": "Q9531"
+            },
+            {
+                "id": 3,
+                "label": "Investigative journalism",
+                "wikiLink": "http://en.wikipedia.org/Category:Investigative_journalism",
+                "score": 0.5032,
+                "wikidataId": "Q1139793"
+            }
+        ],
+        "coarseTopics": [
+            {
+                "id": 0,
+                "label": "Business",
+                "wikiLink": "http://en.wikipedia.org/Category:Business",
+                "score": 0.9487,
+                "wikidataId": "Q4830453"
+            },
+            {
+                "id": 1,
+                "label": "Finance",
+                "wikiLink": "http://en.wikipedia.org/Category:Finance",
+                "score": 0.9102,
+                "wikidataId": "Q43015"
+            },
+            {
+                "id": 2,
+                "label": "Mass media",
+                "wikiLink": "http://en.wikipedia.org/Category:Mass_media",
+                "score": 0.5032,
+                "wikidataId": "Q11033"
+            }
+        ],
+        "categories": [
+            {
+                "id": 0,
+                "classifierId": "textrazor_newscodes",
+                "categoryId": "04006000",
+                "label": "economy, business and finance>financial and business service",
+                "score": 0.5713
+            },
+            {
+                "id": 1,
+                "classifierId": "textrazor_newscodes",
+                "categoryId": "04006002",
+                "label": "economy, business and finance>financial and business service>banking",
+                "score": 0.5371
+            },
+            {
+                "id": 2,
+                "classifierId": "textrazor_newscodes",
+                "categoryId": "13010000",
+                "label": "science and technology>media",
+                "score": 0.2436
+            }
+        ],
+        "entailments": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    2
+                ],
+                "entailedTree": {
+                    "word": "stockholder",
+                    "wordId": 0,
+                    "parentId": -1
+                },
+                "contextScore": 0.1373,
+                "priorScore": 0.9961,
+                "score": 0.5862
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    21
+                ],
+                "entailedTree": {
+                    "word": "probe",
+                    "wordId": 0,
+                    "parentId": -1
+                },
+                "contextScore": 0.0917,
+                "priorScore": 0.4501,
+                "score": 0.2536
+            }
+        ],
+        "relations": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    1
+                ],
+                "params": [
+                    {
+                        "relation": "SUBJECT",
+                        "wordPositions": [
+                            0
+                        ]
+                    },
+                    {
+                        "relation": "OBJECT",
+                        "wordPositions": [
+                            2,
+                            3,
+                            4,
+                            5
+                        ]
+                    }
+                ]
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    22,
+                    23
+                ],
+                "params": [
+                    {
+                        "relation": "SUBJECT",
+                        "wordPositions": [
+                            18,
+                            19,
+                            20,
+                            21
+                        ]
+                    }
+                ]
+            }
+        ],
+        "properties": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    21
+                ],
+                "propertyPositions": [
+                    19,
+                    20
+                ]
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    11
+                ],
+                "propertyPositions": [
+                    10
+                ]
+            }
+        ],
+        "nounPhrases": [
+            {
+                "id": 0,
+                "wordPositions": [
+                    2,
+                    3,
+                    4,
+                    5
+                ]
+            },
+            {
+                "id": 1,
+                "wordPositions": [
+                    9,
+                    10,
+                    11
+                ]
+            },
+            {
+                "id": 2,
+                "wordPositions": [
+                    13,
+                    14,
+                    15,
+                    16
+                ]
+            },
+            {
+                "id": 3,
+                "wordPositions": [
+                    18,
+                    19,
+                    20,
+                    21
+                ]
+            }
+        ],
+        "sentences": [
+            {
+                "position": 0,
+                "words": [
+                    {
+                        "position": 0,
+                        "startingPos": 0,
+                        "endingPos": 8,
+                        "stem": "barclai",
+                        "lemma": "barclays",
+                        "token": "Barclays",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 1,
+                        "relationToParent": "nsubj"
+                    },
+                    {
+                        "position": 1,
+                        "startingPos": 9,
+                        "endingPos": 15,
+                        "stem": "misl",
+                        "lemma": "mislead",
+                        "token": "misled",
+                        "partOfSpeech": "VBD",
+                        "parentPosition": 23,
+                        "relationToParent": "ccomp",
+                        "senses": [
+                            {
+                                "sense": "mislead.v.01",
+                                "score": 0.8842
+                            }
+                        ]
+                    },
+                    {
+                        "position": 2,
+                        "startingPos": 16,
+                        "endingPos": 28,
+                        "stem": "sharehold",
+                        "lemma": "shareholder",
+                        "token": "shareholders",
+                        "partOfSpeech": "NNS",
+                        "parentPosition": 1,
+                        "relationToParent": "dobj",
+                        "senses": [
+                            {
+                                "sense": "stockholder.n.01",
+                                "score": 0.9531
+                            }
+                        ],
+                        "spellingSuggestions": [
+                            {
+                                "suggestion": "shareholders",
+                                "score": 0.9912
+                            }
+                        ]
+                    },
+                    {
+                        "position": 3,
+                        "startingPos": 29,
+                        "endingPos": 32,
+                        "stem": "and",
+                        "lemma": "and",
+                        "token": "and",
+                        "partOfSpeech": "CC",
+                        "parentPosition": 2,
+                        "relationToParent": "cc"
+                    },
+                    {
+                        "position": 4,
+                        "startingPos": 33,
+                        "endingPos": 36,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 5,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 5,
+                        "startingPos": 37,
+                        "endingPos": 43,
+                        "stem": "public",
+                        "lemma": "public",
+                        "token": "public",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 2,
+                        "relationToParent": "conj"
+                    },
+                    {
+                        "position": 6,
+                        "startingPos": 44,
+                        "endingPos": 49,
+                        "stem": "about",
+                        "lemma": "about",
+                        "token": "about",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 1,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 7,
+                        "startingPos": 50,
+                        "endingPos": 53,
+                        "stem": "on",
+                        "lemma": "one",
+                        "token": "one",
+                        "partOfSpeech": "CD",
+                        "parentPosition": 6,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 8,
+                        "startingPos": 54,
+                        "endingPos": 56,
+                        "stem": "of",
+                        "lemma": "of",
+                        "token": "of",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 7,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 9,
+                        "startingPos": 57,
+                        "endingPos": 60,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 11,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 10,
+                        "startingPos": 61,
+                        "endingPos": 68,
+                        "stem": "biggest",
+                        "lemma": "big",
+                        "token": "biggest",
+                        "partOfSpeech": "JJS",
+                        "parentPosition": 11,
+                        "relationToParent": "amod"
+                    },
+                    {
+                        "position": 11,
+                        "startingPos": 69,
+                        "endingPos": 80,
+                        "stem": "invest",
+                        "lemma": "investment",
+                        "token": "investments",
+                        "partOfSpeech": "NNS",
+                        "parentPosition": 8,
+                        "relationToParent": "pobj",
+                        "senses": [
+                            {
+                                "sense": "investment.n.01",
+                                "score": 0.7013
+                            },
+                            {
+                                "sense": "investing.n.01",
+                                "score": 0.2102
+                            }
+                        ]
+                    },
+                    {
+                        "position": 12,
+                        "startingPos": 81,
+                        "endingPos": 83,
+                        "stem": "in",
+                        "lemma": "in",
+                        "token": "in",
+                        "partOfSpeech": "IN",
+                        "parentPosition": 11,
+                        "relationToParent": "prep"
+                    },
+                    {
+                        "position": 13,
+                        "startingPos": 84,
+                        "endingPos": 87,
+                        "stem": "the",
+                        "lemma": "the",
+                        "token": "the",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 14,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 14,
+                        "startingPos": 88,
+                        "endingPos": 92,
+                        "stem": "bank",
+                        "lemma": "bank",
+                        "token": "bank",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 16,
+                        "relationToParent": "poss",
+                        "senses": [
+                            {
+                                "sense": "bank.n.01",
+                                "score": 0.6627
+                            },
+                            {
+                                "sense": "depository_financial_institution.n.01",
+                                "score": 0.3121
+                            }
+                        ]
+                    },
+                    {
+                        "position": 15,
+                        "startingPos": 92,
+                        "endingPos": 94,
+                        "stem": "'s",
+                        "lemma": "'s",
+                        "token": "'s",
+                        "partOfSpeech": "POS",
+                        "parentPosition": 14,
+                        "relationToParent": "possessive"
+                    },
+                    {
+                        "position": 16,
+                        "startingPos": 95,
+                        "endingPos": 102,
+                        "stem": "histori",
+                        "lemma": "history",
+                        "token": "history",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 12,
+                        "relationToParent": "pobj"
+                    },
+                    {
+                        "position": 17,
+                        "startingPos": 102,
+                        "endingPos": 103,
+                        "stem": ",",
+                        "lemma": ",",
+                        "token": ",",
+                        "partOfSpeech": ",",
+                        "parentPosition": 23,
+                        "relationToParent": "punct"
+                    },
+                    {
+                        "position": 18,
+                        "startingPos": 104,
+                        "endingPos": 105,
+                        "stem": "a",
+                        "lemma": "a",
+                        "token": "a",
+                        "partOfSpeech": "DT",
+                        "parentPosition": 21,
+                        "relationToParent": "det"
+                    },
+                    {
+                        "position": 19,
+                        "startingPos": 106,
+                        "endingPos": 109,
+                        "stem": "bbc",
+                        "lemma": "bbc",
+                        "token": "BBC",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 21,
+                        "relationToParent": "nn"
+                    },
+                    {
+                        "position": 20,
+                        "startingPos": 110,
+                        "endingPos": 118,
+                        "stem": "panorama",
+                        "lemma": "panorama",
+                        "token": "Panorama",
+                        "partOfSpeech": "NNP",
+                        "parentPosition": 21,
+                        "relationToParent": "nn"
+                    },
+                    {
+                        "position": 21,
+                        "startingPos": 119,
+                        "endingPos": 132,
+                        "stem": "investig",
+                        "lemma": "investigation",
+                        "token": "investigation",
+                        "partOfSpeech": "NN",
+                        "parentPosition": 23,
+                        "relationToParent": "nsubj",
+                        "senses": [
+                            {
+                                "sense": "probe.n.01",
+                                "score": 0.8315
+                            }
+                        ]
+                    },
+                    {
+                        "position": 22,
+                        "startingPos": 133,
+                        "endingPos": 136,
+                        "stem": "ha",
+                        "lemma": "have",
+                        "token": "has",
+                        "partOfSpeech": "VBZ",
+                        "parentPosition": 23,
+                        "relationToParent": "aux"
+                    },
+                    {
+                        "position": 23,
+                        "startingPos": 137,
+                        "endingPos": 142,
+                        "stem": "found",
+                        "lemma": "find",
+                        "token": "found",
+                        "partOfSpeech": "VBN",
+                        "senses": [
+                            {
+                                "sense": "find.v.03",
+                                "score": 0.5902
+                            }
+                        ]
+                    },
+                    {
+                        "position": 24,
+                        "startingPos": 142,
+                        "endingPos": 143,
+                        "stem": ".",
+                        "lemma": ".",
+                        "token": ".",
+                        "partOfSpeech": ".",
+                        "parentPosition": 23,
+                        "relationToParent": "punct"
+                    }
+                ]
+            }
+        ]
+    },
+    "time": 0.1537,
+    "ok": true
+}`
+)
+
+// Management endpoints fixtures
+const (
+	// Account is a response for GET /account/
+	Account = `{
+    "ok": true,
+    "time": 0.0011,
+    "response": {
+        "requestsUsedToday": 17,
+        "concurrentRequestsUsed": 0,
+        "concurrentRequestLimit": 2,
+        "plan": "FREE",
+        "planDailyRequestsIncluded": 500
+    }
+}`
+
+	// Dictionaries is a response for GET /entities/
+	Dictionaries = `{
+    "dictionaries": [
+        {
+            "id": "test_ents",
+            "matchType": "TOKEN",
+            "caseInsensitive": true,
+            "language": "eng"
+        },
+        {
+            "id": "tickers",
+            "matchType": "STEM",
+            "caseInsensitive": false,
+            "language": "eng"
+        }
+    ],
+    "time": 0.002655,
+    "ok": true
+}`
+
+	// Dictionary is a response for GET /entities/{id}
+	Dictionary = `{
+    "response": {
+        "id": "test_ents",
+        "matchType": "TOKEN",
+        "caseInsensitive": true,
+        "language": "eng"
+    },
+    "time": 0.002503,
+    "ok": true
+}`
+
+	// DictionaryEntries is a response for GET /entities/{id}/_all
+	DictionaryEntries = `{
+    "response": {
+        "offset": 0,
+        "limit": 20,
+        "total": 2,
+        "entries": [
+            {
+                "id": "DEV1",
+                "text": "Ken Thompson",
+                "data": {
+                    "born": [
+                        "1943"
+                    ]
+                }
+            },
+            {
+                "id": "DEV2",
+                "text": "Bjarne Stroustrup",
+                "data": {}
+            }
+        ]
+    },
+    "time": 0.005158,
+    "ok": true
+}`
+
+	// DictionaryEntry is a response for GET /entities/{id}/{entryId}
+	DictionaryEntry = `{
+    "response": {
+        "id": "DEV1",
+        "text": "Ken Thompson",
+        "data": {
+            "born": [
+                "1943"
+            ]
+        }
+    },
+    "time": 0.001278,
+    "ok": true
+}`
+
+	// Categories is a response for GET /categories/{id}/_all
+	Categories = `{
+    "response": {
+        "id": "sport",
+        "offset": 0,
+        "limit": 20,
+        "total": 3,
+        "lastUpdated": 1489517818,
+        "categories": [
+            {
+                "categoryId": "100",
+                "label": "Golf",
+                "query": "concept('sport>golf')"
+            },
+            {
+                "categoryId": "101",
+                "label": "Squash",
+                "query": "concept('sport>squash')"
+            },
+            {
+                "categoryId": "102",
+                "label": "Cricket",
+                "query": "concept('sport>cricket')"
+            }
+        ]
+    },
+    "time": 0.00612,
+    "ok": true
+}`
+
+	// Category is a response for GET /categories/{id}/{categoryId}
+	Category = `{
+    "response": {
+        "categoryId": "100",
+        "label": "Golf",
+        "query": "concept('sport>golf')"
+    },
+    "time": 0.00153,
+    "ok": true
+}`
+
+	// OK is a response for management calls that return no payload (PUT, POST, DELETE)
+	OK = `{"time": 0.004913, "ok": true}`
+
+	// Error is a response for a request rejected by the API, e.g. with an invalid key
+	Error = `{"time": 0.0001, "ok": false, "error": "Please provide a valid TextRazor API key."}`
+)
@@ -0,0 +1,185 @@
+package textrazortest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// GenerateOptions defines the size of an analysis generated by GenerateAnalysis
+type GenerateOptions struct {
+	// Seed makes the generation reproducible, the same options generate the same analysis
+	Seed int64
+	// Sentences is the number of sentences of the document
+	Sentences int
+	// WordsPerSentence is the number of words of each sentence, including its final period, 20 if 0
+	WordsPerSentence int
+	// Entities is the number of entity mentions, at most the number of words
+	Entities int
+	// Topics is the number of topics
+	Topics int
+}
+
+// generatedWord is a word of the vocabulary of GenerateAnalysis
+type generatedWord struct {
+	token, lemma, stem, pos string
+}
+
+var generatedVocabulary = []generatedWord{
+	{"the", "the", "the", "DT"}, {"a", "a", "a", "DT"}, {"of", "of", "of", "IN"}, {"in", "in", "in", "IN"},
+	{"and", "and", "and", "CC"}, {"shareholders", "shareholder", "sharehold", "NNS"}, {"public", "public", "public", "NN"},
+	{"investments", "investment", "invest", "NNS"}, {"bank", "bank", "bank", "NN"}, {"history", "history", "histori", "NN"},
+	{"investigation", "investigation", "investig", "NN"}, {"has", "have", "ha", "VBZ"}, {"found", "find", "found", "VBN"},
+	{"misled", "mislead", "misl", "VBD"}, {"biggest", "big", "biggest", "JJS"}, {"reported", "report", "report", "VBD"},
+	{"market", "market", "market", "NN"}, {"regulators", "regulator", "regul", "NNS"}, {"quarterly", "quarterly", "quarterli", "JJ"},
+	{"profits", "profit", "profit", "NNS"}, {"fell", "fall", "fell", "VBD"}, {"sharply", "sharply", "sharpli", "RB"},
+	{"after", "after", "after", "IN"}, {"board", "board", "board", "NN"}, {"announced", "announce", "announc", "VBD"},
+}
+
+// generatedEntity is an entity of GenerateAnalysis, mentioned by a single word
+type generatedEntity struct {
+	id, wikidataID string
+	types          []string
+}
+
+var generatedEntities = []generatedEntity{
+	{"Barclays", "Q245343", []string{"Agent", "Organisation", "Company", "Bank"}},
+	{"BBC", "Q9531", []string{"Agent", "Organisation", "Company", "Broadcaster"}},
+	{"Panorama", "Q1331926", []string{"Work", "TelevisionShow"}},
+	{"London", "Q84", []string{"Place", "PopulatedPlace", "Settlement", "City"}},
+	{"Reuters", "Q130879", []string{"Agent", "Organisation", "Company"}},
+	{"Qatar", "Q846", []string{"Place", "PopulatedPlace", "Country"}},
+}
+
+var generatedTopics = []string{"Banking", "Finance", "Business", "Economy", "Investment", "Journalism", "Television", "Politics"}
+
+type generatedAnalysisWord struct {
+	Position         int    `json:"position"`
+	StartingPos      int    `json:"startingPos"`
+	EndingPos        int    `json:"endingPos"`
+	Stem             string `json:"stem"`
+	Lemma            string `json:"lemma"`
+	Token            string `json:"token"`
+	PartOfSpeech     string `json:"partOfSpeech"`
+	ParentPosition   *int   `json:"parentPosition,omitempty"`
+	RelationToParent string `json:"relationToParent,omitempty"`
+}
+
+type generatedSentence struct {
+	Position int                     `json:"position"`
+	Words    []generatedAnalysisWord `json:"words"`
+}
+
+type generatedAnalysisEntity struct {
+	ID              int      `json:"id"`
+	Type            []string `json:"type"`
+	MatchingTokens  []int    `json:"matchingTokens"`
+	EntityID        string   `json:"entityId"`
+	EntityEnglishID string   `json:"entityEnglishId"`
+	ConfidenceScore float64  `json:"confidenceScore"`
+	RelevanceScore  float64  `json:"relevanceScore"`
+	WikiLink        string   `json:"wikiLink"`
+	WikidataID      string   `json:"wikidataId"`
+	MatchedText     string   `json:"matchedText"`
+	StartingPos     int      `json:"startingPos"`
+	EndingPos       int      `json:"endingPos"`
+}
+
+type generatedTopic struct {
+	ID         int     `json:"id"`
+	Label      string  `json:"label"`
+	Score      float64 `json:"score"`
+	WikiLink   string  `json:"wikiLink"`
+	WikidataID string  `json:"wikidataId"`
+}
+
+// GenerateAnalysis returns a document and the compact JSON response of its analysis, with consistent word positions,
+// character offsets and entity tokens, so benchmarks and memory tests run against large analyses without storing
+// them. The analysis is generated from a small vocabulary, it only looks realistic to the client.
+func GenerateAnalysis(o GenerateOptions) (text, body string) {
+	if o.WordsPerSentence <= 0 {
+		o.WordsPerSentence = 20
+	}
+	r := rand.New(rand.NewSource(o.Seed))
+	total := o.Sentences * o.WordsPerSentence
+
+	// the positions of the words mentioning an entity, never a final period
+	var mentions []int
+	for _, p := range r.Perm(total) {
+		if len(mentions) == o.Entities {
+			break
+		}
+		if p%o.WordsPerSentence != o.WordsPerSentence-1 {
+			mentions = append(mentions, p)
+		}
+	}
+	sort.Ints(mentions)
+
+	var b strings.Builder
+	sentences := make([]generatedSentence, o.Sentences)
+	entities := make([]generatedAnalysisEntity, 0, len(mentions))
+	position := 0
+	for s := range sentences {
+		words := make([]generatedAnalysisWord, o.WordsPerSentence)
+		for i := range words {
+			w := generatedVocabulary[r.Intn(len(generatedVocabulary))]
+			if i == len(words)-1 {
+				w = generatedWord{".", ".", ".", "."}
+			} else if len(mentions) > 0 && mentions[0] == position {
+				mentions = mentions[1:]
+				e := generatedEntities[r.Intn(len(generatedEntities))]
+				w = generatedWord{e.id, strings.ToLower(e.id), strings.ToLower(e.id), "NNP"}
+				start := b.Len()
+				if b.Len() > 0 {
+					start++
+				}
+				entities = append(entities, generatedAnalysisEntity{
+					ID: len(entities), Type: e.types, MatchingTokens: []int{position}, EntityID: e.id, EntityEnglishID: e.id,
+					ConfidenceScore: 0.5 + 10*r.Float64(), RelevanceScore: r.Float64(), WikiLink: "http://en.wikipedia.org/wiki/" + e.id,
+					WikidataID: e.wikidataID, MatchedText: e.id, StartingPos: start, EndingPos: start + len(e.id),
+				})
+			}
+			if b.Len() > 0 && w.token != "." {
+				b.WriteByte(' ')
+			}
+			words[i] = generatedAnalysisWord{Position: position, StartingPos: b.Len(), EndingPos: b.Len() + len(w.token),
+				Stem: w.stem, Lemma: w.lemma, Token: w.token, PartOfSpeech: w.pos}
+			if i > 0 {
+				parent := position - 1
+				words[i].ParentPosition, words[i].RelationToParent = &parent, "dep"
+			}
+			b.WriteString(w.token)
+			position++
+		}
+		sentences[s] = generatedSentence{Position: s, Words: words}
+	}
+
+	topics := make([]generatedTopic, o.Topics)
+	for i := range topics {
+		label := generatedTopics[i%len(generatedTopics)]
+		if i >= len(generatedTopics) {
+			label = fmt.Sprintf("%s %d", label, i/len(generatedTopics))
+		}
+		topics[i] = generatedTopic{ID: i, Label: label, Score: 1 - float64(i)/float64(o.Topics+1),
+			WikiLink: "http://en.wikipedia.org/Category:" + strings.ReplaceAll(label, " ", "_"), WikidataID: fmt.Sprintf("Q%d", 1000+i)}
+	}
+
+	response := map[string]interface{}{
+		"response": map[string]interface{}{
+			"language":           "eng",
+			"languageIsReliable": true,
+			"sentences":          sentences,
+			"entities":           entities,
+			"topics":             topics,
+		},
+		"time": 0.0123,
+		"ok":   true,
+	}
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+	return b.String(), string(encoded)
+}
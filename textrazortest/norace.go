@@ -0,0 +1,7 @@
+//go:build !race
+
+package textrazortest
+
+// RaceEnabled reports whether the tests run with the race detector, which allocates on its own
+// and makes the allocation counts unreliable
+const RaceEnabled = false
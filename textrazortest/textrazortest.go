@@ -0,0 +1,115 @@
+// Package textrazortest provides recorded TextRazor API responses and a fake
+// http.RoundTripper, so code using the textrazor package can be tested
+// without making real API calls.
+//
+// Every Analysis* fixture is a response for the same document (see Text), with
+// consistent word positions and character offsets across extractors.
+package textrazortest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// AnalysisFixtures maps the name of each Analysis* fixture to its body
+var AnalysisFixtures = map[string]string{
+	"AnalysisEntities":          AnalysisEntities,
+	"AnalysisCustomEntities":    AnalysisCustomEntities,
+	"AnalysisTopics":            AnalysisTopics,
+	"AnalysisCategories":        AnalysisCategories,
+	"AnalysisEntailments":       AnalysisEntailments,
+	"AnalysisRelations":         AnalysisRelations,
+	"AnalysisNounPhrases":       AnalysisNounPhrases,
+	"AnalysisWords":             AnalysisWords,
+	"AnalysisDependencyTrees":   AnalysisDependencyTrees,
+	"AnalysisSenses":            AnalysisSenses,
+	"AnalysisSpelling":          AnalysisSpelling,
+	"AnalysisCustomAnnotations": AnalysisCustomAnnotations,
+	"AnalysisFull":              AnalysisFull,
+}
+
+// Transport is a minimal http.RoundTripper replying to every request
+// with the same status and body
+type Transport struct {
+	Status int
+	Body   string
+}
+
+// NewTransport returns a Transport replying with the given status and body
+func NewTransport(status int, body string) *Transport {
+	return &Transport{Status: status, Body: body}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	response := &http.Response{
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Request:    req,
+		StatusCode: t.Status,
+		Body:       ioutil.NopCloser(strings.NewReader(t.Body)),
+	}
+	return response, nil
+}
+
+// Reply defines a response sent by a SequenceTransport
+type Reply struct {
+	Status int
+	Header http.Header
+	Body   string
+}
+
+// SequenceTransport is a http.RoundTripper sending Replies in order, the last one is repeated.
+// It is safe for concurrent use.
+type SequenceTransport struct {
+	Replies []Reply
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+// NewSequenceTransport returns a SequenceTransport sending the given replies
+func NewSequenceTransport(replies ...Reply) *SequenceTransport {
+	return &SequenceTransport{Replies: replies}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *SequenceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	t.mu.Lock()
+	n := len(t.requests)
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+
+	if len(t.Replies) == 0 {
+		return nil, fmt.Errorf("no reply configured")
+	}
+	if n >= len(t.Replies) {
+		n = len(t.Replies) - 1
+	}
+	reply := t.Replies[n]
+	header := http.Header{"Content-Type": {"application/json"}}
+	for k, v := range reply.Header {
+		header[k] = v
+	}
+	return &http.Response{
+		Header:     header,
+		Request:    req,
+		StatusCode: reply.Status,
+		Body:       ioutil.NopCloser(strings.NewReader(reply.Body)),
+	}, nil
+}
+
+// Requests returns the requests received so far
+func (t *SequenceTransport) Requests() []*http.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*http.Request(nil), t.requests...)
+}
@@ -0,0 +1,76 @@
+package textrazor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// default timeouts of the transport created by DefaultTransport
+const (
+	DefaultDialTimeout           = 10 * time.Second
+	DefaultTLSHandshakeTimeout   = 10 * time.Second
+	DefaultResponseHeaderTimeout = 2 * time.Minute
+)
+
+// TimeoutTransport creates a compressed or uncompressed http.Transport with the given timeouts, 0 means no timeout
+//
+// responseHeader limits the time waiting for the API once the request is sent, it includes the analysis time
+func TimeoutTransport(useCompression bool, dial, tlsHandshake, responseHeader time.Duration) http.RoundTripper {
+	return &http.Transport{
+		DisableCompression:    !useCompression,
+		DialContext:           (&net.Dialer{Timeout: dial}).DialContext,
+		TLSHandshakeTimeout:   tlsHandshake,
+		ResponseHeaderTimeout: responseHeader,
+	}
+}
+
+// WithTimeout limits the duration of every API call to d, including rate limit retries, 0 means no timeout
+//
+// it can be changed for a single call with CallTimeout
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = d }
+}
+
+// CallTimeout limits the duration of a single API call to d, overriding the client timeout
+func CallTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) { o.timeout = d }
+}
+
+// withTimeout returns a context bounded by the call or client timeout
+func (c *Client) withTimeout(ctx context.Context, o *callOptions) (context.Context, context.CancelFunc) {
+	timeout := c.timeout
+	if o.timeout > 0 {
+		timeout = o.timeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// WithDecodeReserve reserves a fraction, between 0 and 1, of the time left before the deadline of each call
+// to decode the response. The HTTP exchange, including the reading of the body as it is decoded, is canceled
+// when the rest is elapsed, so a slow response cannot exceed the deadline unnoticed.
+// It has no effect on calls without a deadline or timeout.
+//
+// it can be changed for a single call with CallDecodeReserve
+func WithDecodeReserve(fraction float64) Option {
+	return func(c *Client) { c.decodeReserve = fraction }
+}
+
+// CallDecodeReserve is like WithDecodeReserve for a single call
+func CallDecodeReserve(fraction float64) CallOption {
+	return func(o *callOptions) { o.decodeReserve = fraction }
+}
+
+// reserveDeadline returns a context whose deadline leaves the given fraction of the remaining time of ctx
+func reserveDeadline(ctx context.Context, fraction float64) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || fraction <= 0 || fraction >= 1 {
+		return ctx, func() {}
+	}
+	remaining := time.Until(deadline)
+	return context.WithTimeout(ctx, remaining-time.Duration(float64(remaining)*fraction))
+}
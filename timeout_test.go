@@ -0,0 +1,116 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+//***************************************************************
+// 			Timeout tests
+
+// hangingTransport never replies, until the request is canceled
+type hangingTransport struct{}
+
+func (hangingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestTimeouts(t *testing.T) {
+	var tests = []struct {
+		name       string
+		client     time.Duration
+		call       []CallOption
+		expectHang bool
+	}{
+		{"client timeout", 10 * time.Millisecond, nil, false},
+		{"call timeout", time.Hour, []CallOption{CallTimeout(10 * time.Millisecond)}, false},
+		{"no timeout", 0, nil, true},
+	}
+
+	for _, tt := range tests {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, hangingTransport{}, WithTimeout(tt.client))
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		start := time.Now()
+		_, err := client.GetAccountContext(ctx, tt.call...)
+		elapsed := time.Since(start)
+		cancel()
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error(tt.name, "expect a deadline exceeded error, got", err)
+		}
+		if hung := elapsed >= 200*time.Millisecond; hung != tt.expectHang {
+			t.Error(tt.name, "expect hang", tt.expectHang, "got", elapsed)
+		}
+	}
+}
+
+func TestAnalyzeTimeout(t *testing.T) {
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, hangingTransport{}, WithConcurrencyLimit(1))
+	// hold the only slot, so the call times out while waiting for it
+	release, err := client.concurrency.acquire(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	_, err = client.AnalyzeTextContext(context.Background(), testText, Params{"extractors": {"entities"}}, CallTimeout(10*time.Millisecond))
+	var deadlineErr *DeadlineError
+	if !errors.As(err, &deadlineErr) || deadlineErr.Breakdown[0].Phase != PhaseConcurrency {
+		t.Error("expect the timeout to include the concurrency limit, got", err)
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	transport := TimeoutTransport(DefaultUseCompression, time.Second, time.Second, 20*time.Millisecond)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, false, server.URL, server.URL, transport)
+	start := time.Now()
+	if _, err := client.GetAccount(); err == nil {
+		t.Error("expect a response header timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Error("expect the request to time out early, got", elapsed)
+	}
+
+	if dt, ok := DefaultTransport(true).(*http.Transport); !ok || dt.TLSHandshakeTimeout != DefaultTLSHandshakeTimeout || dt.ResponseHeaderTimeout != DefaultResponseHeaderTimeout || dt.DisableCompression {
+		t.Error("expect the default transport to have the default timeouts")
+	}
+}
+
+func TestDecodeReserve(t *testing.T) {
+	var tests = []struct {
+		name   string
+		client []Option
+		call   []CallOption
+		expect time.Duration
+	}{
+		{"no reserve", nil, []CallOption{CallTimeout(100 * time.Millisecond)}, 100 * time.Millisecond},
+		{"client reserve", []Option{WithDecodeReserve(0.5)}, []CallOption{CallTimeout(100 * time.Millisecond)}, 50 * time.Millisecond},
+		{"call reserve", []Option{WithDecodeReserve(0.1)}, []CallOption{CallTimeout(100 * time.Millisecond), CallDecodeReserve(0.5)}, 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, hangingTransport{}, tt.client...)
+		start := time.Now()
+		_, err := client.GetAccountContext(context.Background(), tt.call...)
+		elapsed := time.Since(start)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error(tt.name, "expect a deadline exceeded error, got", err)
+		}
+		if elapsed < tt.expect || elapsed > tt.expect+40*time.Millisecond {
+			t.Error(tt.name, "expect the HTTP exchange to be canceled after", tt.expect, "got", elapsed)
+		}
+	}
+}
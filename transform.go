@@ -0,0 +1,163 @@
+package textrazor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextTransform transforms a text before its analysis, e.g. to normalize it,
+// and returns the OffsetMap mapping the offsets of the transformed text back to the text
+type TextTransform interface {
+	Transform(text string) (string, *OffsetMap)
+}
+
+// TextTransformFunc is a function implementing TextTransform
+type TextTransformFunc func(text string) (string, *OffsetMap)
+
+// Transform implements TextTransform
+func (f TextTransformFunc) Transform(text string) (string, *OffsetMap) { return f(text) }
+
+// WithTextTransform transforms the texts before their analysis, in order. The offsets of the entities and words
+// of the analyses, and their rawText, then refer to the original text, while cleanedText is the transformed text.
+func WithTextTransform(transforms ...TextTransform) Option {
+	return func(c *Client) {
+		c.transforms = append(c.transforms, transforms...)
+	}
+}
+
+// NormalizeWith returns a TextTransform applying a Unicode normalization, such as NFC or NFKC, to texts
+// so the same content is analyzed the same way whatever its normalization form, e.g. with golang.org/x/text/unicode/norm:
+//
+//	textrazor.WithTextTransform(textrazor.NormalizeWith(norm.NFC.String))
+//
+// normalize is applied to each segment of a base character and its combining marks,
+// whose offsets are mapped back to the original segment.
+func NormalizeWith(normalize func(string) string) TextTransform {
+	return TextTransformFunc(func(text string) (string, *OffsetMap) {
+		var (
+			b     strings.Builder
+			m     = &OffsetMap{}
+			start = 0
+		)
+		flush := func(end int) {
+			if end > start {
+				segment := text[start:end]
+				normalized := normalize(segment)
+				b.WriteString(normalized)
+				m.Add(utf8.RuneCountInString(segment), utf8.RuneCountInString(normalized))
+			}
+			start = end
+		}
+		for i, r := range text {
+			if i > 0 && !unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me) {
+				flush(i)
+			}
+		}
+		flush(len(text))
+		return b.String(), m
+	})
+}
+
+// OffsetMap maps the code point offsets of a transformed text to the offsets of the original text.
+// It is built by adding the lengths of the successive segments of both texts, an offset inside
+// a segment of the same length in both texts is mapped to the same position in the original segment,
+// and otherwise to its start or its end.
+type OffsetMap struct {
+	segments []offsetSegment
+}
+
+type offsetSegment struct {
+	original, transformed       int
+	originalLen, transformedLen int
+}
+
+// Add appends a segment of originalLen code points transformed into transformedLen code points
+func (m *OffsetMap) Add(originalLen, transformedLen int) {
+	s := offsetSegment{originalLen: originalLen, transformedLen: transformedLen}
+	if n := len(m.segments); n > 0 {
+		last := &m.segments[n-1]
+		s.original, s.transformed = last.original+last.originalLen, last.transformed+last.transformedLen
+		// consecutive unchanged lengths are mapped linearly, a single segment is enough
+		if originalLen == transformedLen && last.originalLen == last.transformedLen {
+			last.originalLen += originalLen
+			last.transformedLen += transformedLen
+			return
+		}
+	}
+	m.segments = append(m.segments, s)
+}
+
+// Start maps the offset where a span of the transformed text starts
+func (m *OffsetMap) Start(offset int) int {
+	return m.original(offset, false)
+}
+
+// End maps the offset where a span of the transformed text ends
+func (m *OffsetMap) End(offset int) int {
+	return m.original(offset, true)
+}
+
+func (m *OffsetMap) original(offset int, end bool) int {
+	if m == nil || len(m.segments) == 0 {
+		return offset
+	}
+	// the segment holding offset, the last segment starting before or at offset
+	i := sort.Search(len(m.segments), func(i int) bool { return m.segments[i].transformed > offset }) - 1
+	if i < 0 {
+		return offset
+	}
+	s := m.segments[i]
+	inside := offset - s.transformed
+	switch {
+	case inside >= s.transformedLen:
+		// past the end of the text
+		return s.original + s.originalLen + inside - s.transformedLen
+	case s.originalLen == s.transformedLen:
+		return s.original + inside
+	case inside == 0:
+		return s.original
+	case end:
+		return s.original + s.originalLen
+	}
+	return s.original
+}
+
+// Remap maps the offsets of the entities and the words of an analysis of the transformed text
+func (m *OffsetMap) Remap(a *Analysis) {
+	for i := range a.Entities {
+		e := &a.Entities[i]
+		e.StartingPos, e.EndingPos = m.Start(e.StartingPos), m.End(e.EndingPos)
+	}
+	for i := range a.Sentences {
+		for j := range a.Sentences[i].Words {
+			w := &a.Sentences[i].Words[j]
+			w.StartingPos, w.EndingPos = m.Start(w.StartingPos), m.End(w.EndingPos)
+		}
+	}
+}
+
+// analyzeTransformed analyzes the text of params transformed by the client, with the offsets of the original text
+func (c *Client) analyzeTransformed(ctx context.Context, params Params, opts ...CallOption) (*Analysis, error) {
+	original := params.Get("text")
+	text, maps := original, make([]*OffsetMap, len(c.transforms))
+	for i, t := range c.transforms {
+		text, maps[i] = t.Transform(text)
+	}
+	p := copyParams(params)
+	p.Set("text", text)
+
+	a, err := c.analyzeParams(ctx, p, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(maps) - 1; i >= 0; i-- {
+		maps[i].Remap(a)
+	}
+	if a.RawText != "" {
+		a.RawText = original
+	}
+	return a, nil
+}
@@ -0,0 +1,127 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Text transforms tests
+
+// toyNormalize composes "e" and a combining acute accent, and decomposes the "fi" ligature,
+// like NFC and NFKC would
+var toyNormalize = strings.NewReplacer("e\u0301", "é", "\ufb01", "fi").Replace
+
+func TestNormalizeWith(t *testing.T) {
+	text := "Caf" + "e\u0301" + " \ufb01nance"
+	normalized, m := NormalizeWith(toyNormalize).Transform(text)
+	if normalized != "Café finance" {
+		t.Fatal("unexpected normalization", normalized)
+	}
+
+	// offsets in the normalized text, and in the original text
+	var tests = []struct {
+		start, end                  int
+		expectStart, expectEnd      int
+		expectNormalized, expectRaw string
+	}{
+		{0, 4, 0, 5, "Café", "Caf" + "e\u0301"},
+		{5, 12, 6, 12, "finance", "\ufb01nance"},
+		{6, 12, 6, 12, "inance", "\ufb01nance"},
+		{5, 6, 6, 7, "f", "\ufb01"},
+		{1, 3, 1, 3, "af", "af"},
+	}
+	for _, tt := range tests {
+		if got := string([]rune(normalized)[tt.start:tt.end]); got != tt.expectNormalized {
+			t.Fatal("bad test, expect", tt.expectNormalized, "got", got)
+		}
+		e := Entity{StartingPos: m.Start(tt.start), EndingPos: m.End(tt.end)}
+		if e.StartingPos != tt.expectStart || e.EndingPos != tt.expectEnd || e.Mention(text) != tt.expectRaw {
+			t.Errorf("%s: expect [%d:%d] %q, got [%d:%d] %q", tt.expectNormalized, tt.expectStart, tt.expectEnd, tt.expectRaw, e.StartingPos, e.EndingPos, e.Mention(text))
+		}
+	}
+}
+
+func TestOffsetMap(t *testing.T) {
+	m := &OffsetMap{}
+	m.Add(3, 3)
+	m.Add(2, 5)
+	m.Add(4, 4)
+	m.Add(1, 1)
+	if len(m.segments) != 3 {
+		t.Error("expect unchanged segments to be merged, got", m.segments)
+	}
+	var tests = []struct {
+		offset     int
+		start, end int
+	}{
+		{0, 0, 0},
+		{2, 2, 2},
+		{3, 3, 3},
+		{4, 3, 5},
+		{8, 5, 5},
+		{10, 7, 7},
+		{13, 10, 10},
+		{15, 12, 12},
+	}
+	for _, tt := range tests {
+		if start, end := m.Start(tt.offset), m.End(tt.offset); start != tt.start || end != tt.end {
+			t.Error(tt.offset, "expect", tt.start, tt.end, "got", start, end)
+		}
+	}
+	var empty *OffsetMap
+	if empty.Start(4) != 4 || empty.End(4) != 4 {
+		t.Error("expect a nil map to keep offsets")
+	}
+}
+
+// echoTransport replies with an entity and a word for each word of the analyzed text
+type echoTransport struct {
+	texts []string
+}
+
+func (t *echoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.ParseForm()
+	text := req.PostForm.Get("text")
+	t.texts = append(t.texts, text)
+	a := Analysis{RawText: text, CleanedText: text, Sentences: []Sentence{{}}}
+	offset := 0
+	for i, w := range strings.Split(text, " ") {
+		start, end := offset, offset+utf8.RuneCountInString(w)
+		a.Entities = append(a.Entities, Entity{MatchedText: w, StartingPos: start, EndingPos: end})
+		a.Sentences[0].Words = append(a.Sentences[0].Words, Word{Position: i, Token: w, StartingPos: start, EndingPos: end})
+		offset = end + 1
+	}
+	b, _ := json.Marshal(map[string]interface{}{"ok": true, "response": a})
+	return textrazortest.NewTransport(http.StatusOK, string(b)).RoundTrip(req)
+}
+
+func TestWithTextTransform(t *testing.T) {
+	transport := &echoTransport{}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithTextTransform(NormalizeWith(toyNormalize)))
+
+	text := "Cafe\u0301 \ufb01nance"
+	a, err := client.AnalyzeText(text, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.texts[0] != "Café finance" {
+		t.Error("expect the normalized text to be sent, got", transport.texts[0])
+	}
+	if a.RawText != text || a.CleanedText != "Café finance" {
+		t.Error("expect the raw text to be the original text, got", a.RawText, a.CleanedText)
+	}
+	for i, expect := range []string{"Cafe\u0301", "\ufb01nance"} {
+		if got := a.Entities[i].Mention(text); got != expect {
+			t.Errorf("expect entity %d to match %q, got %q", i, expect, got)
+		}
+		if w := a.Sentences[0].Words[i]; w.StartingPos != a.Entities[i].StartingPos || w.EndingPos != a.Entities[i].EndingPos {
+			t.Error("expect the words to be remapped, got", w)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package textrazor
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// The transports below wrap an http.RoundTripper, http.DefaultTransport when nil, and compose with the transport
+// of NewCustomClient, e.g. to retry, throttle or log the requests of other clients of the API:
+//
+//	transport := &textrazor.HeaderTransport{
+//		Headers:   http.Header{"X-Team": {"search"}},
+//		Transport: &textrazor.RetryTransport{MaxRetries: 3, Transport: textrazor.DialerTransport(true, dialer)},
+//	}
+
+// RetryTransport retries the requests rate limited by the API, or rejected while it is unavailable,
+// waiting for the Retry-After delay of the response or 1 second doubled on each attempt.
+// The requests with a body are only retried when the body can be read again, see http.Request.GetBody.
+type RetryTransport struct {
+	Transport http.RoundTripper
+	// MaxRetries is the number of retries of a request
+	MaxRetries int
+	// MaxWait is the longest delay waited before a retry, longer delays aren't retried, 0 means no limit
+	MaxWait time.Duration
+	// Statuses are the response status codes retried, 429 and 503 when nil
+	Statuses []int
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.Body != nil && req.Body != http.NoBody {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+		resp, err := roundTripper(t.Transport).RoundTrip(r)
+		if err != nil || attempt >= t.MaxRetries || !t.retried(resp.StatusCode) ||
+			req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+		wait := retryAfter(&HTTPResponse{Headers: resp.Header})
+		if wait <= 0 {
+			wait = defaultRetryWait << uint(attempt)
+		}
+		if t.MaxWait > 0 && wait > t.MaxWait {
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *RetryTransport) retried(status int) bool {
+	if t.Statuses == nil {
+		return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+	}
+	for _, s := range t.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// LoggingTransport logs the method, URL, status and duration of every request to Logger,
+// the headers aren't logged as they hold the API key
+type LoggingTransport struct {
+	Transport http.RoundTripper
+	Logger    Logger
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := roundTripper(t.Transport).RoundTrip(req)
+	if err != nil {
+		t.Logger.Printf("textrazor: %s %s failed in %v: %v", req.Method, req.URL, time.Since(start), err)
+		return nil, err
+	}
+	t.Logger.Printf("textrazor: %s %s %d in %v", req.Method, req.URL, resp.StatusCode, time.Since(start))
+	return resp, nil
+}
+
+// RateLimitTransport waits for Limiter before sending each request, see NewRateLimiter
+type RateLimitTransport struct {
+	Transport http.RoundTripper
+	Limiter   RateLimiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return roundTripper(t.Transport).RoundTrip(req)
+}
+
+// HeaderTransport sets Headers on every request, replacing the headers of the same name
+type HeaderTransport struct {
+	Transport http.RoundTripper
+	Headers   http.Header
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *HeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper mustn't modify the request
+	r := req.Clone(req.Context())
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+	for k, v := range t.Headers {
+		r.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+	return roundTripper(t.Transport).RoundTrip(r)
+}
+
+// roundTripper returns t, or http.DefaultTransport when it is nil
+func roundTripper(t http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return http.DefaultTransport
+	}
+	return t
+}
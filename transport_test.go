@@ -0,0 +1,102 @@
+package textrazor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			RoundTripper middlewares tests
+
+func TestTransports(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	var logs bytes.Buffer
+	sequence := textrazortest.NewSequenceTransport(
+		textrazortest.Reply{Status: http.StatusServiceUnavailable, Body: textrazortest.Error},
+		rateLimited,
+		textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	limiter := &countingLimiter{}
+	transport := &HeaderTransport{
+		Headers: http.Header{"x-team": {"search"}},
+		Transport: &RateLimitTransport{Limiter: limiter, Transport: &RetryTransport{MaxRetries: 2,
+			Transport: &LoggingTransport{Logger: log.New(&logs, "", 0), Transport: sequence}}},
+	}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+
+	a, err := client.AnalyzeText(testText, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Entities) == 0 || limiter.calls != 1 {
+		t.Errorf("expect the analysis rate limited once, got %d entities and %d waits", len(a.Entities), limiter.calls)
+	}
+
+	requests := sequence.Requests()
+	if len(requests) != 3 {
+		t.Fatal("expect 2 retries, got", len(requests))
+	}
+	for i, req := range requests {
+		body, _ := req.GetBody()
+		b, _ := io.ReadAll(body)
+		if req.Header.Get("X-Team") != "search" || !strings.Contains(string(b), "extractors=entities") {
+			t.Errorf("expect the header and the body in the attempt %d, got %v %s", i, req.Header, b)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "textrazor: POST "+DefaultSecureEndpoint) || !strings.Contains(lines[0], " 503 in ") || !strings.Contains(lines[2], " 200 in ") {
+		t.Error("expect the 3 attempts logged, got", lines)
+	}
+	if strings.Contains(logs.String(), testAPIKey) {
+		t.Error("expect the API key not logged")
+	}
+}
+
+func TestRetryTransportLimits(t *testing.T) {
+	defer func(d time.Duration) { defaultRetryWait = d }(defaultRetryWait)
+	defaultRetryWait = time.Millisecond
+
+	var tests = []struct {
+		transport *RetryTransport
+		reply     textrazortest.Reply
+		expect    int
+	}{
+		{&RetryTransport{MaxRetries: 1}, rateLimited, 2},
+		{&RetryTransport{MaxRetries: 3, MaxWait: time.Second}, textrazortest.Reply{Status: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"5"}}}, 1},
+		{&RetryTransport{MaxRetries: 3}, textrazortest.Reply{Status: http.StatusBadRequest, Body: textrazortest.Error}, 1},
+		{&RetryTransport{MaxRetries: 1, Statuses: []int{http.StatusBadRequest}}, textrazortest.Reply{Status: http.StatusBadRequest, Body: textrazortest.Error}, 2},
+	}
+	for i, tt := range tests {
+		sequence := textrazortest.NewSequenceTransport(tt.reply)
+		tt.transport.Transport = sequence
+		req, _ := http.NewRequest(http.MethodPost, DefaultEndpoint, strings.NewReader("text=a"))
+		resp, err := tt.transport.RoundTrip(req)
+		if err != nil || resp.StatusCode != tt.reply.Status || len(sequence.Requests()) != tt.expect {
+			t.Errorf("%d: expect %d requests, got %d %v", i, tt.expect, len(sequence.Requests()), err)
+		}
+	}
+
+	// the body can't be sent again
+	sequence := textrazortest.NewSequenceTransport(rateLimited)
+	req, _ := http.NewRequest(http.MethodPost, DefaultEndpoint, io.NopCloser(strings.NewReader("text=a")))
+	if _, err := (&RetryTransport{MaxRetries: 3, Transport: sequence}).RoundTrip(req); err != nil || len(sequence.Requests()) != 1 {
+		t.Error("expect no retry without GetBody, got", len(sequence.Requests()), err)
+	}
+
+	// the context is canceled while waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, DefaultEndpoint, nil)
+	if _, err := (&RetryTransport{MaxRetries: 3, Transport: textrazortest.NewSequenceTransport(rateLimited)}).RoundTrip(req); err != context.Canceled {
+		t.Error("expect the context error, got", err)
+	}
+}
@@ -0,0 +1,150 @@
+package textrazor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxTextSize is the largest text accepted by the API, in bytes
+const DefaultMaxTextSize = 200 * 1024
+
+// maxTruncationRetries is the number of times a text rejected as too large is truncated further, see WithTruncation
+const maxTruncationRetries = 3
+
+// WithTruncation truncates the texts larger than maxBytes with TruncateToLimit before analyzing them,
+// DefaultMaxTextSize if maxBytes is 0, so long documents are analyzed partially instead of failing.
+// A text still rejected as too large, e.g. when the plan has a lower limit, is truncated to 3/4 of its size
+// and sent again, up to 3 times, each attempt using a request of the quota.
+//
+// The size of the text before truncation is set in the CallMeta of the analysis.
+func WithTruncation(maxBytes int) Option {
+	return func(c *Client) {
+		if maxBytes <= 0 {
+			maxBytes = DefaultMaxTextSize
+		}
+		c.maxTextSize = maxBytes
+	}
+}
+
+// TruncateToLimit returns the longest prefix of text of at most bytes bytes ending on a sentence boundary,
+// or on a word boundary when the first sentence is longer, or on a character boundary when the first word is.
+// Sentences end with a period, an exclamation or a question mark followed by a space, a line break,
+// or the ideographic full stop. The words of scripts without spaces, e.g. Chinese or Japanese, are their characters.
+//
+// The trailing spaces of the prefix are removed, text is returned as is when it is short enough.
+func TruncateToLimit(text string, bytes int) string {
+	if len(text) <= bytes {
+		return text
+	}
+	if bytes <= 0 {
+		return ""
+	}
+	cut := bytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	end := sentenceEnd(text, cut)
+	if end <= 0 {
+		end = wordEnd(text, cut)
+	}
+	if end <= 0 {
+		end = cut
+	}
+	return strings.TrimRightFunc(text[:end], unicode.IsSpace)
+}
+
+// sentenceEnd returns the end of the last sentence of text ending before cut, 0 if there is none
+func sentenceEnd(text string, cut int) int {
+	for i := cut; i > 0; {
+		r, size := utf8.DecodeLastRuneInString(text[:i])
+		i -= size
+		switch r {
+		case '\n':
+			if strings.TrimSpace(text[:i]) != "" {
+				return i
+			}
+		case '。', '！', '？', '｡':
+			return i + size
+		case '.', '!', '?':
+			end := i + size
+			// closing quotes and brackets belong to the sentence
+			for end < cut {
+				c, n := utf8.DecodeRuneInString(text[end:])
+				if !unicode.In(c, unicode.Pe, unicode.Pf) && c != '"' && c != '\'' {
+					break
+				}
+				end += n
+			}
+			next, _ := utf8.DecodeRuneInString(text[end:])
+			if end <= cut && unicode.IsSpace(next) && !(r == '.' && isInitial(text[:i])) {
+				return end
+			}
+		}
+	}
+	return 0
+}
+
+// isInitial reports whether text ends with a single letter, e.g. the "J" of "J. Smith", which doesn't end a sentence
+func isInitial(text string) bool {
+	r, size := utf8.DecodeLastRuneInString(text)
+	if !unicode.IsUpper(r) {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(text[:len(text)-size])
+	return len(text) == size || !unicode.IsLetter(before)
+}
+
+// wordEnd returns the end of the last word of text ending before cut, 0 if there is none
+func wordEnd(text string, cut int) int {
+	if r, _ := utf8.DecodeLastRuneInString(text[:cut]); unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+		return cut
+	}
+	if next, _ := utf8.DecodeRuneInString(text[cut:]); unicode.IsSpace(next) {
+		return cut
+	}
+	if i := strings.LastIndexFunc(text[:cut], unicode.IsSpace); i > 0 {
+		return i
+	}
+	return 0
+}
+
+// truncateText truncates the text of params to the size limit of the client,
+// and returns the size of the text before truncation, 0 if it wasn't truncated
+func (c *Client) truncateText(params Params) (Params, int) {
+	text := params.Get("text")
+	if c.maxTextSize <= 0 || len(text) <= c.maxTextSize {
+		return params, 0
+	}
+	params = copyParams(params)
+	params.Set("text", TruncateToLimit(text, c.maxTextSize))
+	return params, len(text)
+}
+
+// analyzeWithinSize sends an analysis request, truncating the text further while the API rejects it as too large
+func (c *Client) analyzeWithinSize(ctx context.Context, timer *callTimer, params Params, truncatedFrom int, opts ...CallOption) (*Analysis, error) {
+	analysis, err := c.analyze(ctx, timer, params, opts...)
+	for retry := 0; c.maxTextSize > 0 && errors.Is(err, ErrRequestTooLarge) && retry < maxTruncationRetries; retry++ {
+		text := params.Get("text")
+		smaller := TruncateToLimit(text, len(text)*3/4)
+		if smaller == "" {
+			break
+		}
+		if truncatedFrom == 0 {
+			truncatedFrom = len(text)
+		}
+		c.logf(ctx, "text rejected as too large, truncated from %d to %d bytes", len(text), len(smaller))
+		params = copyParams(params)
+		params.Set("text", smaller)
+		analysis, err = c.analyze(ctx, timer, params, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if analysis.HTTPResponse != nil {
+		analysis.HTTPResponse.Meta.TruncatedFrom = truncatedFrom
+	}
+	return analysis, nil
+}
@@ -0,0 +1,107 @@
+package textrazor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Truncation tests
+
+func TestTruncateToLimit(t *testing.T) {
+	var tests = []struct {
+		text   string
+		bytes  int
+		expect string
+	}{
+		{"Short text.", 100, "Short text."},
+		{"First sentence. Second sentence.", 25, "First sentence."},
+		{"First sentence! Second sentence?", 31, "First sentence!"},
+		{`He said "stop." Then he left.`, 20, `He said "stop."`},
+		{"A headline\nThe body of the article.", 20, "A headline"},
+		{"Version 3.14 is out. More soon.", 17, "Version 3.14 is"},
+		{"Written by J. Smith in London. Next.", 32, "Written by J. Smith in London."},
+		{"Written by J. Smith and others", 14, "Written by J."},
+		{"One very long sentence without any end", 20, "One very long"},
+		{"One very long sentence", 13, "One very long"},
+		{"Unbreakable", 5, "Unbre"},
+		{"café crème", 4, "caf"},
+		{"日本語の文です。次の文です。", 30, "日本語の文です。"},
+		{"日本語の文です", 10, "日本語"},
+		{"Barclays a induit en erreur ses actionnaires. Une enquête l'a révélé.", 60, "Barclays a induit en erreur ses actionnaires."},
+		{"anything", 0, ""},
+	}
+	for _, tt := range tests {
+		got := TruncateToLimit(tt.text, tt.bytes)
+		if got != tt.expect {
+			t.Errorf("%q %d: expect %q, got %q", tt.text, tt.bytes, tt.expect, got)
+		}
+		if len(got) > tt.bytes && got != tt.text || !utf8.ValidString(got) {
+			t.Errorf("%q %d: invalid truncation %q", tt.text, tt.bytes, got)
+		}
+	}
+}
+
+func TestWithTruncation(t *testing.T) {
+	text := strings.Repeat("Barclays misled shareholders. ", 10)
+	transport := textrazortest.NewSequenceTransport(textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport, WithTruncation(100))
+
+	a, err := client.AnalyzeText(text, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent := sentText(t, transport.Requests()[0]); sent != strings.Repeat("Barclays misled shareholders. ", 3)[:89] {
+		t.Errorf("expect the text truncated to 3 sentences, got %q", sent)
+	}
+	if a.HTTPResponse.Meta.TruncatedFrom != len(text) {
+		t.Error("expect the size of the text before truncation, got", a.HTTPResponse.Meta.TruncatedFrom)
+	}
+
+	// the API accepts smaller texts than the limit
+	tooLarge := textrazortest.Reply{Status: http.StatusRequestEntityTooLarge, Body: "<html>Request Entity Too Large</html>"}
+	var logs bytes.Buffer
+	transport = textrazortest.NewSequenceTransport(tooLarge, tooLarge, textrazortest.Reply{Status: http.StatusOK, Body: textrazortest.AnalysisEntities})
+	client = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithTruncation(0), WithLogger(log.New(&logs, "", 0)))
+	if a, err = client.AnalyzeText(text, Params{"extractors": {"entities"}}); err != nil {
+		t.Fatal(err)
+	}
+	var sizes []int
+	for _, req := range transport.Requests() {
+		sizes = append(sizes, len(sentText(t, req)))
+	}
+	if len(sizes) != 3 || sizes[0] != len(text) || sizes[1] > sizes[0]*3/4 || sizes[2] > sizes[1]*3/4 || a.HTTPResponse.Meta.TruncatedFrom != len(text) {
+		t.Errorf("expect the text truncated twice, got the sizes %v and %d", sizes, a.HTTPResponse.Meta.TruncatedFrom)
+	}
+	if !strings.Contains(logs.String(), "text rejected as too large, truncated from 300 to ") {
+		t.Error("expect the truncation logged, got", logs.String())
+	}
+
+	// without truncation, the error is returned
+	transport = textrazortest.NewSequenceTransport(tooLarge)
+	client = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+	if _, err := client.AnalyzeText(text, Params{"extractors": {"entities"}}); !errors.Is(err, ErrRequestTooLarge) || len(transport.Requests()) != 1 {
+		t.Error("expect a single request failing, got", err)
+	}
+}
+
+// sentText returns the text of an analysis request
+func sentText(t *testing.T, req *http.Request) string {
+	body, err := req.GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(body)
+	form, _ := url.ParseQuery(string(b))
+	return form.Get("text")
+}
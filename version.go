@@ -0,0 +1,40 @@
+package textrazor
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// modulePath is the import path of the package
+const modulePath = "github.com/bengentil/textrazor-go"
+
+// Version returns the version of the package in the build of the program, e.g. "v1.4.0",
+// "(devel)" when it is unknown, e.g. in a GOPATH build or the tests of the package
+func Version() string { return version() }
+
+var version = sync.OnceValue(moduleVersion)
+
+// userAgent is sent with every request unless a User-Agent header is set with WithHeaders or CallHeaders
+func userAgent() string { return "textrazor-go/" + Version() }
+
+// moduleVersion returns the version of the module of the package in the build, "(devel)" when it is unknown
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			if dep.Version != "" {
+				return dep.Version
+			}
+		}
+	}
+	return "(devel)"
+}
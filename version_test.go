@@ -0,0 +1,50 @@
+package textrazor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Version tests
+
+// headerTransport records the headers of the last request
+type headerTransport struct {
+	http.RoundTripper
+	headers http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.headers = req.Header.Clone()
+	return t.RoundTripper.RoundTrip(req)
+}
+
+func TestVersion(t *testing.T) {
+	// the tests don't run in a module build of a program depending on the package
+	if v := Version(); v != "(devel)" {
+		t.Errorf("expect the (devel) version, got %q", v)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	transport := &headerTransport{RoundTripper: textrazortest.NewTransport(http.StatusOK, textrazortest.Account)}
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport)
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if ua := transport.headers.Get("User-Agent"); ua != "textrazor-go/"+Version() {
+		t.Errorf("expect the User-Agent of the package, got %q", ua)
+	}
+
+	client = NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, transport,
+		WithHeaders(http.Header{"User-Agent": {"myapp/1.0"}}))
+	if _, err := client.GetAccountContext(context.Background(), ForceRefresh()); err != nil {
+		t.Fatal(err)
+	}
+	if ua := transport.headers.Get("User-Agent"); ua != "myapp/1.0" {
+		t.Errorf("expect the User-Agent set by WithHeaders, got %q", ua)
+	}
+}
@@ -0,0 +1,113 @@
+package textrazor
+
+// AnalysisView is a read-only view of an Analysis, safe to share between goroutines.
+//
+// It holds its own copy of the analysis, and every accessor returns a copy of the field it returns,
+// so a consumer modifying a result cannot affect the other consumers. When the references are resolved,
+// the Words of the returned fields point to copies of the referenced words.
+type AnalysisView struct {
+	a *Analysis
+	// words are the words of the analysis by position, nil if its references aren't resolved
+	words map[int]*Word
+}
+
+// View returns a read-only view of a copy of the analysis
+func (a *Analysis) View() AnalysisView {
+	v := AnalysisView{a: a.clone()}
+	if a.resolveRefs {
+		v.words = v.a.wordsByPosition()
+	}
+	return v
+}
+
+// Analysis returns a modifiable copy of the analysis
+func (v AnalysisView) Analysis() *Analysis {
+	if v.a == nil {
+		return nil
+	}
+	return v.a.clone()
+}
+
+// CustomAnnotationOutput returns the customAnnotationOutput of the analysis
+func (v AnalysisView) CustomAnnotationOutput() string { return v.get().CustomAnnotationOutput }
+
+// Language returns the language of the analysis
+func (v AnalysisView) Language() string { return v.get().Language }
+
+// LanguageIsReliable returns the languageIsReliable of the analysis
+func (v AnalysisView) LanguageIsReliable() bool { return v.get().LanguageIsReliable }
+
+// CleanedText returns the cleanedText of the analysis
+func (v AnalysisView) CleanedText() string { return v.get().CleanedText }
+
+// RawText returns the rawText of the analysis
+func (v AnalysisView) RawText() string { return v.get().RawText }
+
+// Entailments returns a copy of the entailments of the analysis
+func (v AnalysisView) Entailments() []Entailment {
+	s := cloneEntailments(v.get().Entailments)
+	if v.words != nil {
+		resolveEntailments(s, copyingResolver(v.words))
+	}
+	return s
+}
+
+// Entities returns a copy of the entities of the analysis
+func (v AnalysisView) Entities() []Entity {
+	s := cloneEntities(v.get().Entities)
+	if v.words != nil {
+		resolveEntities(s, copyingResolver(v.words))
+	}
+	return s
+}
+
+// Topics returns a copy of the topics of the analysis
+func (v AnalysisView) Topics() []Topic { return append([]Topic(nil), v.get().Topics...) }
+
+// CoarseTopics returns a copy of the coarse topics of the analysis
+func (v AnalysisView) CoarseTopics() []Topic { return append([]Topic(nil), v.get().CoarseTopics...) }
+
+// Categories returns a copy of the categories of the analysis
+func (v AnalysisView) Categories() []ScoredCategory {
+	return append([]ScoredCategory(nil), v.get().Categories...)
+}
+
+// NounPhrases returns a copy of the noun phrases of the analysis
+func (v AnalysisView) NounPhrases() []NounPhrase {
+	s := cloneNounPhrases(v.get().NounPhrases)
+	if v.words != nil {
+		resolveNounPhrases(s, copyingResolver(v.words))
+	}
+	return s
+}
+
+// Properties returns a copy of the properties of the analysis
+func (v AnalysisView) Properties() []Property {
+	s := cloneProperties(v.get().Properties)
+	if v.words != nil {
+		resolveProperties(s, copyingResolver(v.words))
+	}
+	return s
+}
+
+// Relations returns a copy of the relations of the analysis
+func (v AnalysisView) Relations() []Relation {
+	s := cloneRelations(v.get().Relations)
+	if v.words != nil {
+		resolveRelations(s, copyingResolver(v.words))
+	}
+	return s
+}
+
+// Sentences returns a copy of the sentences of the analysis
+func (v AnalysisView) Sentences() []Sentence { return cloneSentences(v.get().Sentences) }
+
+// MatchingRules returns a copy of the matching rules of the analysis
+func (v AnalysisView) MatchingRules() []string { return copyStrings(v.get().MatchingRules) }
+
+func (v AnalysisView) get() *Analysis {
+	if v.a == nil {
+		return &Analysis{}
+	}
+	return v.a
+}
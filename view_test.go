@@ -0,0 +1,69 @@
+package textrazor
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Read-only view tests
+
+func TestAnalysisView(t *testing.T) {
+	for name, body := range map[string]string{"entities": textrazortest.AnalysisEntities, "relations": textrazortest.AnalysisRelations, "words": textrazortest.AnalysisWords} {
+		r := HTTPResponse{Response: &Analysis{}}
+		if err := json.Unmarshal([]byte(body), &r); err != nil {
+			t.Fatal(name, err)
+		}
+		analysis := r.Response.(*Analysis)
+		analysis.ResolveReferences()
+		view := analysis.View()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				entities := view.Entities()
+				for j := range entities {
+					entities[j].MatchingTokens[0] = -1
+					entities[j].Types = append(entities[j].Types[:0], "mutated")
+				}
+				sentences := view.Sentences()
+				for j := range sentences {
+					sentences[j].Words[0].Token = "mutated"
+				}
+				relations := view.Relations()
+				for j := range relations {
+					relations[j].Words[0].Token = "mutated"
+				}
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(view.Analysis(), analysis) {
+			t.Error(name, "expect the view to be unchanged")
+		}
+		if c := view.Analysis(); len(c.Relations) > 0 && c.Relations[0].Words[0] != &c.Sentences[0].Words[1] {
+			t.Error(name, "expect copied references to point into the copied sentences")
+		}
+		// the accessors copy their field only, with the same references as a whole copy
+		c := view.Analysis()
+		if !reflect.DeepEqual(view.Entities(), c.Entities) || !reflect.DeepEqual(view.Relations(), c.Relations) ||
+			!reflect.DeepEqual(view.NounPhrases(), c.NounPhrases) || !reflect.DeepEqual(view.Properties(), c.Properties) ||
+			!reflect.DeepEqual(view.Entailments(), c.Entailments) || !reflect.DeepEqual(view.Sentences(), c.Sentences) {
+			t.Error(name, "expect the accessors to return the fields of a copy")
+		}
+		if allocs := testing.AllocsPerRun(10, func() { view.Topics() }); !textrazortest.RaceEnabled && allocs > 1 {
+			t.Error(name, "expect the topics to be copied alone, got", allocs, "allocations")
+		}
+	}
+
+	var empty AnalysisView
+	if empty.Entities() != nil || empty.RawText() != "" || empty.Analysis() != nil {
+		t.Error("expect an empty view to be usable")
+	}
+}
@@ -0,0 +1,102 @@
+package textrazor
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// WithMaxEntities keeps the n most relevant entities of each analysis, in their original order,
+// to bound the memory held by analyses of long texts. The other entities are dropped when decoding.
+func WithMaxEntities(n int) Option {
+	return func(c *Client) { c.maxEntities = n }
+}
+
+// EntityWindow returns up to limit entities starting at offset, e.g. to page through thousands of entities.
+// The window shares the memory of Entities, it isn't a copy.
+func (a *Analysis) EntityWindow(offset, limit int) []Entity {
+	if offset < 0 || offset >= len(a.Entities) || limit <= 0 {
+		return nil
+	}
+	end := offset + limit
+	if end > len(a.Entities) || end < offset {
+		end = len(a.Entities)
+	}
+	return a.Entities[offset:end:end]
+}
+
+// TopEntities returns a copy of the k most relevant entities, the most relevant first,
+// entities of the same relevance keep their order. It only copies the k selected entities.
+func (a *Analysis) TopEntities(k int) []Entity {
+	indexes := a.topEntities(k)
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return a.Entities[indexes[i]].RelevanceScore > a.Entities[indexes[j]].RelevanceScore
+	})
+	top := make([]Entity, len(indexes))
+	for i, index := range indexes {
+		top[i] = a.Entities[index]
+	}
+	return top
+}
+
+// capEntities returns a new slice of the n most relevant entities, in their original order
+func (a *Analysis) capEntities(n int) []Entity {
+	indexes := a.topEntities(n)
+	capped := make([]Entity, len(indexes))
+	for i, index := range indexes {
+		capped[i] = a.Entities[index]
+	}
+	return capped
+}
+
+// topEntities returns the sorted indexes of the k most relevant entities,
+// selected with a heap of k indexes rather than sorting every entity
+func (a *Analysis) topEntities(k int) []int {
+	if k <= 0 {
+		return nil
+	}
+	if k >= len(a.Entities) {
+		indexes := make([]int, len(a.Entities))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes
+	}
+
+	h := &entityHeap{entities: a.Entities, indexes: make([]int, 0, k)}
+	for i := range a.Entities {
+		if h.Len() < k {
+			heap.Push(h, i)
+		} else if h.less(h.indexes[0], i) {
+			h.indexes[0] = i
+			heap.Fix(h, 0)
+		}
+	}
+	sort.Ints(h.indexes)
+	return h.indexes
+}
+
+// entityHeap is a min-heap of entity indexes, the least relevant entity first,
+// the later entity first for the same relevance, so the earlier entities are kept
+type entityHeap struct {
+	entities []Entity
+	indexes  []int
+}
+
+// less reports whether the entity i is less relevant than the entity j
+func (h *entityHeap) less(i, j int) bool {
+	si, sj := h.entities[i].RelevanceScore, h.entities[j].RelevanceScore
+	if si != sj {
+		return si < sj
+	}
+	return i > j
+}
+
+func (h *entityHeap) Len() int           { return len(h.indexes) }
+func (h *entityHeap) Less(i, j int) bool { return h.less(h.indexes[i], h.indexes[j]) }
+func (h *entityHeap) Swap(i, j int)      { h.indexes[i], h.indexes[j] = h.indexes[j], h.indexes[i] }
+func (h *entityHeap) Push(x interface{}) { h.indexes = append(h.indexes, x.(int)) }
+func (h *entityHeap) Pop() interface{} {
+	x := h.indexes[len(h.indexes)-1]
+	h.indexes = h.indexes[:len(h.indexes)-1]
+	return x
+}
@@ -0,0 +1,97 @@
+package textrazor
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bengentil/textrazor-go/textrazortest"
+)
+
+//***************************************************************
+// 			Entity windows tests
+
+func windowAnalysis(scores ...float64) *Analysis {
+	a := &Analysis{}
+	for i, s := range scores {
+		a.Entities = append(a.Entities, Entity{ID: i, RelevanceScore: s})
+	}
+	return a
+}
+
+func entityIDs(entities []Entity) []int {
+	ids := []int{}
+	for _, e := range entities {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestEntityWindow(t *testing.T) {
+	a := windowAnalysis(0.1, 0.2, 0.3, 0.4, 0.5)
+	var tests = []struct {
+		offset, limit int
+		expect        []int
+	}{
+		{0, 2, []int{0, 1}},
+		{3, 10, []int{3, 4}},
+		{4, 1, []int{4}},
+		{5, 1, []int{}},
+		{-1, 2, []int{}},
+		{0, 0, []int{}},
+	}
+	for _, tt := range tests {
+		if got := entityIDs(a.EntityWindow(tt.offset, tt.limit)); !reflect.DeepEqual(got, tt.expect) {
+			t.Error(tt.offset, tt.limit, "expect", tt.expect, "got", got)
+		}
+	}
+
+	w := a.EntityWindow(1, 2)
+	w[0].EntityID = "shared"
+	if a.Entities[1].EntityID != "shared" {
+		t.Error("expect the window to share the entities")
+	}
+	if w = append(w, Entity{ID: 42}); a.Entities[3].ID != 3 {
+		t.Error("expect an append to the window to not overwrite the entities")
+	}
+}
+
+func TestTopEntities(t *testing.T) {
+	a := windowAnalysis(0.2, 0.9, 0.1, 0.9, 0.5, 0.2)
+	var tests = []struct {
+		k      int
+		expect []int
+	}{
+		{1, []int{1}},
+		{3, []int{1, 3, 4}},
+		{5, []int{1, 3, 4, 0, 5}},
+		{10, []int{1, 3, 4, 0, 5, 2}},
+		{0, []int{}},
+	}
+	for _, tt := range tests {
+		if got := entityIDs(a.TopEntities(tt.k)); !reflect.DeepEqual(got, tt.expect) {
+			t.Error(tt.k, "expect", tt.expect, "got", got)
+		}
+	}
+	if got := entityIDs(a.capEntities(3)); !reflect.DeepEqual(got, []int{1, 3, 4}) {
+		t.Error("expect capped entities in their original order, got", got)
+	}
+	if got := entityIDs(a.capEntities(4)); !reflect.DeepEqual(got, []int{0, 1, 3, 4}) {
+		t.Error("expect the earlier entity to be kept on ties, got", got)
+	}
+}
+
+func TestWithMaxEntities(t *testing.T) {
+	full := decodeAnalysis(t, textrazortest.AnalysisEntities)
+	client := NewCustomClient(testAPIKey, DefaultUseCompression, DefaultUseEncryption, DefaultEndpoint, DefaultSecureEndpoint, textrazortest.NewTransport(http.StatusOK, textrazortest.AnalysisEntities), WithMaxEntities(2))
+	a, err := client.AnalyzeText(textrazortest.Text, Params{"extractors": {"entities"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Entities) != 2 || cap(a.Entities) != 2 {
+		t.Fatal("expect 2 entities, got", len(a.Entities))
+	}
+	if top := full.capEntities(2); !reflect.DeepEqual(entityIDs(a.Entities), entityIDs(top)) {
+		t.Error("expect the 2 most relevant entities, got", entityIDs(a.Entities))
+	}
+}